@@ -0,0 +1,163 @@
+package api
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/lbryio/lbrytv/app/users"
+	"github.com/lbryio/lbrytv/config"
+	"github.com/lbryio/lbrytv/internal/maintenance"
+	"github.com/lbryio/lbrytv/models"
+
+	"github.com/gorilla/mux"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// AdminAuth only lets through requests carrying AdminToken in `Authorization: Bearer` header.
+// Admin API is disabled if AdminToken is not configured.
+func AdminAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := config.GetAdminToken()
+		if token == "" {
+			writeJSONError(w, http.StatusNotFound, "admin API is disabled")
+			return
+		}
+		supplied := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(supplied), []byte(token)) != 1 {
+			writeJSONError(w, http.StatusUnauthorized, "invalid admin token")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// GetMaintenance returns maintenance mode state of this lbrytv instance.
+func GetMaintenance(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, maintenance.Get())
+}
+
+// SetMaintenance toggles maintenance mode of this lbrytv instance. Request body should contain JSON like
+//
+//	{"enabled": true, "message": "SDK upgrade in progress", "eta": "2020-01-01T12:00:00Z"}
+//
+// Note that it only affects the instance which handles the request,
+// `Maintenance*` config settings should be used for switching all instances.
+func SetMaintenance(w http.ResponseWriter, r *http.Request) {
+	var s maintenance.Status
+	if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	maintenance.Set(s)
+	logger.Log().Infof("maintenance mode set via admin API by %v", r.RemoteAddr)
+	writeJSON(w, http.StatusOK, maintenance.Get())
+}
+
+// InvalidateAuthCache removes cached internal-apis authentication results of this lbrytv instance,
+// for a single user if `user_id` query parameter is supplied or entirely otherwise.
+func InvalidateAuthCache(w http.ResponseWriter, r *http.Request) {
+	if users.AuthCache == nil {
+		writeJSONError(w, http.StatusNotFound, "auth cache is disabled")
+		return
+	}
+	if uid := r.URL.Query().Get("user_id"); uid != "" {
+		id, err := strconv.Atoi(uid)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "invalid user_id")
+			return
+		}
+		users.AuthCache.InvalidateUser(id)
+	} else {
+		users.AuthCache.Flush()
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"cached": users.AuthCache.Count()})
+}
+
+// GetUser returns local user record with a given ID, including when and from where the user was last seen.
+func GetUser(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+	u, err := models.FindUserG(id)
+	if err == sql.ErrNoRows {
+		writeJSONError(w, http.StatusNotFound, "user not found")
+		return
+	} else if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, u)
+}
+
+// ListUsers returns local user records with email supplied in `email` query parameter.
+func ListUsers(w http.ResponseWriter, r *http.Request) {
+	email := r.URL.Query().Get("email")
+	if email == "" {
+		writeJSONError(w, http.StatusBadRequest, "email is required")
+		return
+	}
+	us, err := models.Users(models.UserWhere.Email.EQ(email), qm.OrderBy(models.UserColumns.ID)).AllG()
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if us == nil {
+		us = models.UserSlice{}
+	}
+	writeJSON(w, http.StatusOK, us)
+}
+
+// DeleteUser erases user's wallets, uploaded files and database records, see users.DeleteUser.
+// Wallets are exported to UserExportDir first if `export` query parameter is set to true.
+func DeleteUser(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+	cfg := config.GetUserDeletion()
+	opts := users.DeleteUserOpts{
+		WalletsDir: cfg.WalletsDir,
+		ArchiveDir: cfg.ArchiveDir,
+		UploadDir:  config.GetPublishSourceDir(),
+	}
+	if r.URL.Query().Get("export") == "true" {
+		if cfg.ExportDir == "" {
+			writeJSONError(w, http.StatusBadRequest, "wallet export is disabled")
+			return
+		}
+		opts.ExportDir = cfg.ExportDir
+	}
+	report, err := users.DeleteUser(id, opts)
+	if err == users.ErrUserNotFound {
+		writeJSONError(w, http.StatusNotFound, err.Error())
+		return
+	} else if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	logger.Log().Warnf("user %v deleted via admin API by %v", id, r.RemoteAddr)
+	writeJSON(w, http.StatusOK, report)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// writeMaintenanceError rejects requests creating wallets while maintenance mode is on.
+func writeMaintenanceError(w http.ResponseWriter) {
+	s := maintenance.Get()
+	writeJSON(w, http.StatusServiceUnavailable, map[string]interface{}{"error": s.Message, "maintenance": s})
+}
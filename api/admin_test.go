@@ -0,0 +1,131 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lbryio/lbrytv/app/proxy"
+	"github.com/lbryio/lbrytv/app/users"
+	"github.com/lbryio/lbrytv/config"
+	"github.com/lbryio/lbrytv/internal/maintenance"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutesAdminMaintenance(t *testing.T) {
+	r := mux.NewRouter()
+	InstallRoutes(proxy.NewService(config.GetLbrynet()), r)
+	defer maintenance.Set(maintenance.Status{})
+
+	call := func(method, token, body string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, "/api/v1/admin/maintenance", bytes.NewBufferString(body))
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	config.Override("AdminToken", "")
+	assert.Equal(t, http.StatusNotFound, call("GET", "", "").Code)
+	config.RestoreOverridden()
+
+	config.Override("AdminToken", "adm1nT0ken")
+	defer config.RestoreOverridden()
+
+	assert.Equal(t, http.StatusUnauthorized, call("GET", "", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, call("POST", "wrong", `{"enabled": true}`).Code)
+	assert.False(t, maintenance.Active())
+
+	rr := call("POST", "adm1nT0ken", `{"enabled": true, "message": "upgrading", "eta": "2020-01-01T12:00:00Z"}`)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.True(t, maintenance.Active())
+
+	var s maintenance.Status
+	rr = call("GET", "adm1nT0ken", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &s))
+	assert.True(t, s.Enabled)
+	assert.Equal(t, "upgrading", s.Message)
+	require.NotNil(t, s.ETA)
+	assert.Equal(t, 2020, s.ETA.Year())
+
+	assert.Equal(t, http.StatusBadRequest, call("POST", "adm1nT0ken", `{enabled`).Code)
+
+	rr = call("POST", "adm1nT0ken", `{"enabled": false}`)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.False(t, maintenance.Active())
+}
+
+func TestRoutesAdminAuthCache(t *testing.T) {
+	r := mux.NewRouter()
+	InstallRoutes(proxy.NewService(config.GetLbrynet()), r)
+	config.Override("AdminToken", "adm1nT0ken")
+	defer config.RestoreOverridden()
+
+	call := func(query string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("DELETE", "/api/v1/admin/auth_cache"+query, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer adm1nT0ken")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	assert.Equal(t, http.StatusNotFound, call("").Code)
+
+	users.AuthCache = users.NewTokenCache(users.TokenCacheOpts{TTL: time.Minute})
+	defer func() { users.AuthCache = nil }()
+	users.AuthCache.Set("abc", &users.RemoteUser{ID: 1})
+	users.AuthCache.Set("def", &users.RemoteUser{ID: 2})
+
+	assert.Equal(t, http.StatusBadRequest, call("?user_id=abc").Code)
+	rr := call("?user_id=1")
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"cached": 1}`, rr.Body.String())
+
+	rr = call("")
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"cached": 0}`, rr.Body.String())
+}
+
+func TestRoutesAdminUsersValidation(t *testing.T) {
+	r := mux.NewRouter()
+	InstallRoutes(proxy.NewService(config.GetLbrynet()), r)
+	config.Override("AdminToken", "adm1nT0ken")
+	defer config.RestoreOverridden()
+
+	for _, path := range []string{"/api/v1/admin/users/abc", "/api/v1/admin/users"} {
+		req, err := http.NewRequest("GET", path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer adm1nT0ken")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, path)
+	}
+}
+
+func TestRoutesAdminDeleteUserValidation(t *testing.T) {
+	r := mux.NewRouter()
+	InstallRoutes(proxy.NewService(config.GetLbrynet()), r)
+	config.Override("AdminToken", "adm1nT0ken")
+	config.Override("UserExportDir", "")
+	defer config.RestoreOverridden()
+
+	for _, path := range []string{"/api/v1/admin/users/abc", "/api/v1/admin/users/1?export=true"} {
+		req, err := http.NewRequest("DELETE", path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer adm1nT0ken")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, path)
+	}
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/lbryio/lbrytv/app/users"
+	"github.com/lbryio/lbrytv/internal/maintenance"
+)
+
+// CreateGuest issues a guest token giving access to a new guest wallet, which can be used by clients
+// that are not logged in. The token is returned in response body and set as users.GuestCookie.
+func CreateGuest(w http.ResponseWriter, r *http.Request) {
+	if users.Guests == nil {
+		writeJSONError(w, http.StatusNotFound, "guest wallets are disabled")
+		return
+	}
+	if maintenance.Active() {
+		writeMaintenanceError(w)
+		return
+	}
+	token, g, err := users.Guests.Create(users.GetIPAddressForRequest(r))
+	if err == users.ErrGuestLimitReached {
+		writeJSONError(w, http.StatusTooManyRequests, err.Error())
+		return
+	} else if err != nil {
+		logger.Log().Errorf("cannot create guest: %v", err)
+		writeJSONError(w, http.StatusInternalServerError, "cannot create guest")
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     users.GuestCookie,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https",
+	})
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"token": token, "guest_id": g.ID})
+}
@@ -12,6 +12,7 @@ import (
 func InstallRoutes(proxyService *proxy.Service, r *mux.Router) {
 	authenticator := users.NewAuthenticator(users.NewWalletService())
 	proxyHandler := proxy.NewRequestHandler(proxyService)
+	walletsHandler := NewWalletsHandler(authenticator)
 	upHandler, err := publish.NewUploadHandler(publish.UploadOpts{ProxyService: proxyService})
 	if err != nil {
 		panic(err)
@@ -22,6 +23,17 @@ func InstallRoutes(proxyService *proxy.Service, r *mux.Router) {
 	v1Router.HandleFunc("/proxy", proxyHandler.HandleOptions).Methods("OPTIONS")
 	v1Router.HandleFunc("/proxy", authenticator.Wrap(upHandler.Handle)).MatcherFunc(upHandler.CanHandle)
 	v1Router.HandleFunc("/proxy", proxyHandler.Handle)
+	v1Router.HandleFunc("/proxy/{method}", proxyHandler.HandleGet).Methods("GET")
+	v1Router.HandleFunc("/guest", CreateGuest).Methods("POST")
+	v1Router.HandleFunc("/wallets", walletsHandler.List).Methods("GET")
+	v1Router.HandleFunc("/wallets", walletsHandler.Create).Methods("POST")
+	v1Router.HandleFunc("/wallets/{wallet_id}", walletsHandler.Rename).Methods("PATCH")
+	v1Router.HandleFunc("/admin/maintenance", AdminAuth(GetMaintenance)).Methods("GET")
+	v1Router.HandleFunc("/admin/maintenance", AdminAuth(SetMaintenance)).Methods("POST")
+	v1Router.HandleFunc("/admin/auth_cache", AdminAuth(InvalidateAuthCache)).Methods("DELETE")
+	v1Router.HandleFunc("/admin/users", AdminAuth(ListUsers)).Methods("GET")
+	v1Router.HandleFunc("/admin/users/{id}", AdminAuth(GetUser)).Methods("GET")
+	v1Router.HandleFunc("/admin/users/{id}", AdminAuth(DeleteUser)).Methods("DELETE")
 
 	// TODO: For temporary backwards compatibility, remove after JS code has been updated to use paths above
 	r.HandleFunc("/api/proxy", proxyHandler.HandleOptions).Methods("OPTIONS")
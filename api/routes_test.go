@@ -8,7 +8,9 @@ import (
 
 	"github.com/lbryio/lbrytv/app/proxy"
 	"github.com/lbryio/lbrytv/app/publish"
+	"github.com/lbryio/lbrytv/app/users"
 	"github.com/lbryio/lbrytv/config"
+	"github.com/lbryio/lbrytv/internal/maintenance"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
@@ -30,6 +32,22 @@ func TestRoutesProxy(t *testing.T) {
 	assert.Contains(t, rr.Body.String(), `"result":`)
 }
 
+func TestRoutesProxyGet(t *testing.T) {
+	r := mux.NewRouter()
+	proxy := proxy.NewService(config.GetLbrynet())
+
+	req, err := http.NewRequest("GET", "/api/v1/proxy/status", nil)
+	require.Nil(t, err)
+	rr := httptest.NewRecorder()
+
+	InstallRoutes(proxy, r)
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "public, max-age=60", rr.HeaderMap.Get("Cache-Control"))
+	assert.Contains(t, rr.Body.String(), `"result":`)
+}
+
 func TestRoutesPublish(t *testing.T) {
 	r := mux.NewRouter()
 	proxy := proxy.NewService(config.GetLbrynet())
@@ -61,7 +79,7 @@ func TestRoutesOptions(t *testing.T) {
 	assert.Equal(t, "*", rr.HeaderMap.Get("Access-Control-Allow-Origin"))
 	assert.Equal(
 		t,
-		"X-Lbry-Auth-Token, Origin, X-Requested-With, Content-Type, Accept",
+		"X-Lbry-Auth-Token, X-Lbry-Guest-Token, X-Lbry-Wallet, Authorization, Idempotency-Key, Origin, X-Requested-With, Content-Type, Accept",
 		rr.HeaderMap.Get("Access-Control-Allow-Headers"),
 	)
 
@@ -77,7 +95,54 @@ func TestRoutesOptions(t *testing.T) {
 	assert.Equal(t, "*", rr.HeaderMap.Get("Access-Control-Allow-Origin"))
 	assert.Equal(
 		t,
-		"X-Lbry-Auth-Token, Origin, X-Requested-With, Content-Type, Accept",
+		"X-Lbry-Auth-Token, X-Lbry-Guest-Token, X-Lbry-Wallet, Authorization, Idempotency-Key, Origin, X-Requested-With, Content-Type, Accept",
 		rr.HeaderMap.Get("Access-Control-Allow-Headers"),
 	)
 }
+
+func TestRoutesGuestDisabled(t *testing.T) {
+	r := mux.NewRouter()
+	InstallRoutes(proxy.NewService(config.GetLbrynet()), r)
+
+	req, err := http.NewRequest("POST", "/api/v1/guest", nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.JSONEq(t, `{"error": "guest wallets are disabled"}`, rr.Body.String())
+}
+
+func TestRoutesWalletsUnauthenticated(t *testing.T) {
+	r := mux.NewRouter()
+	InstallRoutes(proxy.NewService(config.GetLbrynet()), r)
+
+	for _, method := range []string{"GET", "POST"} {
+		req, err := http.NewRequest(method, "/api/v1/wallets", bytes.NewBuffer([]byte(`{"name": "Personal"}`)))
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code, method)
+		assert.JSONEq(t, `{"error": "authentication required"}`, rr.Body.String(), method)
+	}
+}
+
+func TestRoutesMaintenanceBlocksWalletCreation(t *testing.T) {
+	r := mux.NewRouter()
+	InstallRoutes(proxy.NewService(config.GetLbrynet()), r)
+	guests, err := users.NewGuestService(users.GuestServiceOpts{Secret: "s3cret"})
+	require.NoError(t, err)
+	users.Guests = guests
+	defer func() { users.Guests = nil }()
+	maintenance.Set(maintenance.Status{Enabled: true, Message: "upgrading"})
+	defer maintenance.Set(maintenance.Status{})
+
+	for _, path := range []string{"/api/v1/guest", "/api/v1/wallets"} {
+		req, err := http.NewRequest("POST", path, bytes.NewBuffer([]byte(`{"name": "Personal"}`)))
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code, path)
+		assert.JSONEq(t, `{"error": "upgrading", "maintenance": {"enabled": true, "message": "upgrading"}}`, rr.Body.String(), path)
+	}
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lbryio/lbrytv/app/users"
+	"github.com/lbryio/lbrytv/internal/maintenance"
+	"github.com/lbryio/lbrytv/models"
+
+	"github.com/gorilla/mux"
+)
+
+// WalletsHandler serves API for managing wallets of the authenticated user.
+type WalletsHandler struct {
+	auth *users.Authenticator
+}
+
+type walletRequest struct {
+	Name string `json:"name"`
+}
+
+// NewWalletsHandler returns WalletsHandler authenticating users with auth.
+func NewWalletsHandler(auth *users.Authenticator) *WalletsHandler {
+	return &WalletsHandler{auth: auth}
+}
+
+// user returns the authenticated user, writing an error response if there isn't one.
+// Credentials limited to specific SDK methods cannot be used for managing wallets.
+func (h *WalletsHandler) user(w http.ResponseWriter, r *http.Request) *models.User {
+	u, scopes, err := h.auth.AuthenticateUser(r)
+	if err != nil {
+		writeJSONError(w, http.StatusUnauthorized, err.Error())
+		return nil
+	}
+	if u == nil {
+		writeJSONError(w, http.StatusUnauthorized, "authentication required")
+		return nil
+	}
+	if scopes != nil {
+		writeJSONError(w, http.StatusForbidden, "scoped credentials are not accepted here")
+		return nil
+	}
+	return u
+}
+
+// List returns wallets of the authenticated user, the default one first.
+func (h *WalletsHandler) List(w http.ResponseWriter, r *http.Request) {
+	u := h.user(w, r)
+	if u == nil {
+		return
+	}
+	wallets, err := users.Wallets.List(u)
+	if err != nil {
+		logger.Log().Errorf("cannot list wallets: %v", err)
+		writeJSONError(w, http.StatusInternalServerError, "cannot list wallets")
+		return
+	}
+	if wallets == nil {
+		wallets = models.WalletSlice{}
+	}
+	writeJSON(w, http.StatusOK, wallets)
+}
+
+// Create makes a new wallet for the authenticated user. Request body should contain JSON like
+//
+//	{"name": "Channel business"}
+func (h *WalletsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if maintenance.Active() {
+		writeMaintenanceError(w)
+		return
+	}
+	var req walletRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	u := h.user(w, r)
+	if u == nil {
+		return
+	}
+	wallet, err := users.Wallets.Create(u, req.Name)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, wallet)
+}
+
+// Rename changes the name of the authenticated user's wallet, request body is the same as for Create.
+func (h *WalletsHandler) Rename(w http.ResponseWriter, r *http.Request) {
+	var req walletRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	u := h.user(w, r)
+	if u == nil {
+		return
+	}
+	wallet, err := users.Wallets.Rename(u, mux.Vars(r)["wallet_id"], req.Name)
+	if err == users.ErrWalletNotFound {
+		writeJSONError(w, http.StatusNotFound, err.Error())
+		return
+	} else if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, wallet)
+}
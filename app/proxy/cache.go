@@ -78,3 +78,40 @@ func (s cacheStorage) Count() int {
 func init() {
 	InitResponseCache(cacheStorage{c: cache.New(2*time.Minute, 10*time.Minute)})
 }
+
+// CachePolicy describes for how long SDK responses for a given method
+// can be stored by HTTP caches (CDN, browsers) when served over GET.
+type CachePolicy struct {
+	MaxAge time.Duration
+}
+
+// cachePolicies contains HTTP caching settings for relaxed methods.
+// Methods missing from this list are served with caching disabled.
+var cachePolicies = map[string]CachePolicy{
+	MethodResolve:          {MaxAge: 60 * time.Second},
+	MethodClaimSearch:      {MaxAge: 60 * time.Second},
+	MethodCommentList:      {MaxAge: 30 * time.Second},
+	MethodStatus:           {MaxAge: 60 * time.Second},
+	"version":              {MaxAge: 10 * time.Minute},
+	MethodDiscover:         {MaxAge: 10 * time.Minute},
+	"stream_cost_estimate": {MaxAge: 60 * time.Second},
+	"transaction_show":     {MaxAge: 60 * time.Second},
+}
+
+// GetCachePolicy returns HTTP caching settings for a given method.
+func GetCachePolicy(method string) CachePolicy {
+	return cachePolicies[method]
+}
+
+// IsCacheable is true if responses can be stored by HTTP caches at all.
+func (p CachePolicy) IsCacheable() bool {
+	return p.MaxAge > 0
+}
+
+// CacheControl returns Cache-Control header value for the policy.
+func (p CachePolicy) CacheControl() string {
+	if !p.IsCacheable() {
+		return "no-store"
+	}
+	return fmt.Sprintf("public, max-age=%d", int(p.MaxAge.Seconds()))
+}
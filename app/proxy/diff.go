@@ -0,0 +1,134 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// VolatileFields are response fields which values change between calls regardless of the SDK version,
+// they should normally be ignored when comparing responses.
+var VolatileFields = []string{"confirmations", "height", "timestamp", "is_my_output", "expiration_height"}
+
+// DiffResponses compares two JSON-RPC responses (or any other JSON-serializable objects)
+// and returns a list of human-readable differences between them, empty if they are equal.
+// Object keys listed in ignore are skipped at any nesting level.
+func DiffResponses(expected, actual interface{}, ignore []string) ([]string, error) {
+	e, err := normalizeJSON(expected)
+	if err != nil {
+		return nil, err
+	}
+	a, err := normalizeJSON(actual)
+	if err != nil {
+		return nil, err
+	}
+	ignored := make(map[string]bool, len(ignore))
+	for _, k := range ignore {
+		ignored[k] = true
+	}
+	return diffValues("", e, a, ignored, nil), nil
+}
+
+// normalizeJSON converts the object into plain maps, slices and json.Numbers
+// so structs and previously decoded values can be compared against each other.
+func normalizeJSON(v interface{}) (interface{}, error) {
+	var normalized interface{}
+	serialized, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(bytes.NewReader(serialized))
+	d.UseNumber()
+	if err := d.Decode(&normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+func diffValues(path string, expected, actual interface{}, ignored map[string]bool, diffs []string) []string {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return append(diffs, fmt.Sprintf("%v: expected object, got %v", displayPath(path), describe(actual)))
+		}
+		keys := make([]string, 0, len(e)+len(a))
+		for k := range e {
+			keys = append(keys, k)
+		}
+		for k := range a {
+			if _, ok := e[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if ignored[k] {
+				continue
+			}
+			ev, eok := e[k]
+			av, aok := a[k]
+			switch {
+			case !aok:
+				diffs = append(diffs, fmt.Sprintf("%v: missing", joinKey(path, k)))
+			case !eok:
+				diffs = append(diffs, fmt.Sprintf("%v: unexpected %v", joinKey(path, k), describe(av)))
+			default:
+				diffs = diffValues(joinKey(path, k), ev, av, ignored, diffs)
+			}
+		}
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok {
+			return append(diffs, fmt.Sprintf("%v: expected array, got %v", displayPath(path), describe(actual)))
+		}
+		if len(e) != len(a) {
+			return append(diffs, fmt.Sprintf("%v: expected %v items, got %v", displayPath(path), len(e), len(a)))
+		}
+		for i := range e {
+			diffs = diffValues(fmt.Sprintf("%v[%v]", path, i), e[i], a[i], ignored, diffs)
+		}
+	case json.Number:
+		a, ok := actual.(json.Number)
+		if !ok || !numbersEqual(e, a) {
+			return append(diffs, fmt.Sprintf("%v: expected %v, got %v", displayPath(path), describe(expected), describe(actual)))
+		}
+	default:
+		if expected != actual {
+			return append(diffs, fmt.Sprintf("%v: expected %v, got %v", displayPath(path), describe(expected), describe(actual)))
+		}
+	}
+	return diffs
+}
+
+func numbersEqual(a, b json.Number) bool {
+	if a == b {
+		return true
+	}
+	af, aErr := a.Float64()
+	bf, bErr := b.Float64()
+	return aErr == nil && bErr == nil && af == bf
+}
+
+func describe(v interface{}) string {
+	s, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(s)
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "<root>"
+	}
+	return path
+}
+
+func joinKey(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
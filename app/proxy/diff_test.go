@@ -0,0 +1,57 @@
+package proxy
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ybbus/jsonrpc"
+)
+
+func TestDiffResponsesEqual(t *testing.T) {
+	recorded := map[string]interface{}{
+		"result": map[string]interface{}{"amount": json.Number("1.0"), "items": []interface{}{"a", "b"}},
+	}
+	replayed := &jsonrpc.RPCResponse{
+		Result: map[string]interface{}{"amount": 1, "items": []string{"a", "b"}},
+	}
+	diffs, err := DiffResponses(recorded["result"], replayed.Result, nil)
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+}
+
+func TestDiffResponses(t *testing.T) {
+	expected := map[string]interface{}{
+		"name":   "what",
+		"height": 100,
+		"value":  map[string]interface{}{"title": "one", "tags": []interface{}{"a"}},
+		"gone":   true,
+		"items":  []interface{}{1, 2},
+	}
+	actual := map[string]interface{}{
+		"name":   "what",
+		"height": 101,
+		"value":  map[string]interface{}{"title": "two", "tags": "a"},
+		"new":    nil,
+		"items":  []interface{}{1},
+	}
+
+	diffs, err := DiffResponses(expected, actual, VolatileFields)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"gone: missing",
+		"items: expected 2 items, got 1",
+		"new: unexpected null",
+		"value.tags: expected array, got \"a\"",
+		"value.title: expected \"one\", got \"two\"",
+	}, diffs)
+
+	diffs, err = DiffResponses(expected, actual, nil)
+	require.NoError(t, err)
+	assert.Contains(t, diffs, "height: expected 100, got 101")
+
+	diffs, err = DiffResponses("a", 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"<root>: expected \"a\", got 1"}, diffs)
+}
@@ -0,0 +1,261 @@
+package proxy
+
+import (
+	"sort"
+
+	"github.com/lbryio/lbrytv/app/users"
+	"github.com/lbryio/lbrytv/version"
+)
+
+// MethodDiscover is the OpenRPC service discovery method, it returns a description of methods
+// available through the proxy.
+const MethodDiscover = "rpc.discover"
+
+const openRPCVersion = "1.2.4"
+
+// Rate limit classes group methods by the load they put on the SDK.
+const (
+	RateLimitClassRead  = "read"
+	RateLimitClassWrite = "write"
+)
+
+// GetRateLimitClass returns rate limit class for a given method.
+func GetRateLimitClass(method string) string {
+	if methodInList(method, writeMethods) {
+		return RateLimitClassWrite
+	}
+	return RateLimitClassRead
+}
+
+type jsonSchema map[string]interface{}
+
+var (
+	schemaString       = jsonSchema{"type": "string"}
+	schemaStringList   = jsonSchema{"type": "array", "items": schemaString}
+	schemaStringOrList = jsonSchema{"oneOf": []jsonSchema{schemaString, schemaStringList}}
+	schemaInteger      = jsonSchema{"type": "integer"}
+	schemaBoolean      = jsonSchema{"type": "boolean"}
+	schemaAmount       = jsonSchema{"type": "string", "pattern": `^\d+(\.\d{1,8})?$`}
+)
+
+// contentDescriptor is an OpenRPC description of a single method parameter or result.
+type contentDescriptor struct {
+	Name     string     `json:"name"`
+	Required bool       `json:"required,omitempty"`
+	Schema   jsonSchema `json:"schema"`
+}
+
+type openRPCMethod struct {
+	Name           string              `json:"name"`
+	Summary        string              `json:"summary,omitempty"`
+	Tags           []openRPCTag        `json:"tags"`
+	ParamStructure string              `json:"paramStructure"`
+	Params         []contentDescriptor `json:"params"`
+	Result         contentDescriptor   `json:"result"`
+
+	Auth            string   `json:"x-auth"`
+	CacheTTL        int      `json:"x-cache-ttl"`
+	RateLimitClass  string   `json:"x-rate-limit-class"`
+	RejectedParams  []string `json:"x-rejected-params"`
+	ParamsDescribed bool     `json:"x-params-described"`
+}
+
+type openRPCTag struct {
+	Name string `json:"name"`
+}
+
+type openRPCInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+type openRPCDocument struct {
+	OpenRPC    string          `json:"openrpc"`
+	Info       openRPCInfo     `json:"info"`
+	Methods    []openRPCMethod `json:"methods"`
+	AuthHeader string          `json:"x-auth-header"`
+	// AuthHeaders are all headers a client can authenticate with, one of them is enough
+	AuthHeaders []string `json:"x-auth-headers"`
+	// WalletHeader selects one of user's additional wallets
+	WalletHeader string `json:"x-wallet-header"`
+}
+
+func required(name string, schema jsonSchema) contentDescriptor {
+	return contentDescriptor{Name: name, Required: true, Schema: schema}
+}
+
+func optional(name string, schema jsonSchema) contentDescriptor {
+	return contentDescriptor{Name: name, Schema: schema}
+}
+
+var pageParams = []contentDescriptor{
+	optional("page", schemaInteger),
+	optional("page_size", schemaInteger),
+}
+
+var claimMetadataParams = []contentDescriptor{
+	optional("title", schemaString),
+	optional("description", schemaString),
+	optional("tags", schemaStringList),
+	optional("languages", schemaStringList),
+	optional("locations", schemaStringList),
+	optional("thumbnail_url", schemaString),
+	optional("funding_account_ids", schemaStringList),
+	optional("preview", schemaBoolean),
+	optional("blocking", schemaBoolean),
+}
+
+var streamParams = append([]contentDescriptor{
+	required("name", schemaString),
+	required("bid", schemaAmount),
+	required("file_path", schemaString),
+	optional("allow_duplicate_name", schemaBoolean),
+	optional("fee_currency", schemaString),
+	optional("fee_amount", schemaAmount),
+	optional("fee_address", schemaString),
+	optional("author", schemaString),
+	optional("license", schemaString),
+	optional("license_url", schemaString),
+	optional("release_time", schemaInteger),
+	optional("channel_id", schemaString),
+	optional("channel_name", schemaString),
+}, claimMetadataParams...)
+
+// paramSchemas describe parameters of the most commonly used methods.
+// Parameters of methods missing from here are passed to the SDK unchecked.
+var paramSchemas = map[string][]contentDescriptor{
+	"blob_announce": {
+		optional("blob_hash", schemaString),
+		optional("stream_hash", schemaString),
+		optional("sd_hash", schemaString),
+	},
+	MethodClaimSearch: append([]contentDescriptor{
+		optional("name", schemaString),
+		optional("text", schemaString),
+		optional("claim_id", schemaString),
+		optional("claim_ids", schemaStringList),
+		optional("channel", schemaString),
+		optional("channel_ids", schemaStringList),
+		optional("not_channel_ids", schemaStringList),
+		optional("claim_type", schemaString),
+		optional("stream_types", schemaStringList),
+		optional("media_types", schemaStringList),
+		optional("any_tags", schemaStringList),
+		optional("all_tags", schemaStringList),
+		optional("not_tags", schemaStringList),
+		optional("order_by", schemaStringList),
+		optional("no_totals", schemaBoolean),
+	}, pageParams...),
+	MethodCommentList: append([]contentDescriptor{
+		required("claim_id", schemaString),
+		optional("parent_id", schemaString),
+		optional("include_replies", schemaBoolean),
+		optional("is_channel_signature_valid", schemaBoolean),
+		optional("hidden", schemaBoolean),
+	}, pageParams...),
+	MethodResolve: {
+		required(paramUrls, schemaStringOrList),
+	},
+	MethodStatus:        {},
+	"routing_table_get": {},
+	"stream_cost_estimate": {
+		required("uri", schemaString),
+		optional("size", schemaInteger),
+	},
+	"transaction_show": {
+		required("txid", schemaString),
+	},
+	"version": {},
+
+	MethodAccountBalance: {
+		optional("confirmations", schemaInteger),
+	},
+	MethodAccountList: append([]contentDescriptor{
+		optional("include_claims", schemaBoolean),
+		optional("show_seed", schemaBoolean),
+	}, pageParams...),
+	"account_send": {
+		required("amount", schemaAmount),
+		required("addresses", schemaStringOrList),
+	},
+	"channel_create": append([]contentDescriptor{
+		required("name", schemaString),
+		required("bid", schemaAmount),
+		optional("allow_duplicate_name", schemaBoolean),
+		optional("email", schemaString),
+		optional("website_url", schemaString),
+		optional("cover_url", schemaString),
+	}, claimMetadataParams...),
+	"channel_list":     pageParams,
+	"claim_list":       append([]contentDescriptor{optional("claim_type", schemaString)}, pageParams...),
+	"publish":          streamParams,
+	"stream_create":    streamParams,
+	"stream_list":      pageParams,
+	"support_list":     pageParams,
+	"transaction_list": pageParams,
+	"utxo_list":        pageParams,
+	"support_create": {
+		required("claim_id", schemaString),
+		required("amount", schemaAmount),
+		optional("tip", schemaBoolean),
+	},
+	"wallet_balance": {
+		optional("confirmations", schemaInteger),
+	},
+	"wallet_send": {
+		required("amount", schemaAmount),
+		required("addresses", schemaStringOrList),
+	},
+}
+
+func describeMethod(method string) openRPCMethod {
+	m := openRPCMethod{
+		Name:           method,
+		ParamStructure: "by-name",
+		Result:         contentDescriptor{Name: "result", Schema: jsonSchema{}},
+		CacheTTL:       int(GetCachePolicy(method).MaxAge.Seconds()),
+		RateLimitClass: GetRateLimitClass(method),
+		RejectedParams: []string{forbiddenParam},
+	}
+	if MethodRequiresWallet(method) {
+		m.Auth = "wallet"
+		m.Tags = []openRPCTag{{Name: "wallet"}}
+		m.Summary = "Requires authentication, wallet_id is set to the authenticated user's wallet."
+		m.RejectedParams = append(m.RejectedParams, paramWalletID)
+	} else {
+		m.Auth = "none"
+		m.Tags = []openRPCTag{{Name: "relaxed"}}
+	}
+
+	params, ok := paramSchemas[method]
+	m.ParamsDescribed = ok
+	m.Params = append([]contentDescriptor{}, params...)
+	return m
+}
+
+// getDiscoverResponse returns an OpenRPC document describing methods available through the proxy.
+// Methods which require authentication are tagged "wallet", the others are tagged "relaxed".
+func getDiscoverResponse() openRPCDocument {
+	methods := append([]string{}, relaxedMethods...)
+	methods = append(methods, walletSpecificMethods...)
+	sort.Strings(methods)
+
+	doc := openRPCDocument{
+		OpenRPC: openRPCVersion,
+		Info: openRPCInfo{
+			Title:       version.GetAppName(),
+			Description: "LBRY SDK methods available through lbrytv proxy.",
+			Version:     version.GetVersion(),
+		},
+		AuthHeader:   users.TokenHeader,
+		AuthHeaders:  authHeaders,
+		WalletHeader: users.WalletHeader,
+	}
+	for _, method := range methods {
+		if method != MethodDiscover {
+			doc.Methods = append(doc.Methods, describeMethod(method))
+		}
+	}
+	return doc
+}
@@ -0,0 +1,89 @@
+package proxy
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type discoveredMethod struct {
+	Name   string
+	Tags   []map[string]string
+	Params []struct {
+		Name     string
+		Required bool
+	}
+	Auth            string   `json:"x-auth"`
+	CacheTTL        int      `json:"x-cache-ttl"`
+	RateLimitClass  string   `json:"x-rate-limit-class"`
+	RejectedParams  []string `json:"x-rejected-params"`
+	ParamsDescribed bool     `json:"x-params-described"`
+}
+
+func discover(t *testing.T) map[string]discoveredMethod {
+	var response struct {
+		Result struct {
+			OpenRPC      string
+			Methods      []discoveredMethod
+			AuthHeader   string   `json:"x-auth-header"`
+			AuthHeaders  []string `json:"x-auth-headers"`
+			WalletHeader string   `json:"x-wallet-header"`
+		}
+	}
+	// Authentication is not required for discovery
+	resp := NewService("http://localhost:59997").NewCaller().Call(newRawRequest(t, MethodDiscover, nil))
+	require.NoError(t, json.Unmarshal(resp, &response), string(resp))
+	assert.Equal(t, openRPCVersion, response.Result.OpenRPC)
+	assert.Equal(t, "X-Lbry-Auth-Token", response.Result.AuthHeader)
+	assert.ElementsMatch(t,
+		[]string{"X-Lbry-Auth-Token", "X-Lbry-Api-Key", "X-Lbry-Guest-Token", "Authorization"},
+		response.Result.AuthHeaders,
+	)
+	assert.Equal(t, "X-Lbry-Wallet", response.Result.WalletHeader)
+
+	methods := map[string]discoveredMethod{}
+	for _, m := range response.Result.Methods {
+		methods[m.Name] = m
+	}
+	return methods
+}
+
+func TestCallerCallDiscover(t *testing.T) {
+	methods := discover(t)
+
+	assert.Len(t, methods, len(relaxedMethods)+len(walletSpecificMethods)-1)
+	assert.NotContains(t, methods, MethodDiscover)
+
+	resolve := methods[MethodResolve]
+	assert.Equal(t, "none", resolve.Auth)
+	assert.Equal(t, []map[string]string{{"name": "relaxed"}}, resolve.Tags)
+	assert.Equal(t, 60, resolve.CacheTTL)
+	assert.Equal(t, RateLimitClassRead, resolve.RateLimitClass)
+	assert.Equal(t, []string{"account_id"}, resolve.RejectedParams)
+	assert.True(t, resolve.ParamsDescribed)
+	require.Len(t, resolve.Params, 1)
+	assert.Equal(t, "urls", resolve.Params[0].Name)
+	assert.True(t, resolve.Params[0].Required)
+
+	streamCreate := methods["stream_create"]
+	assert.Equal(t, "wallet", streamCreate.Auth)
+	assert.Equal(t, []map[string]string{{"name": "wallet"}}, streamCreate.Tags)
+	assert.Equal(t, 0, streamCreate.CacheTTL)
+	assert.Equal(t, RateLimitClassWrite, streamCreate.RateLimitClass)
+	assert.Equal(t, []string{"account_id", "wallet_id"}, streamCreate.RejectedParams)
+	assert.Equal(t, "name", streamCreate.Params[0].Name)
+	assert.True(t, streamCreate.Params[0].Required)
+
+	addressList := methods["address_list"]
+	assert.Equal(t, RateLimitClassRead, addressList.RateLimitClass)
+	assert.False(t, addressList.ParamsDescribed)
+	assert.Empty(t, addressList.Params)
+}
+
+func TestProxyDiscover(t *testing.T) {
+	response := getPreconditionedQueryResponse(MethodDiscover, nil)
+	require.NotNil(t, response)
+	assert.IsType(t, openRPCDocument{}, response.Result)
+}
@@ -0,0 +1,137 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/vmihailenco/msgpack"
+)
+
+// ResponseFormat defines how a JSON-RPC response is serialized before being sent to the client.
+type ResponseFormat int
+
+const (
+	// FormatJSON is compact JSON, used by default.
+	FormatJSON ResponseFormat = iota
+	// FormatPrettyJSON is indented JSON, requested with `Accept: application/json; pretty=true`.
+	FormatPrettyJSON
+	// FormatMsgpack is MessagePack, requested with `Accept: application/msgpack`.
+	FormatMsgpack
+)
+
+const (
+	mimeJSON        = "application/json"
+	mimeMsgpack     = "application/msgpack"
+	mimeMsgpackX    = "application/x-msgpack"
+	prettyMimeParam = "pretty"
+)
+
+// NegotiateFormat picks a response format based on the value of Accept header supplied by the client.
+// Media ranges are weighted by their q-values, compact JSON is returned if nothing better matches.
+func NegotiateFormat(accept string) ResponseFormat {
+	format := FormatJSON
+	bestQ := 0.0
+
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if rawQ, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(rawQ, 64); err != nil {
+				continue
+			}
+		}
+		if q <= bestQ {
+			continue
+		}
+
+		switch mediaType {
+		case mimeJSON:
+			if pretty, _ := strconv.ParseBool(params[prettyMimeParam]); pretty {
+				format = FormatPrettyJSON
+			} else {
+				format = FormatJSON
+			}
+		case mimeMsgpack, mimeMsgpackX:
+			format = FormatMsgpack
+		case "*/*", "application/*":
+			format = FormatJSON
+		default:
+			continue
+		}
+		bestQ = q
+	}
+	return format
+}
+
+// ContentType returns Content-Type header value for the format.
+func (f ResponseFormat) ContentType() string {
+	if f == FormatMsgpack {
+		return mimeMsgpack
+	}
+	return "application/json; charset=utf-8"
+}
+
+// Marshal serializes the supplied object (normally a JSON-RPC response) into the format.
+func (f ResponseFormat) Marshal(v interface{}) ([]byte, error) {
+	switch f {
+	case FormatPrettyJSON:
+		return json.MarshalIndent(v, "", "  ")
+	case FormatMsgpack:
+		return marshalMsgpack(v)
+	default:
+		return json.Marshal(v)
+	}
+}
+
+// marshalMsgpack converts the object into its JSON representation first
+// so struct field names and omitted values match the JSON response exactly.
+func marshalMsgpack(v interface{}) ([]byte, error) {
+	var generic interface{}
+
+	serialized, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(bytes.NewReader(serialized))
+	d.UseNumber()
+	if err := d.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	enc := msgpack.NewEncoder(buf).SortMapKeys(true).UseCompactEncoding(true)
+	if err := enc.Encode(convertNumbers(generic)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// convertNumbers replaces json.Number values with integers or floats
+// so they don't end up being encoded as strings.
+func convertNumbers(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, item := range t {
+			t[k] = convertNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range t {
+			t[i] = convertNumbers(item)
+		}
+	case json.Number:
+		if n, err := t.Int64(); err == nil {
+			return n
+		}
+		if n, err := t.Float64(); err == nil {
+			return n
+		}
+		return t.String()
+	}
+	return v
+}
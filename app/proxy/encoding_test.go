@@ -0,0 +1,58 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack"
+	"github.com/ybbus/jsonrpc"
+)
+
+func TestNegotiateFormat(t *testing.T) {
+	cases := map[string]ResponseFormat{
+		"":                                     FormatJSON,
+		"*/*":                                  FormatJSON,
+		"application/json":                     FormatJSON,
+		"application/json; pretty=true":        FormatPrettyJSON,
+		"application/json; pretty=false":       FormatJSON,
+		"application/msgpack":                  FormatMsgpack,
+		"application/x-msgpack":                FormatMsgpack,
+		"text/html, application/msgpack":       FormatMsgpack,
+		"application/msgpack;q=0.5, */*;q=0.8": FormatJSON,
+		"application/json;q=0.1, application/msgpack": FormatMsgpack,
+		"image/png":  FormatJSON,
+		"garbage;;;": FormatJSON,
+	}
+	for accept, format := range cases {
+		assert.Equal(t, format, NegotiateFormat(accept), accept)
+	}
+}
+
+func TestResponseFormatMarshal(t *testing.T) {
+	response := &jsonrpc.RPCResponse{
+		JSONRPC: "2.0",
+		Result:  map[string]interface{}{"name": "what", "amount": 10, "fee": 0.5},
+	}
+
+	compact, err := FormatJSON.Marshal(response)
+	require.NoError(t, err)
+	assert.Equal(t, `{"jsonrpc":"2.0","result":{"amount":10,"fee":0.5,"name":"what"},"id":0}`, string(compact))
+
+	pretty, err := FormatPrettyJSON.Marshal(response)
+	require.NoError(t, err)
+	assert.Contains(t, string(pretty), "\n    \"name\": \"what\"")
+
+	packed, err := FormatMsgpack.Marshal(response)
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, msgpack.Unmarshal(packed, &decoded))
+	assert.Equal(t, "2.0", decoded["jsonrpc"])
+	result := decoded["result"].(map[string]interface{})
+	assert.Equal(t, "what", result["name"])
+	assert.EqualValues(t, 10, result["amount"])
+	assert.Equal(t, 0.5, result["fee"])
+
+	assert.Equal(t, "application/msgpack", FormatMsgpack.ContentType())
+	assert.Equal(t, "application/json; charset=utf-8", FormatJSON.ContentType())
+}
@@ -1,15 +1,30 @@
 package proxy
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/lbryio/lbrytv/app/users"
 	"github.com/lbryio/lbrytv/config"
 	"github.com/lbryio/lbrytv/internal/monitor"
+
+	"github.com/gorilla/mux"
+	"github.com/ybbus/jsonrpc"
 )
 
+// ParamsQueryKey is a name of the GET query string parameter containing JSON-encoded method params.
+const ParamsQueryKey = "params"
+
+// authHeaders are not accepted by GET endpoint as its responses are shared between all clients.
+var authHeaders = []string{users.TokenHeader, users.APIKeyHeader, users.GuestTokenHeader, "Authorization"}
+
 var logger = monitor.NewModuleLogger("proxy_handlers")
 
 // RequestHandler is a wrapper for passing proxy.Service instance to proxy HTTP handler.
@@ -39,35 +54,165 @@ func (rh *RequestHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	}
 
 	c := rh.Service.NewCaller()
+	c.SetRequestID(monitor.RequestID(r.Context()))
+	c.SetIdempotencyKey(r.Header.Get(IdempotencyKeyHeader))
+	c.SetFormat(NegotiateFormat(r.Header.Get("Accept")))
+	w.Header().Set("Vary", "Accept")
 
 	if config.AccountsEnabled() {
 		retriever := users.NewWalletService()
 		auth := users.NewAuthenticator(retriever)
-		wid, err := auth.GetWalletID(r)
+		wid, scopes, err := auth.Authenticate(r)
 
 		// TODO: Refactor error response creation
 		if err != nil {
-			response, _ := json.Marshal(NewErrorResponse(err.Error(), ErrAuthFailed))
-			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			response, _ := c.Format().Marshal(NewErrorResponse(err.Error(), ErrAuthFailed))
+			w.Header().Set("Content-Type", c.Format().ContentType())
 			w.WriteHeader(http.StatusOK)
 			w.Write(response)
 			monitor.CaptureRequestError(err, r, w)
 			return
 		}
 		c.SetWalletID(wid)
+		c.SetScopes(scopes)
 	}
 
 	rawCallReponse := c.Call(body)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Content-Type", c.Format().ContentType())
 	w.WriteHeader(http.StatusOK)
 	w.Write(rawCallReponse)
 }
 
+// HandleGet serves relaxed SDK methods over GET so their responses can be cached by CDN and browsers.
+// Method params should be supplied as canonical JSON (no whitespace, sorted keys) in `params` query value:
+//
+//	/api/v1/proxy/resolve?params={"urls":["lbry://what"]}
+//
+// Requests with non-canonical params are redirected to the canonical URL so they share the same cache entry.
+func (rh *RequestHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
+	method := mux.Vars(r)["method"]
+	format := NegotiateFormat(r.Header.Get("Accept"))
+	w.Header().Set("Vary", "Accept, Accept-Encoding")
+
+	if !methodInList(method, relaxedMethods) {
+		writeGetError(w, format, http.StatusForbidden, NewMethodError(fmt.Errorf("method %v is not available over GET", method)))
+		return
+	}
+	for _, h := range authHeaders {
+		if r.Header.Get(h) != "" {
+			writeGetError(w, format, http.StatusBadRequest, NewParamsError(errors.New("authentication is not allowed for GET requests")))
+			return
+		}
+	}
+
+	params, canonicalQuery, err := parseGetParams(r.URL.Query().Get(ParamsQueryKey))
+	if err != nil {
+		writeGetError(w, format, http.StatusBadRequest, NewParseError(err))
+		return
+	}
+	if r.URL.RawQuery != canonicalQuery {
+		u := *r.URL
+		u.RawQuery = canonicalQuery
+		http.Redirect(w, r, u.RequestURI(), http.StatusMovedPermanently)
+		return
+	}
+
+	rawQuery, err := json.Marshal(&jsonrpc.RPCRequest{Method: method, Params: params, JSONRPC: "2.0"})
+	if err != nil {
+		writeGetError(w, format, http.StatusInternalServerError, NewError(err))
+		return
+	}
+
+	c := rh.Service.NewCaller()
+	c.SetRequestID(monitor.RequestID(r.Context()))
+	c.SetFormat(format)
+	response, callErr := c.call(rawQuery)
+	if callErr != nil {
+		c.logger().Errorf("error calling lbrynet: %v, query: %s", callErr, rawQuery)
+		writeGetError(w, format, http.StatusOK, callErr)
+		return
+	}
+	body, callErr := c.marshal(response)
+	if callErr != nil {
+		monitor.CaptureException(callErr)
+		writeGetError(w, format, http.StatusInternalServerError, callErr)
+		return
+	}
+
+	policy := GetCachePolicy(method)
+	if response.Error != nil {
+		policy = CachePolicy{}
+	}
+	etag := makeETag(body)
+
+	hs := w.Header()
+	hs.Set("Cache-Control", policy.CacheControl())
+	hs.Set("ETag", etag)
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	hs.Set("Content-Type", format.ContentType())
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// parseGetParams decodes JSON params supplied in the query string and returns them
+// along with the canonical query string they should be requested with.
+func parseGetParams(rawParams string) (map[string]interface{}, string, error) {
+	var params map[string]interface{}
+
+	if rawParams == "" {
+		return nil, "", nil
+	}
+	d := json.NewDecoder(bytes.NewBufferString(rawParams))
+	d.UseNumber()
+	if err := d.Decode(&params); err != nil {
+		return nil, "", fmt.Errorf("cannot parse params: %v", err)
+	}
+	if params == nil {
+		return nil, "", nil
+	}
+	canonicalParams, err := json.Marshal(params)
+	if err != nil {
+		return nil, "", err
+	}
+	return params, url.Values{ParamsQueryKey: {string(canonicalParams)}}.Encode(), nil
+}
+
+func makeETag(body []byte) string {
+	return fmt.Sprintf(`"%x"`, sha256.Sum256(body))
+}
+
+// etagMatches checks If-None-Match header value against the current ETag, using weak comparison.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+func writeGetError(w http.ResponseWriter, format ResponseFormat, status int, e CallError) {
+	response, _ := format.Marshal(e.AsRPCResponse())
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", format.ContentType())
+	w.WriteHeader(status)
+	w.Write(response)
+}
+
 // HandleOptions returns necessary CORS headers for pre-flight requests to proxy API
 func (rh *RequestHandler) HandleOptions(w http.ResponseWriter, r *http.Request) {
 	hs := w.Header()
 	hs.Set("Access-Control-Max-Age", "7200")
 	hs.Set("Access-Control-Allow-Origin", "*")
-	hs.Set("Access-Control-Allow-Headers", "X-Lbry-Auth-Token, Origin, X-Requested-With, Content-Type, Accept")
+	hs.Set("Access-Control-Allow-Headers", "X-Lbry-Auth-Token, X-Lbry-Guest-Token, X-Lbry-Wallet, Authorization, Idempotency-Key, Origin, X-Requested-With, Content-Type, Accept")
 	w.WriteHeader(http.StatusOK)
 }
@@ -7,8 +7,14 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/lbryio/lbrytv/app/users"
+	"github.com/lbryio/lbrytv/internal/monitor"
+
+	"github.com/gorilla/mux"
 	ljsonrpc "github.com/lbryio/lbry.go/v2/extras/jsonrpc"
+	logrus_test "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/ybbus/jsonrpc"
 )
 
@@ -56,6 +62,7 @@ func TestProxy(t *testing.T) {
 	var queryBody []byte
 	var parsedResponse jsonrpc.RPCResponse
 	resolveResponse := make(ljsonrpc.ResolveResponse)
+	sdk.AddClaim("one", "", "One")
 
 	query = jsonrpc.NewRequest("resolve", map[string]string{"urls": "one"})
 	queryBody, _ = json.Marshal(query)
@@ -74,3 +81,119 @@ func TestProxy(t *testing.T) {
 	ljsonrpc.Decode(parsedResponse.Result, &resolveResponse)
 	assert.Equal(t, "one", resolveResponse["one"].Name)
 }
+
+func newGetRouter(svc *Service) *mux.Router {
+	r := mux.NewRouter()
+	r.HandleFunc("/api/v1/proxy/{method}", NewRequestHandler(svc).HandleGet).Methods("GET")
+	return r
+}
+
+func TestProxyGet(t *testing.T) {
+	ts := launchDummyAPIServer([]byte(`{"jsonrpc": "2.0", "result": {"what": {"name": "what"}}, "id": 0}`))
+	defer ts.Close()
+	router := newGetRouter(NewService(ts.URL))
+
+	r, _ := http.NewRequest("GET", `/api/v1/proxy/resolve?params=%7B%22urls%22%3A%5B%22what%22%5D%7D`, nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, r)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "public, max-age=60", rr.Header().Get("Cache-Control"))
+	assert.Equal(t, "Accept, Accept-Encoding", rr.Header().Get("Vary"))
+	assert.Contains(t, rr.Body.String(), `"name":"what"`)
+	etag := rr.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	r.Header.Set("If-None-Match", etag)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, r)
+
+	assert.Equal(t, http.StatusNotModified, rr.Code)
+	assert.Equal(t, etag, rr.Header().Get("ETag"))
+	assert.Empty(t, rr.Body.String())
+}
+
+func TestProxyGetSDKErrorNotCached(t *testing.T) {
+	ts := launchDummyAPIServer([]byte(`{"jsonrpc": "2.0", "error": {"code": -32500, "message": "oops"}, "id": 0}`))
+	defer ts.Close()
+	router := newGetRouter(NewService(ts.URL))
+
+	r, _ := http.NewRequest("GET", `/api/v1/proxy/claim_search`, nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, r)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+	assert.Contains(t, rr.Body.String(), `"message":"oops"`)
+}
+
+func TestProxyGetNonRelaxedMethod(t *testing.T) {
+	router := newGetRouter(svc)
+
+	r, _ := http.NewRequest("GET", `/api/v1/proxy/account_balance`, nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, r)
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+	assert.Contains(t, rr.Body.String(), "method account_balance is not available over GET")
+}
+
+func TestProxyGetAuthRejected(t *testing.T) {
+	router := newGetRouter(svc)
+
+	r, _ := http.NewRequest("GET", `/api/v1/proxy/resolve`, nil)
+	r.Header.Set(users.TokenHeader, "abc")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, r)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "authentication is not allowed for GET requests")
+}
+
+func TestProxyGetNonCanonicalParams(t *testing.T) {
+	router := newGetRouter(svc)
+
+	r, _ := http.NewRequest("GET", `/api/v1/proxy/claim_search?params=%7B%22page%22%3A%201%2C%20%22any_tags%22%3A%20%5B%22art%22%5D%7D`, nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, r)
+
+	assert.Equal(t, http.StatusMovedPermanently, rr.Code)
+	assert.Equal(t,
+		"/api/v1/proxy/claim_search?params=%7B%22any_tags%22%3A%5B%22art%22%5D%2C%22page%22%3A1%7D",
+		rr.Header().Get("Location"))
+}
+
+func TestETagMatches(t *testing.T) {
+	assert.True(t, etagMatches(`"abc"`, `"abc"`))
+	assert.True(t, etagMatches(`W/"abc"`, `"abc"`))
+	assert.True(t, etagMatches(`"xyz", "abc"`, `"abc"`))
+	assert.True(t, etagMatches(`*`, `"abc"`))
+	assert.False(t, etagMatches(``, `"abc"`))
+	assert.False(t, etagMatches(`"xyz"`, `"abc"`))
+}
+
+func TestProxyForwardsRequestID(t *testing.T) {
+	var sdkRequestID string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sdkRequestID = r.Header.Get(monitor.RequestIDHeader)
+		w.Write([]byte(`{"jsonrpc": "2.0", "result": {"what": {"name": "what"}}, "id": 0}`))
+	}))
+	defer ts.Close()
+
+	svc := NewService(ts.URL)
+	hook := logrus_test.NewLocal(svc.logger.Logger())
+	handler := monitor.RequestIDMiddleware(http.HandlerFunc(NewRequestHandler(svc).Handle))
+
+	queryBody, _ := json.Marshal(jsonrpc.NewRequest("resolve", map[string]string{"urls": "what"}))
+	r, _ := http.NewRequest("POST", "/api/proxy", bytes.NewBuffer(queryBody))
+	r.Header.Set(monitor.RequestIDHeader, "abc")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, r)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "abc", rr.Header().Get(monitor.RequestIDHeader))
+	assert.Equal(t, "abc", sdkRequestID)
+	require.NotNil(t, hook.LastEntry())
+	assert.Equal(t, "abc", hook.LastEntry().Data[monitor.RequestIDF])
+}
@@ -0,0 +1,207 @@
+package proxy
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/monitor"
+	"github.com/lbryio/lbrytv/models"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/ybbus/jsonrpc"
+)
+
+// IdempotencyKeyHeader is the name of HTTP header carrying client-generated key
+// which makes retries of a mutating call return the response of the first call.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// paramIdempotencyKey is an alternative to IdempotencyKeyHeader, it's stripped before the query is sent to the SDK.
+const paramIdempotencyKey = "idempotency_key"
+
+const maxIdempotencyKeyLength = 255
+
+// ErrIdempotencyKeyReused means the idempotency key was already used for a different call.
+const ErrIdempotencyKeyReused int = -32096
+
+// ErrIdempotencyKeyInProgress means the call with the same idempotency key hasn't been completed yet.
+const ErrIdempotencyKeyInProgress int = -32097
+
+// ErrIdempotencyOutcomeUnknown means the call with the same idempotency key might have been executed by the SDK
+// but its response was lost, so it's not known if it succeeded.
+const ErrIdempotencyOutcomeUnknown int = -32089
+
+// Idempotency key error kinds supplied in `data.kind`.
+const (
+	ErrorKindIdempotencyKeyReused      = "idempotency_key_reused"
+	ErrorKindIdempotencyKeyInProgress  = "idempotency_key_in_progress"
+	ErrorKindIdempotencyOutcomeUnknown = "idempotency_outcome_unknown"
+)
+
+// Idempotency stores responses of mutating calls made with idempotency keys,
+// so retried calls get the stored response instead of being sent to the SDK again.
+type Idempotency struct {
+	retention time.Duration
+	stop      chan struct{}
+	logger    monitor.ModuleLogger
+}
+
+// NewIdempotency creates idempotency key storage, keys are kept for the retention period.
+// Start needs to be called to enable removal of expired keys.
+func NewIdempotency(retention time.Duration) *Idempotency {
+	return &Idempotency{
+		retention: retention,
+		stop:      make(chan struct{}),
+		logger:    monitor.NewModuleLogger("idempotency"),
+	}
+}
+
+// Start launches a goroutine periodically removing expired idempotency keys.
+func (i *Idempotency) Start(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				n, err := i.PurgeExpired()
+				if err != nil {
+					i.logger.Log().Errorf("error removing expired idempotency keys: %v", err)
+					continue
+				}
+				i.logger.LogF(monitor.F{"number": n}).Debug("removed expired idempotency keys")
+			case <-i.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops expired idempotency keys removal.
+func (i *Idempotency) Stop() {
+	close(i.stop)
+}
+
+// PurgeExpired removes idempotency keys older than the retention period.
+func (i *Idempotency) PurgeExpired() (int64, error) {
+	return models.IdempotencyKeys(
+		models.IdempotencyKeyWhere.CreatedAt.LT(time.Now().Add(-i.retention)),
+	).DeleteAll(boil.GetDB())
+}
+
+// begin registers idempotency key for the query. It returns a stored response if the same query
+// has been made with the key before, in which case the query should not be sent to the SDK.
+func (i *Idempotency) begin(q *Query, key string) (*jsonrpc.RPCResponse, CallError) {
+	hash, err := requestHash(q)
+	if err != nil {
+		return nil, NewInternalError(err)
+	}
+
+	// Second attempt is for keys which have expired or were removed concurrently
+	for attempt := 0; attempt < 2; attempt++ {
+		row := &models.IdempotencyKey{WalletID: q.walletID, Key: key, Method: q.Method(), RequestHash: hash}
+		err = row.InsertG(boil.Infer())
+		if err == nil {
+			return nil, nil
+		}
+		if !isUniqueViolation(err) {
+			return nil, NewInternalError(err)
+		}
+
+		existing, err := models.FindIdempotencyKeyG(q.walletID, key)
+		if err == sql.ErrNoRows {
+			continue
+		} else if err != nil {
+			return nil, NewInternalError(err)
+		}
+		if time.Since(existing.CreatedAt) > i.retention {
+			if _, err := existing.DeleteG(); err != nil {
+				return nil, NewInternalError(err)
+			}
+			continue
+		}
+
+		if existing.RequestHash != hash {
+			return nil, ClassifiedError{
+				GenericError{errors.New("idempotency key has already been used for a different call"), ErrIdempotencyKeyReused},
+				ErrorKindIdempotencyKeyReused,
+			}
+		}
+		if !existing.Response.Valid {
+			return nil, ClassifiedError{
+				GenericError{errors.New("call with this idempotency key is still in progress"), ErrIdempotencyKeyInProgress},
+				ErrorKindIdempotencyKeyInProgress,
+			}
+		}
+		response := q.newResponse()
+		if err := existing.Response.Unmarshal(response); err != nil {
+			return nil, NewInternalError(err)
+		}
+		// Stored response carries ID of the original request
+		response.ID = q.Request.ID
+		i.logger.LogF(monitor.F{"wallet_id": q.walletID, "method": q.Method()}).Info("returning stored response for idempotency key")
+		return response, nil
+	}
+	return nil, NewInternalError(errors.New("cannot register idempotency key"))
+}
+
+// finish stores the final response for idempotency key. If there is no response and the query
+// never reached the SDK, the key is removed so the call can be retried with it. Otherwise the SDK
+// might have executed the call, so retries get an unknown outcome error instead of repeating it.
+func (i *Idempotency) finish(q *Query, key string, r *jsonrpc.RPCResponse, delivered bool) {
+	log := i.logger.LogF(monitor.F{"wallet_id": q.walletID, "method": q.Method()})
+	row := &models.IdempotencyKey{WalletID: q.walletID, Key: key}
+	if r == nil && !delivered {
+		if _, err := row.DeleteG(); err != nil {
+			log.Errorf("error removing idempotency key: %v", err)
+		}
+		return
+	}
+	if r == nil {
+		log.Warn("outcome of the call is unknown, keeping idempotency key")
+		r = q.newResponse()
+		r.Error = &jsonrpc.RPCError{
+			Code:    ErrIdempotencyOutcomeUnknown,
+			Message: "outcome of the call with this idempotency key is unknown, check the wallet before retrying with a new key",
+			Data:    map[string]interface{}{"kind": ErrorKindIdempotencyOutcomeUnknown},
+		}
+	}
+	serialized, err := json.Marshal(r)
+	if err != nil {
+		log.Errorf("error serializing response for idempotency key: %v", err)
+		return
+	}
+	row.Response = null.JSONFrom(serialized)
+	if _, err := row.UpdateG(boil.Whitelist(models.IdempotencyKeyColumns.Response)); err != nil {
+		log.Errorf("error storing response for idempotency key: %v", err)
+	}
+}
+
+// requestHash identifies call payload, json.Marshal sorts map keys so equal params produce equal hashes.
+func requestHash(q *Query) (string, error) {
+	params, err := json.Marshal(q.Params())
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256([]byte(fmt.Sprintf("%v:%s", q.Method(), params)))
+	return hex.EncodeToString(h[:]), nil
+}
+
+// validateIdempotencyKey checks client-supplied idempotency key.
+func validateIdempotencyKey(key string) CallError {
+	if len(key) > maxIdempotencyKeyLength {
+		return NewParamsError(fmt.Errorf("idempotency key cannot be longer than %v characters", maxIdempotencyKeyLength))
+	}
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	pqErr, ok := errors.Cause(err).(*pq.Error)
+	return ok && pqErr.Code == "23505"
+}
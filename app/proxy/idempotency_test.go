@@ -0,0 +1,181 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/storage"
+	"github.com/lbryio/lbrytv/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/ybbus/jsonrpc"
+)
+
+// launchCountingSDK returns a server responding with a new transaction ID to each call and recording received requests.
+func launchCountingSDK() (*httptest.Server, func() []*jsonrpc.RPCRequest) {
+	var (
+		mu       sync.Mutex
+		requests []*jsonrpc.RPCRequest
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := &jsonrpc.RPCRequest{}
+		json.NewDecoder(r.Body).Decode(req)
+		mu.Lock()
+		requests = append(requests, req)
+		txid := fmt.Sprintf("tx%v", len(requests))
+		mu.Unlock()
+		json.NewEncoder(w).Encode(jsonrpc.RPCResponse{JSONRPC: "2.0", ID: req.ID, Result: map[string]interface{}{"txid": txid}})
+	}))
+	return ts, func() []*jsonrpc.RPCRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+func newIdempotentCaller(endpoint, wid, key string) *Caller {
+	svc := NewService(endpoint)
+	svc.SetIdempotency(NewIdempotency(time.Hour))
+	c := svc.NewCaller()
+	c.SetWalletID(wid)
+	c.SetIdempotencyKey(key)
+	return c
+}
+
+func TestCallerIdempotencyKeyHeader(t *testing.T) {
+	ts, requests := launchCountingSDK()
+	defer ts.Close()
+	wid := fmt.Sprintf("lbrytv-id.%v.wallet", rand.Int())
+	params := map[string]interface{}{"addresses": "bX", "amount": "1.0"}
+
+	r1, err := newIdempotentCaller(ts.URL, wid, "key1").call(newRawRequest(t, "wallet_send", params))
+	require.Nil(t, err)
+	r2, err := newIdempotentCaller(ts.URL, wid, "key1").call(newRawRequest(t, "wallet_send", params))
+	require.Nil(t, err)
+
+	require.Len(t, requests(), 1)
+	assert.Equal(t, r1.Result, r2.Result)
+
+	// Another key makes a new call
+	r3, err := newIdempotentCaller(ts.URL, wid, "key2").call(newRawRequest(t, "wallet_send", params))
+	require.Nil(t, err)
+	require.Len(t, requests(), 2)
+	assert.NotEqual(t, r1.Result, r3.Result)
+}
+
+func TestCallerIdempotencyKeyParam(t *testing.T) {
+	ts, requests := launchCountingSDK()
+	defer ts.Close()
+	wid := fmt.Sprintf("lbrytv-id.%v.wallet", rand.Int())
+
+	for i := 0; i < 2; i++ {
+		params := map[string]interface{}{"claim_id": "abc", "amount": "1.0", paramIdempotencyKey: "key1"}
+		_, err := newIdempotentCaller(ts.URL, wid, "").call(newRawRequest(t, "support_create", params))
+		require.Nil(t, err)
+	}
+
+	require.Len(t, requests(), 1)
+	assert.NotContains(t, requests()[0].Params, paramIdempotencyKey)
+}
+
+func TestCallerIdempotencyKeyReused(t *testing.T) {
+	ts, requests := launchCountingSDK()
+	defer ts.Close()
+	wid := fmt.Sprintf("lbrytv-id.%v.wallet", rand.Int())
+
+	_, err := newIdempotentCaller(ts.URL, wid, "key1").call(newRawRequest(t, "wallet_send", map[string]interface{}{"amount": "1.0"}))
+	require.Nil(t, err)
+	_, err = newIdempotentCaller(ts.URL, wid, "key1").call(newRawRequest(t, "wallet_send", map[string]interface{}{"amount": "2.0"}))
+	require.NotNil(t, err)
+	assert.Equal(t, ErrIdempotencyKeyReused, err.Code())
+	require.Len(t, requests(), 1)
+}
+
+func TestCallerIdempotencyKeyTransportError(t *testing.T) {
+	wid := fmt.Sprintf("lbrytv-id.%v.wallet", rand.Int())
+
+	_, err := newIdempotentCaller("http://localhost:59998", wid, "key1").call(newRawRequest(t, "wallet_send", nil))
+	require.NotNil(t, err)
+
+	// Key is released so the call can be retried
+	ts, requests := launchCountingSDK()
+	defer ts.Close()
+	_, err = newIdempotentCaller(ts.URL, wid, "key1").call(newRawRequest(t, "wallet_send", nil))
+	require.Nil(t, err)
+	require.Len(t, requests(), 1)
+}
+
+func TestCallerIdempotencyKeyUnknownOutcome(t *testing.T) {
+	wid := fmt.Sprintf("lbrytv-id.%v.wallet", rand.Int())
+	// SDK receives the call but the connection breaks before the response is sent
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		conn.Close()
+	}))
+	_, err := newIdempotentCaller(ts.URL, wid, "key1").call(newRawRequest(t, "wallet_send", nil))
+	ts.Close()
+	require.NotNil(t, err)
+
+	// Call could have been executed, so it's not repeated
+	ts, requests := launchCountingSDK()
+	defer ts.Close()
+	r, err := newIdempotentCaller(ts.URL, wid, "key1").call(newRawRequest(t, "wallet_send", nil))
+	require.Nil(t, err)
+	require.NotNil(t, r.Error)
+	assert.Equal(t, ErrIdempotencyOutcomeUnknown, r.Error.Code)
+	assert.Empty(t, requests())
+}
+
+func TestIdempotencyPurgeExpired(t *testing.T) {
+	storage.Conn.Truncate([]string{"idempotency_keys"})
+	wid := fmt.Sprintf("lbrytv-id.%v.wallet", rand.Int())
+	for _, created := range []time.Time{time.Now().Add(-2 * time.Hour), time.Now()} {
+		row := &models.IdempotencyKey{WalletID: wid, Key: created.String(), CreatedAt: created, Method: "wallet_send", RequestHash: "x"}
+		require.NoError(t, row.InsertG(boil.Infer()))
+	}
+
+	n, err := NewIdempotency(time.Hour).PurgeExpired()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, n)
+}
+
+func TestCallerStripsIdempotencyKeyParam(t *testing.T) {
+	ts, requests := launchCountingSDK()
+	defer ts.Close()
+
+	// Key is not forwarded to the SDK even when idempotency is disabled or method is not mutating
+	c := NewService(ts.URL).NewCaller()
+	_, err := c.call(newRawRequest(t, "resolve", map[string]interface{}{"urls": "what", paramIdempotencyKey: "key1"}))
+	require.Nil(t, err)
+	require.Len(t, requests(), 1)
+	assert.Equal(t, map[string]interface{}{"urls": "what"}, requests()[0].Params)
+
+	_, err = c.call(newRawRequest(t, "resolve", map[string]interface{}{paramIdempotencyKey: strings.Repeat("k", 256)}))
+	require.NotNil(t, err)
+	assert.Equal(t, ErrInvalidParams, err.Code())
+}
+
+func TestRequestHash(t *testing.T) {
+	q1, err := NewQuery([]byte(`{"jsonrpc": "2.0", "method": "wallet_send", "params": {"amount": "1.0", "addresses": "bX"}}`))
+	require.NoError(t, err)
+	q2, err := NewQuery([]byte(`{"jsonrpc": "2.0", "method": "wallet_send", "params": {"addresses": "bX", "amount": "1.0"}}`))
+	require.NoError(t, err)
+	q3, err := NewQuery([]byte(`{"jsonrpc": "2.0", "method": "support_create", "params": {"addresses": "bX", "amount": "1.0"}}`))
+	require.NoError(t, err)
+
+	h1, _ := requestHash(q1)
+	h2, _ := requestHash(q2)
+	h3, _ := requestHash(q3)
+	assert.Equal(t, h1, h2)
+	assert.NotEqual(t, h1, h3)
+}
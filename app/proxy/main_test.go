@@ -10,6 +10,7 @@ import (
 
 	"github.com/lbryio/lbrytv/config"
 	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/internal/lbrynettest"
 	"github.com/lbryio/lbrytv/internal/storage"
 )
 
@@ -19,15 +20,17 @@ const proxySuffix = "/api/proxy"
 const testSetupWait = 200 * time.Millisecond
 
 var svc *Service
+var sdk *lbrynettest.Server
 
 func TestMain(m *testing.M) {
 	rand.Seed(time.Now().UnixNano())
 
 	go launchGrumpyServer()
+	var sdkCleanup func()
+	sdk, sdkCleanup = lbrynettest.UseTestServer(&lbrynet.Client)
 	svc = NewService(config.GetLbrynet())
 
 	config.Override("AccountsEnabled", true)
-	defer config.RestoreOverridden()
 
 	dbConfig := config.GetDatabase()
 	params := storage.ConnParams{
@@ -38,11 +41,13 @@ func TestMain(m *testing.M) {
 	c, connCleanup := storage.CreateTestConn(params)
 	c.SetDefaultConnection()
 
-	defer connCleanup()
-	defer lbrynet.RemoveAccount(dummyUserID)
-
 	code := m.Run()
 
+	// Deferred calls don't run after os.Exit
+	lbrynet.RemoveAccount(dummyUserID)
+	connCleanup()
+	config.RestoreOverridden()
+	sdkCleanup()
 	os.Exit(code)
 }
 
@@ -0,0 +1,44 @@
+package proxy
+
+import (
+	"errors"
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/maintenance"
+
+	"github.com/ybbus/jsonrpc"
+)
+
+// ErrMaintenance means the call is not available while lbrytv is in maintenance mode.
+const ErrMaintenance int = -32099
+
+// ErrorKindMaintenance is supplied in `data.kind` of ErrMaintenance responses.
+const ErrorKindMaintenance = "maintenance"
+
+// MaintenanceError is returned for wallet-mutating calls and uploads made during maintenance.
+type MaintenanceError struct {
+	ClassifiedError
+	eta *time.Time
+}
+
+// NewMaintenanceError creates an error describing maintenance in progress.
+func NewMaintenanceError(s maintenance.Status) MaintenanceError {
+	return MaintenanceError{
+		ClassifiedError{GenericError{errors.New(s.Message), ErrMaintenance}, ErrorKindMaintenance},
+		s.ETA,
+	}
+}
+
+// AsRPCResponse returns error as jsonrpc.RPCResponse with maintenance ETA supplied in `data.eta` if it's known.
+func (e MaintenanceError) AsRPCResponse() *jsonrpc.RPCResponse {
+	r := e.ClassifiedError.AsRPCResponse()
+	if e.eta != nil {
+		r.Error.Data.(map[string]interface{})["eta"] = e.eta.UTC().Format(time.RFC3339)
+	}
+	return r
+}
+
+// isBlockedByMaintenance returns true for methods which are not available during maintenance.
+func isBlockedByMaintenance(method string) bool {
+	return maintenance.Active() && methodInList(method, writeMethods)
+}
@@ -0,0 +1,44 @@
+package proxy
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/maintenance"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ybbus/jsonrpc"
+)
+
+func TestCallerMaintenance(t *testing.T) {
+	ts, requests := launchCountingSDK()
+	defer ts.Close()
+
+	eta := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	maintenance.Set(maintenance.Status{Enabled: true, ETA: &eta})
+	defer maintenance.Set(maintenance.Status{})
+
+	c := NewService(ts.URL).NewCaller()
+	c.SetWalletID("lbrytv-id.1.wallet")
+
+	var rpcResponse jsonrpc.RPCResponse
+	raw := c.Call(newRawRequest(t, "wallet_send", map[string]interface{}{"amount": "1.0", "addresses": "bX"}))
+	require.NoError(t, json.Unmarshal(raw, &rpcResponse))
+	require.NotNil(t, rpcResponse.Error)
+	assert.Equal(t, ErrMaintenance, rpcResponse.Error.Code)
+	assert.Equal(t, maintenance.DefaultMessage, rpcResponse.Error.Message)
+	assert.Equal(t, map[string]interface{}{"kind": ErrorKindMaintenance, "eta": "2020-01-01T12:00:00Z"}, rpcResponse.Error.Data)
+	assert.Len(t, requests(), 0)
+
+	r, err := c.call(newRawRequest(t, "resolve", map[string]interface{}{"urls": "what"}))
+	require.Nil(t, err)
+	assert.Nil(t, r.Error)
+	assert.Len(t, requests(), 1)
+
+	maintenance.Set(maintenance.Status{})
+	_, err = c.call(newRawRequest(t, "wallet_send", map[string]interface{}{"amount": "1.0", "addresses": "bX"}))
+	require.Nil(t, err)
+	assert.Len(t, requests(), 2)
+}
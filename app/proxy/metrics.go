@@ -0,0 +1,69 @@
+package proxy
+
+import (
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Outcomes of proxied calls as reported in metrics.
+const (
+	OutcomeOK             = "ok"
+	OutcomeSDKError       = "sdk_error"
+	OutcomeTransportError = "transport_error"
+	// OutcomeCacheHit is for calls served without reaching the SDK, from cache or predefined responses.
+	OutcomeCacheHit = "cache_hit"
+	OutcomeRejected = "rejected"
+)
+
+// Auth states of proxied calls as reported in metrics.
+const (
+	AuthAnonymous     = "anonymous"
+	AuthAuthenticated = "authenticated"
+)
+
+// methodOther replaces method names which are not exposed by the proxy,
+// so clients can't flood metrics with arbitrary label values.
+const methodOther = "other"
+
+var callLabels = []string{"method", "outcome", "auth"}
+
+// CallsTotal counts calls processed by Caller.
+var CallsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: "proxy",
+		Name:      "calls_total",
+		Help:      "Number of proxied calls by method, outcome and auth state.",
+	},
+	callLabels,
+)
+
+// CallDurations observes time it takes Caller to process a call, including the SDK call.
+var CallDurations = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Subsystem: "proxy",
+		Name:      "call_duration_seconds",
+		Help:      "Time to process a proxied call by method, outcome and auth state.",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	},
+	callLabels,
+)
+
+func init() {
+	metrics.Registry.MustRegister(CallsTotal, CallDurations, ShadowQueriesTotal)
+}
+
+// observeCall records call outcome and duration in metrics.
+func observeCall(method, outcome, walletID string, duration time.Duration) {
+	if !methodInList(method, relaxedMethods) && !methodInList(method, walletSpecificMethods) {
+		method = methodOther
+	}
+	auth := AuthAnonymous
+	if walletID != "" {
+		auth = AuthAuthenticated
+	}
+	CallsTotal.WithLabelValues(method, outcome, auth).Inc()
+	CallDurations.WithLabelValues(method, outcome, auth).Observe(duration.Seconds())
+}
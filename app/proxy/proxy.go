@@ -36,6 +36,33 @@ var relaxedMethods = []string{
 	"comment_list",
 	"version",
 	"routing_table_get",
+	"rpc.discover",
+}
+
+// writeMethods are methods which create transactions or otherwise change wallet state.
+var writeMethods = []string{
+	"publish",
+	"account_send",
+	"channel_abandon",
+	"channel_create",
+	"channel_update",
+	"channel_import",
+	"comment_abandon",
+	"comment_create",
+	"comment_hide",
+	"stream_abandon",
+	"stream_create",
+	"stream_update",
+	"support_abandon",
+	"support_create",
+	"sync_apply",
+	"preference_set",
+	"utxo_release",
+	"wallet_send",
+	"wallet_encrypt",
+	"wallet_decrypt",
+	"wallet_lock",
+	"wallet_unlock",
 }
 
 // walletSpecificMethods are methods which require wallet_id.
@@ -180,9 +207,9 @@ func Proxy(r *jsonrpc.RPCRequest, accountID string) ([]byte, error) {
 	return ForwardCall(*r)
 }
 
-// MarshalResponse takes a raw json request body and serializes it into RPCRequest struct for further processing.
+// MarshalResponse serializes RPCResponse into compact JSON.
 func MarshalResponse(r *jsonrpc.RPCResponse) ([]byte, error) {
-	sr, err := json.MarshalIndent(r, "", "  ")
+	sr, err := json.Marshal(r)
 	if err != nil {
 		return nil, err
 	}
@@ -282,7 +309,7 @@ func ForwardCall(request jsonrpc.RPCRequest) ([]byte, error) {
 			responseCache.Save(request.Method, request.Params, processedResponse)
 		}
 	} else {
-		processedResponse = callResult
+		processedResponse = classifyResponse(callResult)
 		monitor.LogFailedQuery(request.Method, request.Params, callResult.Error)
 	}
 
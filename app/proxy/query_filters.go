@@ -15,6 +15,16 @@ func methodInList(method string, checkMethods []string) bool {
 	return false
 }
 
+// MethodRequiresWallet returns true if the method can only be called with a wallet ID supplied.
+func MethodRequiresWallet(method string) bool {
+	return !methodInList(method, relaxedMethods)
+}
+
+// IsWriteMethod returns true if the method creates transactions or otherwise changes wallet state.
+func IsWriteMethod(method string) bool {
+	return methodInList(method, writeMethods)
+}
+
 // getPreconditionedQueryResponse returns true if we got a resolve query with more than `cacheResolveLongerThan` urls in it
 func getPreconditionedQueryResponse(method string, params interface{}) *jsonrpc.RPCResponse {
 	if methodInList(method, forbiddenMethods) {
@@ -32,5 +42,10 @@ func getPreconditionedQueryResponse(method string, params interface{}) *jsonrpc.
 		r.Result = getStatusResponse()
 		return &r
 	}
+	if method == MethodDiscover {
+		var r jsonrpc.RPCResponse
+		r.Result = getDiscoverResponse()
+		return &r
+	}
 	return nil
 }
@@ -28,3 +28,12 @@ func TestProxy_Status(t *testing.T) {
 		"692EAWhtoqDuAfQ6KHMXxFxt8tkhmt7sfprEMHWKjy5hf6PwZcHDV542VHqRnFnTCD",
 		result["installation_id"].(string))
 }
+
+func TestIsWriteMethod(t *testing.T) {
+	for _, m := range []string{"wallet_send", "support_create", "stream_create", "publish"} {
+		assert.True(t, IsWriteMethod(m), m)
+	}
+	for _, m := range []string{"resolve", "claim_search", "wallet_balance"} {
+		assert.False(t, IsWriteMethod(m), m)
+	}
+}
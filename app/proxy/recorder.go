@@ -0,0 +1,208 @@
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/monitor"
+
+	"github.com/ybbus/jsonrpc"
+)
+
+const maskedValue = "***"
+
+// sensitiveParams are masked in recorded queries and responses. Wallet ID is stripped completely from queries
+// as it's meaningless outside of the SDK instance it was recorded on.
+var sensitiveParams = map[string]bool{
+	"password":     true,
+	"new_password": true,
+	"seed":         true,
+	"private_key":  true,
+}
+
+// unrecordedMethods return secrets as a whole, so their responses can't be masked.
+var unrecordedMethods = map[string]bool{
+	"channel_export": true,
+	"sync_apply":     true,
+}
+
+var recorderLogger = monitor.NewModuleLogger("recorder")
+
+// RecorderOpts contains settings for Recorder.
+type RecorderOpts struct {
+	// Path is a JSONL file records are appended to.
+	Path string
+	// MaxSize is the file size in bytes after which the file is rotated. 0 disables rotation.
+	MaxSize int64
+	// SampleRate is a fraction of queries to be recorded, 1 records every query.
+	SampleRate float64
+}
+
+// Record is a single proxied query with the response received for it, as stored in a recording.
+type Record struct {
+	Time     time.Time            `json:"time"`
+	Method   string               `json:"method"`
+	Params   interface{}          `json:"params,omitempty"`
+	Response *jsonrpc.RPCResponse `json:"response"`
+	Duration float64              `json:"duration"`
+}
+
+// Recorder writes sanitized queries proxied to the SDK along with their responses to a JSONL file
+// so they can later be fed to `lbrytv replay`.
+type Recorder struct {
+	opts RecorderOpts
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewRecorder opens recording file for appending and returns a Recorder writing to it.
+func NewRecorder(opts RecorderOpts) (*Recorder, error) {
+	r := &Recorder{opts: opts}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Recorder) open() error {
+	f, err := os.OpenFile(r.opts.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	r.file = f
+	r.size = stat.Size()
+	return nil
+}
+
+// rotate moves current recording file aside, suffixing it with current timestamp, and starts a new one.
+func (r *Recorder) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(r.opts.Path, fmt.Sprintf("%v.%v", r.opts.Path, time.Now().UnixNano())); err != nil {
+		return err
+	}
+	return r.open()
+}
+
+func (r *Recorder) sampled() bool {
+	return r.opts.SampleRate >= 1 || rand.Float64() < r.opts.SampleRate
+}
+
+// Record stores the query and its response if the query gets sampled.
+// Queries of methods returning nothing but secrets, like channel keys or wallet data, are never recorded.
+func (r *Recorder) Record(method string, params interface{}, response *jsonrpc.RPCResponse, duration float64) {
+	if unrecordedMethods[method] || !r.sampled() {
+		return
+	}
+	line, err := json.Marshal(Record{
+		Time:     time.Now().UTC(),
+		Method:   method,
+		Params:   sanitizeParams(params),
+		Response: SanitizeResponse(response),
+		Duration: duration,
+	})
+	if err != nil {
+		recorderLogger.Log().Errorf("cannot serialize record for %v: %v", method, err)
+		return
+	}
+	line = append(line, '\n')
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.opts.MaxSize > 0 && r.size > 0 && r.size+int64(len(line)) > r.opts.MaxSize {
+		if err := r.rotate(); err != nil {
+			recorderLogger.Log().Errorf("cannot rotate recording %v: %v", r.opts.Path, err)
+			return
+		}
+	}
+	n, err := r.file.Write(line)
+	r.size += int64(n)
+	if err != nil {
+		recorderLogger.Log().Errorf("cannot write to recording %v: %v", r.opts.Path, err)
+	}
+}
+
+// Close closes the recording file.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+// sanitizeParams returns a copy of query params with wallet ID removed and sensitive values masked.
+func sanitizeParams(params interface{}) interface{} {
+	return sanitizeValue(params, true)
+}
+
+// SanitizeResponse returns a copy of SDK response with seeds, private keys and passwords in its result masked.
+func SanitizeResponse(response *jsonrpc.RPCResponse) *jsonrpc.RPCResponse {
+	if response == nil {
+		return nil
+	}
+	sanitized := *response
+	sanitized.Result = sanitizeValue(response.Result, false)
+	return &sanitized
+}
+
+func sanitizeValue(v interface{}, topLevel bool) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		sanitized := make(map[string]interface{}, len(t))
+		for k, item := range t {
+			if topLevel && k == paramWalletID {
+				continue
+			}
+			if sensitiveParams[k] {
+				sanitized[k] = maskedValue
+				continue
+			}
+			sanitized[k] = sanitizeValue(item, false)
+		}
+		return sanitized
+	case []interface{}:
+		sanitized := make([]interface{}, len(t))
+		for i, item := range t {
+			sanitized[i] = sanitizeValue(item, false)
+		}
+		return sanitized
+	}
+	return v
+}
+
+// ReadRecords parses a recording made by Recorder, calling fn for every record in it.
+// Reading stops at the first error returned by fn.
+func ReadRecords(source io.Reader, fn func(Record) error) error {
+	scanner := bufio.NewScanner(source)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var rec Record
+		d := json.NewDecoder(bytes.NewReader(scanner.Bytes()))
+		d.UseNumber()
+		if err := d.Decode(&rec); err != nil {
+			return fmt.Errorf("malformed record on line %v: %v", line, err)
+		}
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,180 @@
+package proxy
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ybbus/jsonrpc"
+)
+
+func newTestRecorder(t *testing.T, opts RecorderOpts) (*Recorder, func()) {
+	dir, err := ioutil.TempDir("", "recorder")
+	require.NoError(t, err)
+	opts.Path = filepath.Join(dir, "proxy.jsonl")
+	r, err := NewRecorder(opts)
+	require.NoError(t, err)
+	return r, func() {
+		r.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func readTestRecords(t *testing.T, path string) []Record {
+	var records []Record
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, ReadRecords(f, func(rec Record) error {
+		records = append(records, rec)
+		return nil
+	}))
+	return records
+}
+
+func TestSanitizeParams(t *testing.T) {
+	params := map[string]interface{}{
+		"wallet_id": "lbrytv-id.123.wallet",
+		"password":  "secret",
+		"name":      "channel",
+		"nested":    map[string]interface{}{"wallet_id": "keep", "seed": "words words"},
+		"list":      []interface{}{map[string]interface{}{"private_key": "key"}},
+	}
+	sanitized := sanitizeParams(params)
+
+	assert.Equal(t, map[string]interface{}{
+		"password": "***",
+		"name":     "channel",
+		"nested":   map[string]interface{}{"wallet_id": "keep", "seed": "***"},
+		"list":     []interface{}{map[string]interface{}{"private_key": "***"}},
+	}, sanitized)
+	// Original params are left intact as they're still used by the caller
+	assert.Equal(t, "secret", params["password"])
+	assert.Equal(t, "lbrytv-id.123.wallet", params["wallet_id"])
+
+	assert.Nil(t, sanitizeParams(nil))
+}
+
+func TestRecorderRecord(t *testing.T) {
+	r, cleanup := newTestRecorder(t, RecorderOpts{SampleRate: 1})
+	defer cleanup()
+
+	r.Record("resolve", map[string]interface{}{"urls": "what"}, &jsonrpc.RPCResponse{Result: "ok"}, 0.5)
+	r.Record("account_balance", map[string]interface{}{"wallet_id": "abc"}, &jsonrpc.RPCResponse{
+		Error: &jsonrpc.RPCError{Code: -32500, Message: "oops"},
+	}, 0.1)
+
+	records := readTestRecords(t, r.opts.Path)
+	require.Len(t, records, 2)
+	assert.Equal(t, "resolve", records[0].Method)
+	assert.Equal(t, map[string]interface{}{"urls": "what"}, records[0].Params)
+	assert.Equal(t, "ok", records[0].Response.Result)
+	assert.Equal(t, 0.5, records[0].Duration)
+	assert.Equal(t, "account_balance", records[1].Method)
+	assert.Equal(t, map[string]interface{}{}, records[1].Params)
+	assert.Equal(t, "oops", records[1].Response.Error.Message)
+}
+
+func TestRecorderSecrets(t *testing.T) {
+	r, cleanup := newTestRecorder(t, RecorderOpts{SampleRate: 1})
+	defer cleanup()
+
+	r.Record("channel_export", map[string]interface{}{"channel_id": "abc"}, &jsonrpc.RPCResponse{Result: "c2VjcmV0"}, 0.1)
+	r.Record("sync_apply", map[string]interface{}{"password": ""}, &jsonrpc.RPCResponse{
+		Result: map[string]interface{}{"hash": "abc", "data": "c2VjcmV0"},
+	}, 0.1)
+	r.Record("account_list", map[string]interface{}{"show_seed": true}, &jsonrpc.RPCResponse{
+		Result: map[string]interface{}{"lbc_mainnet": []interface{}{
+			map[string]interface{}{"id": "abc", "seed": "words words", "private_key": "xprv"},
+		}},
+	}, 0.1)
+
+	records := readTestRecords(t, r.opts.Path)
+	require.Len(t, records, 1)
+	assert.Equal(t, "account_list", records[0].Method)
+	assert.Equal(t, map[string]interface{}{"lbc_mainnet": []interface{}{
+		map[string]interface{}{"id": "abc", "seed": "***", "private_key": "***"},
+	}}, records[0].Response.Result)
+}
+
+func TestRecorderSampling(t *testing.T) {
+	r, cleanup := newTestRecorder(t, RecorderOpts{SampleRate: 0})
+	defer cleanup()
+
+	for i := 0; i < 100; i++ {
+		r.Record("resolve", nil, &jsonrpc.RPCResponse{Result: "ok"}, 0)
+	}
+	assert.Empty(t, readTestRecords(t, r.opts.Path))
+}
+
+func TestRecorderRotation(t *testing.T) {
+	r, cleanup := newTestRecorder(t, RecorderOpts{SampleRate: 1, MaxSize: 300})
+	defer cleanup()
+
+	for i := 0; i < 10; i++ {
+		r.Record("resolve", map[string]interface{}{"urls": "what"}, &jsonrpc.RPCResponse{Result: "ok"}, 0)
+	}
+
+	files, err := filepath.Glob(r.opts.Path + "*")
+	require.NoError(t, err)
+	assert.True(t, len(files) > 1)
+
+	total := 0
+	for _, f := range files {
+		stat, err := os.Stat(f)
+		require.NoError(t, err)
+		assert.True(t, stat.Size() <= 300)
+		total += len(readTestRecords(t, f))
+	}
+	assert.Equal(t, 10, total)
+}
+
+func TestCallerRecordsQueries(t *testing.T) {
+	ts := launchDummyAPIServer([]byte(`{"jsonrpc": "2.0", "result": {"available": "1.0"}, "id": 0}`))
+	defer ts.Close()
+	r, cleanup := newTestRecorder(t, RecorderOpts{SampleRate: 1})
+	defer cleanup()
+
+	svc := NewService(ts.URL)
+	svc.SetRecorder(r)
+	c := svc.NewCaller()
+	c.SetWalletID("lbrytv-id.123.wallet")
+	c.Call(newRawRequest(t, "account_balance", nil))
+	// Predefined responses never reach the SDK so they aren't recorded
+	c.Call(newRawRequest(t, "status", nil))
+
+	records := readTestRecords(t, r.opts.Path)
+	require.Len(t, records, 1)
+	assert.Equal(t, "account_balance", records[0].Method)
+	assert.Equal(t, map[string]interface{}{}, records[0].Params)
+	diffs, err := DiffResponses(map[string]interface{}{"available": "1.0"}, records[0].Response.Result, nil)
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+}
+
+func TestCallerRecordsClassifiedErrors(t *testing.T) {
+	ts := launchDummyAPIServer([]byte(`{"jsonrpc": "2.0", "error": {"code": -32500, "message": "Not enough funds to cover this transaction."}, "id": 0}`))
+	defer ts.Close()
+	r, cleanup := newTestRecorder(t, RecorderOpts{SampleRate: 1})
+	defer cleanup()
+
+	svc := NewService(ts.URL)
+	svc.SetRecorder(r)
+	c := svc.NewCaller()
+	c.SetWalletID("lbrytv-id.123.wallet")
+	raw := c.Call(newRawRequest(t, "account_balance", nil))
+
+	records := readTestRecords(t, r.opts.Path)
+	require.Len(t, records, 1)
+	require.NotNil(t, records[0].Response.Error)
+	returned := &jsonrpc.RPCResponse{}
+	require.NoError(t, json.Unmarshal(raw, returned))
+	require.NotNil(t, returned.Error)
+	// Replay compares responses returned to the client, so errors must be recorded the same way
+	assert.EqualValues(t, returned.Error.Code, records[0].Response.Error.Code)
+	assert.Equal(t, ErrorKindInsufficientFunds, records[0].Response.Error.Data.(map[string]interface{})["kind"])
+}
@@ -0,0 +1,126 @@
+package proxy
+
+import (
+	"errors"
+	"net"
+	"regexp"
+
+	"github.com/ybbus/jsonrpc"
+)
+
+// ErrSDKInsufficientFunds means the wallet balance is too low for the transaction requested.
+const ErrSDKInsufficientFunds int = -32090
+
+// ErrSDKClaimNotFound means the claim requested does not exist.
+const ErrSDKClaimNotFound int = -32091
+
+// ErrSDKWalletNotLoaded means the wallet is not currently loaded in the SDK.
+const ErrSDKWalletNotLoaded int = -32092
+
+// ErrSDKInvalidURL means a malformed LBRY URL was supplied.
+const ErrSDKInvalidURL int = -32093
+
+// ErrSDKTimeout means the SDK did not manage to complete the call in time.
+const ErrSDKTimeout int = -32094
+
+// Error kinds are supplied in `data.kind` of error responses so clients don't have to match error messages.
+const (
+	ErrorKindInsufficientFunds = "insufficient_funds"
+	ErrorKindClaimNotFound     = "claim_not_found"
+	ErrorKindWalletNotLoaded   = "wallet_not_loaded"
+	ErrorKindInvalidURL        = "invalid_url"
+	ErrorKindTimeout           = "timeout"
+	// ErrorKindUnknown is for SDK errors which couldn't be classified, they retain their original code.
+	ErrorKindUnknown = "unknown"
+)
+
+type sdkErrorClass struct {
+	re   *regexp.Regexp
+	code int
+	kind string
+}
+
+// sdkErrorClasses are checked in order, first matching class is applied to the error.
+var sdkErrorClasses = []sdkErrorClass{
+	{regexp.MustCompile(`(?i)(not enough|insufficient) funds`), ErrSDKInsufficientFunds, ErrorKindInsufficientFunds},
+	{regexp.MustCompile(`Couldn't find wallet:`), ErrSDKWalletNotLoaded, ErrorKindWalletNotLoaded},
+	{regexp.MustCompile(`(?i)(invalid (lbry )?url|not a valid url|could not parse url)`), ErrSDKInvalidURL, ErrorKindInvalidURL},
+	{regexp.MustCompile(`(?i)((could ?n[o']t|can't|cannot) find (the |a )?claim|claim .*not found|no claim found)`), ErrSDKClaimNotFound, ErrorKindClaimNotFound},
+	{regexp.MustCompile(`(?i)time[d ]?out`), ErrSDKTimeout, ErrorKindTimeout},
+}
+
+// ClassifiedError is a proxy error carrying a machine-readable kind.
+type ClassifiedError struct {
+	GenericError
+	kind string
+}
+
+// NewTimeoutError is for SDK calls that couldn't be completed in time.
+func NewTimeoutError(e error) ClassifiedError {
+	return ClassifiedError{GenericError{e, ErrSDKTimeout}, ErrorKindTimeout}
+}
+
+// Kind returns machine-readable error kind.
+func (e ClassifiedError) Kind() string {
+	return e.kind
+}
+
+// AsRPCResponse returns error as jsonrpc.RPCResponse with error kind supplied in data.
+func (e ClassifiedError) AsRPCResponse() *jsonrpc.RPCResponse {
+	r := e.GenericError.AsRPCResponse()
+	r.Error.Data = map[string]interface{}{"kind": e.kind}
+	return r
+}
+
+// reClientTimeout matches http client timeout errors, jsonrpc client doesn't wrap them so they can only be matched by message.
+var reClientTimeout = regexp.MustCompile(`(Client\.Timeout exceeded|context deadline exceeded|i/o timeout)`)
+
+var reNotDelivered = regexp.MustCompile(`dial (tcp|unix) `)
+
+// isNotDelivered returns true if the error happened before the query could reach the SDK, e.g. when connection is refused.
+func isNotDelivered(err error) bool {
+	return reNotDelivered.MatchString(err.Error())
+}
+
+// isTimeout returns true if the error is caused by an SDK connection timing out.
+func isTimeout(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return reClientTimeout.MatchString(err.Error())
+}
+
+// ClassifySDKError maps SDK error to one of lbrytv error codes, setting `data.kind` to the error kind.
+// Original SDK error code and data are retained in `data.sdk_code` and `data.details`.
+// Errors which couldn't be classified keep their original code and get ErrorKindUnknown.
+func ClassifySDKError(e *jsonrpc.RPCError) *jsonrpc.RPCError {
+	classified := &jsonrpc.RPCError{
+		Code:    e.Code,
+		Message: e.Message,
+	}
+	data := map[string]interface{}{"kind": ErrorKindUnknown, "sdk_code": e.Code}
+	if e.Data != nil {
+		data["details"] = e.Data
+	}
+	for _, c := range sdkErrorClasses {
+		if c.re.MatchString(e.Message) {
+			classified.Code = c.code
+			data["kind"] = c.kind
+			break
+		}
+	}
+	classified.Data = data
+	return classified
+}
+
+// classifyResponse returns a copy of the response with its error classified.
+// Responses are copied as the original ones might still be used elsewhere, e.g. by Shadow.
+func classifyResponse(r *jsonrpc.RPCResponse) *jsonrpc.RPCResponse {
+	if r == nil || r.Error == nil {
+		return r
+	}
+	classified := *r
+	classified.Error = ClassifySDKError(r.Error)
+	return &classified
+}
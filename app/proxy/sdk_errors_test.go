@@ -0,0 +1,87 @@
+package proxy
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ybbus/jsonrpc"
+)
+
+func TestClassifySDKError(t *testing.T) {
+	cases := []struct {
+		message string
+		code    int
+		kind    string
+	}{
+		{"Not enough funds to cover this transaction.", ErrSDKInsufficientFunds, ErrorKindInsufficientFunds},
+		{"Insufficient funds, need 1.0 LBC.", ErrSDKInsufficientFunds, ErrorKindInsufficientFunds},
+		{"Couldn't find wallet: lbrytv-id.123.wallet.", ErrSDKWalletNotLoaded, ErrorKindWalletNotLoaded},
+		{"Could not find claim at \"lbry://what\".", ErrSDKClaimNotFound, ErrorKindClaimNotFound},
+		{"Can't find the claim 'abc' in this account.", ErrSDKClaimNotFound, ErrorKindClaimNotFound},
+		{"Invalid LBRY URL: lbry://what#wrong#", ErrSDKInvalidURL, ErrorKindInvalidURL},
+		{"'lbry://@' is not a valid url", ErrSDKInvalidURL, ErrorKindInvalidURL},
+		{"Failed to download sd blob abc within timeout", ErrSDKTimeout, ErrorKindTimeout},
+		{"ResolveTimeoutError", ErrSDKTimeout, ErrorKindTimeout},
+		{"Invalid method requested: crazy_method.", -32601, ErrorKindUnknown},
+	}
+	for _, c := range cases {
+		t.Run(c.message, func(t *testing.T) {
+			original := &jsonrpc.RPCError{Code: -32601, Message: c.message, Data: []interface{}{"Traceback"}}
+			classified := ClassifySDKError(original)
+			assert.Equal(t, c.code, classified.Code)
+			assert.Equal(t, c.message, classified.Message)
+			assert.Equal(t, map[string]interface{}{
+				"kind":     c.kind,
+				"sdk_code": -32601,
+				"details":  []interface{}{"Traceback"},
+			}, classified.Data)
+			// Original error is left untouched
+			assert.Equal(t, -32601, original.Code)
+		})
+	}
+}
+
+func TestIsTimeout(t *testing.T) {
+	assert.True(t, isTimeout(errors.New(`rpc call resolve() on http://localhost:5279/: Post http://localhost:5279/: net/http: request canceled (Client.Timeout exceeded while awaiting headers)`)))
+	assert.False(t, isTimeout(errors.New(`rpc call resolve() on http://localhost:5279/: Post http://localhost:5279/: dial tcp [::1]:5279: connect: connection refused`)))
+}
+
+func TestIsNotDelivered(t *testing.T) {
+	assert.True(t, isNotDelivered(errors.New(`rpc call resolve() on http://localhost:5279/: Post http://localhost:5279/: dial tcp [::1]:5279: connect: connection refused`)))
+	assert.False(t, isNotDelivered(errors.New(`rpc call resolve() on http://localhost:5279/: Post http://localhost:5279/: net/http: request canceled (Client.Timeout exceeded while awaiting headers)`)))
+	assert.False(t, isNotDelivered(errors.New(`rpc call wallet_send() on http://localhost:5279/: Post http://localhost:5279/: EOF`)))
+}
+
+func TestCallerCallClassifiesSDKError(t *testing.T) {
+	var rpcResponse jsonrpc.RPCResponse
+	ts := launchDummyAPIServer([]byte(`{"jsonrpc": "2.0", "error": {"code": -32500, "message": "Not enough funds to cover this transaction."}, "id": 0}`))
+	defer ts.Close()
+
+	c := NewService(ts.URL).NewCaller()
+	c.SetWalletID("lbrytv-id.123.wallet")
+	response := c.Call(newRawRequest(t, "stream_create", map[string]interface{}{"name": "what"}))
+	require.NoError(t, json.Unmarshal(response, &rpcResponse))
+	require.NotNil(t, rpcResponse.Error)
+	assert.Equal(t, ErrSDKInsufficientFunds, rpcResponse.Error.Code)
+	assert.Equal(t, "Not enough funds to cover this transaction.", rpcResponse.Error.Message)
+	assert.Equal(t, map[string]interface{}{"kind": ErrorKindInsufficientFunds, "sdk_code": -32500.}, rpcResponse.Error.Data)
+}
+
+func TestCallerCallTimeout(t *testing.T) {
+	var rpcResponse jsonrpc.RPCResponse
+	ts := launchDummyAPIServerDelayed([]byte(`{"jsonrpc": "2.0", "result": "ok", "id": 0}`), 200)
+	defer ts.Close()
+
+	c := NewService(ts.URL).NewCaller()
+	c.client = jsonrpc.NewClientWithOpts(ts.URL, &jsonrpc.RPCClientOpts{HTTPClient: &http.Client{Timeout: 50 * time.Millisecond}})
+	response := c.Call(newRawRequest(t, MethodResolve, map[string]interface{}{"urls": "what"}))
+	require.NoError(t, json.Unmarshal(response, &rpcResponse))
+	require.NotNil(t, rpcResponse.Error)
+	assert.Equal(t, ErrSDKTimeout, rpcResponse.Error.Code)
+	assert.Equal(t, map[string]interface{}{"kind": ErrorKindTimeout}, rpcResponse.Error.Data)
+}
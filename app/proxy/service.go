@@ -11,20 +11,26 @@ import (
 	"time"
 
 	ljsonrpc "github.com/lbryio/lbry.go/v2/extras/jsonrpc"
-	"github.com/lbryio/lbrytv/internal/metrics"
+	"github.com/lbryio/lbrytv/app/users"
+	"github.com/lbryio/lbrytv/internal/maintenance"
 	"github.com/lbryio/lbrytv/internal/monitor"
+	"github.com/lbryio/lbrytv/models"
 
 	"github.com/ybbus/jsonrpc"
 )
 
 type Preprocessor func(q *Query)
 
-// Service generates Caller objects and keeps execution time metrics
-// for all calls proxied through those objects.
+// Service generates Caller objects, calls proxied through those objects
+// are reported in CallsTotal and CallDurations metrics.
 type Service struct {
-	*metrics.Collector
 	TargetEndpoint string
 	logger         monitor.QueryMonitor
+	recorder       *Recorder
+	shadow         *Shadow
+	walletLocker   *WalletLocker
+	idempotency    *Idempotency
+	spendingLimits *SpendingLimits
 }
 
 // Caller patches through JSON-RPC requests from clients, doing pre/post-processing,
@@ -35,6 +41,12 @@ type Caller struct {
 	client       jsonrpc.RPCClient
 	service      *Service
 	preprocessor Preprocessor
+	format       ResponseFormat
+	requestID    string
+	// idempotencyKey is supplied by the client to make retries of mutating calls safe
+	idempotencyKey string
+	// scopes limit methods the client can call, nil if there are no limits
+	scopes users.Scopes
 }
 
 // Query is a wrapper around client JSON-RPC query for easier (un)marshaling and processing.
@@ -42,19 +54,45 @@ type Query struct {
 	Request    *jsonrpc.RPCRequest
 	rawRequest []byte
 	walletID   string
+	scopes     users.Scopes
 }
 
 // NewService is the entry point to proxy module.
 // Normally only one instance of Service should be created per running server.
 func NewService(targetEndpoint string) *Service {
 	s := Service{
-		Collector:      metrics.NewCollector(),
 		TargetEndpoint: targetEndpoint,
 		logger:         monitor.NewProxyLogger(),
 	}
 	return &s
 }
 
+// SetRecorder makes all callers created by the service record queries they proxy to the SDK.
+func (ps *Service) SetRecorder(r *Recorder) {
+	ps.recorder = r
+}
+
+// SetShadow makes all callers created by the service mirror a share of queries to a canary SDK.
+func (ps *Service) SetShadow(s *Shadow) {
+	ps.shadow = s
+}
+
+// SetWalletLocker makes all callers created by the service serialize wallet-mutating calls per wallet.
+func (ps *Service) SetWalletLocker(l *WalletLocker) {
+	ps.walletLocker = l
+}
+
+// SetIdempotency makes all callers created by the service store responses of mutating calls
+// made with idempotency keys.
+func (ps *Service) SetIdempotency(i *Idempotency) {
+	ps.idempotency = i
+}
+
+// SetSpendingLimits makes all callers created by the service check calls sending LBC out of wallets against limits.
+func (ps *Service) SetSpendingLimits(l *SpendingLimits) {
+	ps.spendingLimits = l
+}
+
 // NewCaller returns an instance of Caller ready to proxy requests.
 // Note that `SetWalletID` needs to be called if an authenticated user is making this call.
 func (ps *Service) NewCaller() *Caller {
@@ -131,6 +169,11 @@ func (q *Query) SetWalletID(id string) {
 	q.walletID = id
 }
 
+// SetScopes limits methods query is allowed to call.
+func (q *Query) SetScopes(scopes users.Scopes) {
+	q.scopes = scopes
+}
+
 // cacheHit returns cached response or nil in case it's a miss or query shouldn't be cacheable.
 func (q *Query) cacheHit() *jsonrpc.RPCResponse {
 	if q.isCacheable() {
@@ -150,10 +193,15 @@ func (q *Query) cacheHit() *jsonrpc.RPCResponse {
 }
 
 func (q *Query) predefinedResponse() *jsonrpc.RPCResponse {
-	if q.Method() == MethodStatus {
+	switch q.Method() {
+	case MethodStatus:
 		response := q.newResponse()
 		response.Result = getStatusResponse()
 		return response
+	case MethodDiscover:
+		response := q.newResponse()
+		response.Result = getDiscoverResponse()
+		return response
 	}
 	return nil
 }
@@ -162,6 +210,9 @@ func (q *Query) validate() CallError {
 	if !methodInList(q.Method(), relaxedMethods) && !methodInList(q.Method(), walletSpecificMethods) {
 		return NewMethodError(errors.New("forbidden method"))
 	}
+	if !q.scopes.Allows(q.Method()) {
+		return NewMethodError(fmt.Errorf("method %v is outside of credentials scopes", q.Method()))
+	}
 	if q.ParamsAsMap() != nil {
 		if _, ok := q.ParamsAsMap()[forbiddenParam]; ok {
 			return NewParamsError(fmt.Errorf("forbidden parameter supplied: %v", forbiddenParam))
@@ -193,13 +244,58 @@ func (c *Caller) SetWalletID(id string) {
 	c.walletID = id
 }
 
+// SetScopes limits methods the client is allowed to call.
+func (c *Caller) SetScopes(scopes users.Scopes) {
+	c.scopes = scopes
+}
+
 // WalletID is an SDK wallet ID for the client this caller instance is serving.
 func (c *Caller) WalletID() string {
 	return c.walletID
 }
 
+// SetRequestID sets ID of the client request this caller is serving. It is attached to log entries
+// and forwarded to the SDK in X-Request-Id header, so it should be set before making any calls.
+func (c *Caller) SetRequestID(id string) {
+	c.requestID = id
+	if id == "" {
+		return
+	}
+	c.client = jsonrpc.NewClientWithOpts(c.service.TargetEndpoint, &jsonrpc.RPCClientOpts{
+		CustomHeaders: map[string]string{monitor.RequestIDHeader: id},
+	})
+}
+
+// SetIdempotencyKey sets idempotency key supplied by the client in Idempotency-Key header.
+// Key supplied in `idempotency_key` param of the call takes precedence over it.
+func (c *Caller) SetIdempotencyKey(key string) {
+	c.idempotencyKey = key
+}
+
+// RequestID is an ID of the client request this caller is serving.
+func (c *Caller) RequestID() string {
+	return c.requestID
+}
+
+func (c *Caller) logger() monitor.QueryMonitor {
+	if c.requestID != "" {
+		return c.service.logger.WithRequestID(c.requestID)
+	}
+	return c.service.logger
+}
+
+// SetFormat sets serialization format for responses returned by Call. Compact JSON is used by default.
+func (c *Caller) SetFormat(f ResponseFormat) {
+	c.format = f
+}
+
+// Format is the serialization format of responses returned by Call.
+func (c *Caller) Format() ResponseFormat {
+	return c.format
+}
+
 func (c *Caller) marshal(r *jsonrpc.RPCResponse) ([]byte, CallError) {
-	serialized, err := json.MarshalIndent(r, "", "  ")
+	serialized, err := c.format.Marshal(r)
 	if err != nil {
 		return nil, NewError(err)
 	}
@@ -207,7 +303,7 @@ func (c *Caller) marshal(r *jsonrpc.RPCResponse) ([]byte, CallError) {
 }
 
 func (c *Caller) marshalError(e CallError) []byte {
-	serialized, err := json.MarshalIndent(e.AsRPCResponse(), "", "  ")
+	serialized, err := c.format.Marshal(e.AsRPCResponse())
 	if err != nil {
 		return []byte(err.Error())
 	}
@@ -223,25 +319,53 @@ func (c *Caller) sendQuery(q *Query) (*jsonrpc.RPCResponse, error) {
 }
 
 func (c *Caller) call(rawQuery []byte) (*jsonrpc.RPCResponse, CallError) {
+	var method string
+	outcome := OutcomeOK
+	callStartTime := time.Now()
+	defer func() { observeCall(method, outcome, c.WalletID(), time.Since(callStartTime)) }()
+
 	q, err := NewQuery(rawQuery)
 	if err != nil {
-		c.service.logger.Errorf("malformed JSON from client: %s", err.Error())
+		c.logger().Errorf("malformed JSON from client: %s", err.Error())
+		outcome = OutcomeRejected
 		return nil, NewParseError(err)
 	}
+	method = q.Method()
 
 	if c.WalletID() != "" {
 		q.SetWalletID(c.WalletID())
 	}
+	q.SetScopes(c.scopes)
+
+	idempotencyKey := c.idempotencyKey
+	if p := q.ParamsAsMap(); p != nil {
+		if key, ok := p[paramIdempotencyKey]; ok {
+			delete(p, paramIdempotencyKey)
+			idempotencyKey = fmt.Sprintf("%v", key)
+		}
+	}
+	if err := validateIdempotencyKey(idempotencyKey); err != nil {
+		outcome = OutcomeRejected
+		return nil, err
+	}
 
 	// Check for account identificator (wallet ID) for account-specific methods happens here
 	if err := q.validate(); err != nil {
+		outcome = OutcomeRejected
 		return nil, err
 	}
 
+	if isBlockedByMaintenance(q.Method()) {
+		outcome = OutcomeRejected
+		return nil, NewMaintenanceError(maintenance.Get())
+	}
+
 	if cachedResponse := q.cacheHit(); cachedResponse != nil {
+		outcome = OutcomeCacheHit
 		return cachedResponse, nil
 	}
 	if predefinedResponse := q.predefinedResponse(); predefinedResponse != nil {
+		outcome = OutcomeCacheHit
 		return predefinedResponse, nil
 	}
 
@@ -249,26 +373,107 @@ func (c *Caller) call(rawQuery []byte) (*jsonrpc.RPCResponse, CallError) {
 		c.preprocessor(q)
 	}
 
+	if c.service.walletLocker != nil && q.walletID != "" && methodInList(q.Method(), writeMethods) {
+		unlock, err := c.service.walletLocker.Lock(q.walletID)
+		if err != nil {
+			outcome = OutcomeRejected
+			return nil, err
+		}
+		defer unlock()
+	}
+
+	var (
+		finalResponse *jsonrpc.RPCResponse
+		// delivered is set once the query might have reached the SDK, so its outcome is unknown without a response
+		delivered bool
+	)
+	if c.service.idempotency != nil && idempotencyKey != "" && q.walletID != "" && methodInList(q.Method(), writeMethods) {
+		storedResponse, err := c.service.idempotency.begin(q, idempotencyKey)
+		if err != nil {
+			outcome = OutcomeRejected
+			return nil, err
+		}
+		if storedResponse != nil {
+			outcome = OutcomeCacheHit
+			return storedResponse, nil
+		}
+		defer func() { c.service.idempotency.finish(q, idempotencyKey, finalResponse, delivered) }()
+	}
+
+	var spending *models.Spending
+	if c.service.spendingLimits != nil && q.walletID != "" {
+		amount, err := spendingAmount(q)
+		if err != nil {
+			outcome = OutcomeRejected
+			return nil, NewParamsError(err)
+		}
+		if amount > 0 {
+			uid, cErr := spendingUserID(q.walletID)
+			if cErr != nil {
+				outcome = OutcomeRejected
+				return nil, cErr
+			}
+			spending, cErr = c.service.spendingLimits.reserve(uid, q.Method(), amount)
+			if cErr != nil {
+				outcome = OutcomeRejected
+				return nil, cErr
+			}
+		}
+	}
+
 	queryStartTime := time.Now()
 	r, err := c.sendQuery(q)
+	delivered = err == nil || !isNotDelivered(err)
 	if err != nil {
+		// Spending stays reserved if the call might have gone through
+		if spending != nil && !delivered {
+			c.service.spendingLimits.cancel(spending)
+		}
+		outcome = OutcomeTransportError
+		if isTimeout(err) {
+			return r, NewTimeoutError(err)
+		}
 		return r, NewInternalError(err)
 	}
-	execTime := time.Now().Sub(queryStartTime).Seconds()
 
-	c.service.SetMetricsValue(q.Method(), execTime, q.Params())
+	// Wallets get unloaded when the SDK restarts, load it back and retry the call once
+	if q.walletID != "" && !methodInList(q.Method(), relaxedMethods) && isWalletNotLoaded(r.Error) {
+		if err := reloadWallet(q.walletID); err != nil {
+			c.logger().Errorf("cannot reload wallet %v: %v", q.walletID, err)
+		} else if retried, err := c.sendQuery(q); err == nil {
+			r = retried
+		}
+	}
+	execTime := time.Now().Sub(queryStartTime).Seconds()
 
 	if r.Error != nil {
-		c.service.logger.LogFailedQuery(q.Method(), q.Params(), r.Error)
+		outcome = OutcomeSDKError
+		c.logger().LogFailedQuery(q.Method(), q.Params(), r.Error)
+		if spending != nil {
+			c.service.spendingLimits.cancel(spending)
+		}
 	} else {
-		c.service.logger.LogSuccessfulQuery(q.Method(), execTime, q.Params())
+		c.logger().LogSuccessfulQuery(q.Method(), execTime, q.Params())
 	}
 
 	r, err = processResponse(q.Request, r)
 
+	if c.service.shadow != nil {
+		// Mirrored responses are kept as returned by the SDK so they can be compared to the shadow SDK responses
+		c.service.shadow.Mirror(q.Request, r)
+	}
+
+	r = classifyResponse(r)
+
+	// Responses are recorded as returned to the client, that's what `lbrytv replay` compares them against
+	if c.service.recorder != nil {
+		c.service.recorder.Record(q.Method(), q.Params(), r, execTime)
+	}
+
 	if q.isCacheable() {
 		responseCache.Save(q.Method(), q.Params(), r)
 	}
+	finalResponse = r
 	return r, nil
 }
 
@@ -277,14 +482,16 @@ func (c *Caller) call(rawQuery []byte) (*jsonrpc.RPCResponse, CallError) {
 func (c *Caller) Call(rawQuery []byte) []byte {
 	r, err := c.call(rawQuery)
 	if err != nil {
-		monitor.CaptureException(err, map[string]string{"query": string(rawQuery), "response": fmt.Sprintf("%v", r)})
-		c.service.logger.Errorf("error calling lbrynet: %v, query: %s", err, rawQuery)
+		monitor.CaptureException(err, map[string]string{
+			"query": string(rawQuery), "response": fmt.Sprintf("%v", r), monitor.RequestIDF: c.requestID,
+		})
+		c.logger().Errorf("error calling lbrynet: %v, query: %s", err, rawQuery)
 		return c.marshalError(err)
 	}
 	serialized, err := c.marshal(r)
 	if err != nil {
 		monitor.CaptureException(err)
-		c.service.logger.Errorf("error marshaling response: %v", err)
+		c.logger().Errorf("error marshaling response: %v", err)
 		return c.marshalError(err)
 	}
 	return serialized
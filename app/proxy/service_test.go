@@ -11,10 +11,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/lbryio/lbrytv/app/users"
 	"github.com/lbryio/lbrytv/config"
 	"github.com/lbryio/lbrytv/internal/lbrynet"
 
 	ljsonrpc "github.com/lbryio/lbry.go/v2/extras/jsonrpc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	logrus_test "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -28,6 +32,12 @@ func prettyPrint(i interface{}) {
 	fmt.Println(string(s))
 }
 
+func histogramValues(t *testing.T, o prometheus.Observer) (uint64, float64) {
+	var m dto.Metric
+	require.NoError(t, o.(prometheus.Metric).Write(&m))
+	return m.Histogram.GetSampleCount(), m.Histogram.GetSampleSum()
+}
+
 func newRawRequest(t *testing.T, method string, params interface{}) []byte {
 	var (
 		body []byte
@@ -105,8 +115,42 @@ func TestCallerMetrics(t *testing.T) {
 		client:  &ClientMock{Delay: 250 * time.Millisecond},
 		service: svc,
 	}
+	callsBefore := testutil.ToFloat64(CallsTotal.WithLabelValues("resolve", OutcomeOK, AuthAnonymous))
+	samplesBefore, sumBefore := histogramValues(t, CallDurations.WithLabelValues("resolve", OutcomeOK, AuthAnonymous))
+
 	c.Call([]byte(newRawRequest(t, "resolve", map[string]string{"urls": "what"})))
-	assert.Equal(t, 0.25, math.Round(svc.GetMetricsValue("resolve").Value*100)/100)
+
+	samples, sum := histogramValues(t, CallDurations.WithLabelValues("resolve", OutcomeOK, AuthAnonymous))
+	assert.Equal(t, callsBefore+1, testutil.ToFloat64(CallsTotal.WithLabelValues("resolve", OutcomeOK, AuthAnonymous)))
+	assert.Equal(t, samplesBefore+1, samples)
+	assert.Equal(t, 0.25, math.Round((sum-sumBefore)*100)/100)
+}
+
+func TestCallerMetricsOutcomes(t *testing.T) {
+	ts := launchDummyAPIServer([]byte(`{"jsonrpc": "2.0", "error": {"code": -32500, "message": "oops"}, "id": 0}`))
+	defer ts.Close()
+
+	cases := []struct {
+		method, walletID, endpoint, outcome, auth, label string
+	}{
+		{"resolve", "", ts.URL, OutcomeSDKError, AuthAnonymous, "resolve"},
+		{"account_balance", "lbrytv-id.123.wallet", ts.URL, OutcomeSDKError, AuthAuthenticated, "account_balance"},
+		{"account_balance", "", ts.URL, OutcomeRejected, AuthAnonymous, "account_balance"},
+		{"resolve", "", "http://localhost:59997", OutcomeTransportError, AuthAnonymous, "resolve"},
+		{"status", "", ts.URL, OutcomeCacheHit, AuthAnonymous, "status"},
+		// Unknown method names are not used as label values
+		{"crazy_method", "", ts.URL, OutcomeRejected, AuthAnonymous, "other"},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v_%v", c.method, c.outcome), func(t *testing.T) {
+			counter := CallsTotal.WithLabelValues(c.label, c.outcome, c.auth)
+			before := testutil.ToFloat64(counter)
+			caller := NewService(c.endpoint).NewCaller()
+			caller.SetWalletID(c.walletID)
+			caller.Call(newRawRequest(t, c.method, nil))
+			assert.Equal(t, before+1, testutil.ToFloat64(counter))
+		})
+	}
 }
 
 func TestCallerCallResolve(t *testing.T) {
@@ -122,7 +166,8 @@ func TestCallerCallResolve(t *testing.T) {
 	rawCallReponse := c.Call(request)
 	parseRawResponse(t, rawCallReponse, &resolveResponse)
 	assert.Equal(t, resolvedClaimID, resolveResponse[resolvedURL].ClaimID)
-	assert.True(t, svc.GetMetricsValue("resolve").Value > 0)
+	samples, _ := histogramValues(t, CallDurations.WithLabelValues("resolve", OutcomeOK, AuthAnonymous))
+	assert.True(t, samples > 0)
 }
 
 func TestCallerCallAccountBalance(t *testing.T) {
@@ -139,7 +184,7 @@ func TestCallerCallAccountBalance(t *testing.T) {
 	request := newRawRequest(t, "account_balance", nil)
 	result := c.Call(request)
 
-	assert.Contains(t, string(result), `"message": "account identificator required"`)
+	assert.Contains(t, string(result), `"message":"account identificator required"`)
 
 	c.SetWalletID(wid)
 	request = newRawRequest(t, "account_balance", nil)
@@ -154,7 +199,7 @@ func TestCallerCallAccountBalance(t *testing.T) {
 
 func TestCallerCallRelaxedMethods(t *testing.T) {
 	for _, m := range relaxedMethods {
-		if m == MethodStatus {
+		if m == MethodStatus || m == MethodDiscover {
 			continue
 		}
 		mockClient := &ClientMock{}
@@ -184,7 +229,7 @@ func TestCallerCallNonRelaxedMethods(t *testing.T) {
 		}
 		request := newRawRequest(t, m, nil)
 		result := c.Call(request)
-		assert.Contains(t, string(result), `"message": "account identificator required"`)
+		assert.Contains(t, string(result), `"message":"account identificator required"`)
 	}
 }
 
@@ -197,7 +242,7 @@ func TestCallerCallForbiddenMethod(t *testing.T) {
 	}
 	request := newRawRequest(t, "stop", nil)
 	result := c.Call(request)
-	assert.Contains(t, string(result), `"message": "forbidden method"`)
+	assert.Contains(t, string(result), `"message":"forbidden method"`)
 }
 
 func TestCallerCallAttachesAccountId(t *testing.T) {
@@ -339,3 +384,26 @@ func TestQueryParamsAsMap(t *testing.T) {
 	q, _ = NewQuery(newRawRequest(t, "claim_search", searchParams))
 	assert.Equal(t, searchParams, q.ParamsAsMap())
 }
+
+func TestCallerScopes(t *testing.T) {
+	ts, requests := launchCountingSDK()
+	defer ts.Close()
+
+	c := NewService(ts.URL).NewCaller()
+	c.SetWalletID("lbrytv-id.1.wallet")
+	c.SetScopes(users.Scopes{"publish", "claim_list"})
+
+	_, err := c.call(newRawRequest(t, "wallet_send", map[string]interface{}{"amount": "1.0", "addresses": "bX"}))
+	require.NotNil(t, err)
+	assert.Equal(t, ErrMethodUnavailable, err.Code())
+	assert.Equal(t, "method wallet_send is outside of credentials scopes", err.Error())
+
+	// Relaxed methods are limited too
+	_, err = c.call(newRawRequest(t, "resolve", map[string]interface{}{"urls": "what"}))
+	require.NotNil(t, err)
+	assert.Len(t, requests(), 0)
+
+	_, err = c.call(newRawRequest(t, "claim_list", nil))
+	require.Nil(t, err)
+	assert.Len(t, requests(), 1)
+}
@@ -0,0 +1,136 @@
+package proxy
+
+import (
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/monitor"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/ybbus/jsonrpc"
+)
+
+// Outcomes of a mirrored query as reported by ShadowQueriesTotal.
+const (
+	ShadowOutcomeMatch   = "match"
+	ShadowOutcomeDiff    = "diff"
+	ShadowOutcomeError   = "error"
+	ShadowOutcomeDropped = "dropped"
+)
+
+// maxLoggedDiffs limits the number of differences logged for a single mismatched response.
+const maxLoggedDiffs = 5
+
+// ShadowQueriesTotal counts queries mirrored to the canary SDK by method and comparison outcome.
+var ShadowQueriesTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: "proxy",
+		Name:      "shadow_queries_total",
+		Help:      "Number of queries mirrored to the canary SDK by comparison outcome.",
+	},
+	[]string{"method", "outcome"},
+)
+
+// ShadowOpts contains settings for mirroring traffic to a canary SDK.
+type ShadowOpts struct {
+	// Endpoint is the canary SDK JSON-RPC address.
+	Endpoint string
+	// Percentage of relaxed method calls to mirror, 0 to 100.
+	Percentage float64
+	// Concurrency is the maximum number of mirrored queries in flight,
+	// queries exceeding it are dropped instead of being queued.
+	Concurrency int
+	// Timeout for a single canary SDK call.
+	Timeout time.Duration
+	// Ignore lists response fields that are skipped when comparing, VolatileFields are used if it's nil.
+	Ignore []string
+}
+
+// Shadow mirrors queries to a canary SDK instance in the background
+// and compares its responses with the ones returned by the primary SDK.
+type Shadow struct {
+	opts   ShadowOpts
+	client jsonrpc.RPCClient
+	slots  chan struct{}
+	logger monitor.ModuleLogger
+	// done is called after each mirrored query is processed, only used in tests.
+	done func()
+}
+
+// NewShadow creates a Shadow mirroring queries to a canary SDK according to the supplied options.
+func NewShadow(opts ShadowOpts) *Shadow {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.Ignore == nil {
+		opts.Ignore = VolatileFields
+	}
+	return &Shadow{
+		opts: opts,
+		client: jsonrpc.NewClientWithOpts(opts.Endpoint, &jsonrpc.RPCClientOpts{
+			HTTPClient: &http.Client{Timeout: opts.Timeout},
+		}),
+		slots:  make(chan struct{}, opts.Concurrency),
+		logger: monitor.NewModuleLogger("proxy_shadow"),
+	}
+}
+
+// Mirror sends the query to the canary SDK if it gets sampled and compares the outcome to the primary response.
+// It never blocks: the query is dropped when too many mirrored queries are already in flight.
+func (s *Shadow) Mirror(request *jsonrpc.RPCRequest, primary *jsonrpc.RPCResponse) {
+	if !MethodRequiresWallet(request.Method) && rand.Float64()*100 < s.opts.Percentage {
+		select {
+		case s.slots <- struct{}{}:
+			go func() {
+				defer func() { <-s.slots }()
+				s.compare(request, primary)
+			}()
+		default:
+			ShadowQueriesTotal.WithLabelValues(request.Method, ShadowOutcomeDropped).Inc()
+		}
+	}
+}
+
+func (s *Shadow) compare(request *jsonrpc.RPCRequest, primary *jsonrpc.RPCResponse) {
+	if s.done != nil {
+		defer s.done()
+	}
+
+	canary, err := s.client.CallRaw(request)
+	if err == nil {
+		canary, err = processResponse(request, canary)
+	}
+	if err != nil {
+		ShadowQueriesTotal.WithLabelValues(request.Method, ShadowOutcomeError).Inc()
+		s.logger.LogF(monitor.F{"method": request.Method}).Warnf("canary sdk call failed: %v", err)
+		return
+	}
+
+	// Request IDs differ between the two SDK calls so only result and error are compared
+	diffs, err := DiffResponses(
+		map[string]interface{}{"result": primary.Result, "error": primary.Error},
+		map[string]interface{}{"result": canary.Result, "error": canary.Error},
+		s.opts.Ignore,
+	)
+	if err != nil {
+		ShadowQueriesTotal.WithLabelValues(request.Method, ShadowOutcomeError).Inc()
+		s.logger.LogF(monitor.F{"method": request.Method}).Warnf("cannot compare canary sdk response: %v", err)
+		return
+	}
+	if len(diffs) == 0 {
+		ShadowQueriesTotal.WithLabelValues(request.Method, ShadowOutcomeMatch).Inc()
+		return
+	}
+
+	ShadowQueriesTotal.WithLabelValues(request.Method, ShadowOutcomeDiff).Inc()
+	sample := diffs
+	if len(sample) > maxLoggedDiffs {
+		sample = sample[:maxLoggedDiffs]
+	}
+	s.logger.LogF(monitor.F{
+		"method": request.Method,
+		"params": request.Params,
+		"diffs":  len(diffs),
+	}).Warnf("canary sdk response differs: %v", sample)
+}
@@ -0,0 +1,119 @@
+package proxy
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ybbus/jsonrpc"
+)
+
+func newTestShadow(endpoint string, percentage float64) (*Shadow, *sync.WaitGroup) {
+	wg := &sync.WaitGroup{}
+	s := NewShadow(ShadowOpts{Endpoint: endpoint, Percentage: percentage, Concurrency: 1, Timeout: time.Second})
+	s.done = wg.Done
+	return s, wg
+}
+
+func shadowCount(method, outcome string) float64 {
+	return testutil.ToFloat64(ShadowQueriesTotal.WithLabelValues(method, outcome))
+}
+
+func TestShadowMirrorMatch(t *testing.T) {
+	ts := launchDummyAPIServer([]byte(`{"jsonrpc": "2.0", "result": {"what": {"name": "what", "height": 101}}, "id": 0}`))
+	defer ts.Close()
+	s, wg := newTestShadow(ts.URL, 100)
+	before := shadowCount(MethodResolve, ShadowOutcomeMatch)
+
+	wg.Add(1)
+	s.Mirror(
+		jsonrpc.NewRequest(MethodResolve, map[string]interface{}{"urls": "what"}),
+		&jsonrpc.RPCResponse{Result: map[string]interface{}{"what": map[string]interface{}{"name": "what", "height": 100}}},
+	)
+	wg.Wait()
+	assert.Equal(t, before+1, shadowCount(MethodResolve, ShadowOutcomeMatch))
+}
+
+func TestShadowMirrorDiff(t *testing.T) {
+	ts := launchDummyAPIServer([]byte(`{"jsonrpc": "2.0", "result": {"what": {"name": "what", "title": "two"}}, "id": 0}`))
+	defer ts.Close()
+	s, wg := newTestShadow(ts.URL, 100)
+	hook := test.NewLocal(s.logger.Logger)
+	before := shadowCount(MethodResolve, ShadowOutcomeDiff)
+
+	wg.Add(1)
+	s.Mirror(
+		jsonrpc.NewRequest(MethodResolve, map[string]interface{}{"urls": "what"}),
+		&jsonrpc.RPCResponse{Result: map[string]interface{}{"what": map[string]interface{}{"name": "what", "title": "one"}}},
+	)
+	wg.Wait()
+	assert.Equal(t, before+1, shadowCount(MethodResolve, ShadowOutcomeDiff))
+	require.NotNil(t, hook.LastEntry())
+	assert.Equal(t, `canary sdk response differs: [result.what.title: expected "one", got "two"]`, hook.LastEntry().Message)
+	assert.Equal(t, 1, hook.LastEntry().Data["diffs"])
+}
+
+func TestShadowMirrorError(t *testing.T) {
+	s, wg := newTestShadow("http://localhost:59997", 100)
+	before := shadowCount(MethodClaimSearch, ShadowOutcomeError)
+
+	wg.Add(1)
+	s.Mirror(jsonrpc.NewRequest(MethodClaimSearch), &jsonrpc.RPCResponse{Result: "ok"})
+	wg.Wait()
+	assert.Equal(t, before+1, shadowCount(MethodClaimSearch, ShadowOutcomeError))
+}
+
+func TestShadowMirrorSkipped(t *testing.T) {
+	ts := launchDummyAPIServer([]byte(`{"jsonrpc": "2.0", "result": "ok", "id": 0}`))
+	defer ts.Close()
+
+	s, _ := newTestShadow(ts.URL, 0)
+	s.done = func() { t.Error("query shouldn't have been mirrored") }
+	s.Mirror(jsonrpc.NewRequest(MethodResolve), &jsonrpc.RPCResponse{Result: "ok"})
+
+	// Wallet-specific methods are never mirrored
+	s, _ = newTestShadow(ts.URL, 100)
+	s.done = func() { t.Error("query shouldn't have been mirrored") }
+	s.Mirror(jsonrpc.NewRequest(MethodAccountBalance), &jsonrpc.RPCResponse{Result: "ok"})
+
+	time.Sleep(100 * time.Millisecond)
+}
+
+func TestShadowMirrorDropped(t *testing.T) {
+	ts := launchDummyAPIServer([]byte(`{"jsonrpc": "2.0", "result": "ok", "id": 0}`))
+	defer ts.Close()
+	s, _ := newTestShadow(ts.URL, 100)
+	s.done = func() { t.Error("query shouldn't have been mirrored") }
+	before := shadowCount(MethodResolve, ShadowOutcomeDropped)
+
+	// All slots are taken by queries in flight
+	s.slots <- struct{}{}
+	s.Mirror(jsonrpc.NewRequest(MethodResolve), &jsonrpc.RPCResponse{Result: "ok"})
+	assert.Equal(t, before+1, shadowCount(MethodResolve, ShadowOutcomeDropped))
+}
+
+func TestCallerMirrorsQueries(t *testing.T) {
+	primary := launchDummyAPIServer([]byte(`{"jsonrpc": "2.0", "result": {"what": {"name": "what"}}, "id": 0}`))
+	defer primary.Close()
+	canary := launchDummyAPIServerDelayed([]byte(`{"jsonrpc": "2.0", "result": {"what": {"name": "what"}}, "id": 0}`), 500)
+	defer canary.Close()
+
+	svc := NewService(primary.URL)
+	s, wg := newTestShadow(canary.URL, 100)
+	svc.SetShadow(s)
+	before := shadowCount(MethodResolve, ShadowOutcomeMatch)
+
+	wg.Add(1)
+	start := time.Now()
+	resp := svc.NewCaller().Call(newRawRequest(t, MethodResolve, map[string]interface{}{"urls": "what"}))
+	// Client doesn't wait for the canary SDK
+	assert.True(t, time.Since(start) < 500*time.Millisecond)
+	assert.Contains(t, string(resp), `"name":"what"`)
+
+	wg.Wait()
+	assert.Equal(t, before+1, shadowCount(MethodResolve, ShadowOutcomeMatch))
+}
@@ -0,0 +1,215 @@
+package proxy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"time"
+
+	"github.com/lbryio/lbrytv/app/users"
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/internal/monitor"
+	"github.com/lbryio/lbrytv/models"
+
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// ErrSpendingLimitExceeded means the call would send more LBC out of the wallet than the user is allowed to.
+const ErrSpendingLimitExceeded int = -32098
+
+// ErrorKindSpendingLimitExceeded is supplied in `data.kind` of ErrSpendingLimitExceeded responses.
+const ErrorKindSpendingLimitExceeded = "spending_limit_exceeded"
+
+// spendingWindow is the period daily limit applies to, it's rolling so limits don't reset all at once.
+const spendingWindow = 24 * time.Hour
+
+// spendingAmount returns how many deweys the query is going to send out of the wallet.
+// Only wallet_send, account_send and tips made with support_create are counted.
+func spendingAmount(q *Query) (int64, error) {
+	p := q.ParamsAsMap()
+	switch q.Method() {
+	case "wallet_send", "account_send":
+	case "support_create":
+		// Supports which aren't tips stay in the wallet
+		if tip, _ := p["tip"].(bool); !tip {
+			return 0, nil
+		}
+	default:
+		return 0, nil
+	}
+
+	var amount string
+	switch v := p["amount"].(type) {
+	case string:
+		amount = v
+	case float64:
+		amount = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return 0, errors.New("amount is required")
+	}
+	deweys, err := lbrynet.ParseLBC(amount)
+	if err != nil {
+		return 0, err
+	}
+	// The same amount is sent to each of the addresses
+	if addresses, ok := p["addresses"].([]interface{}); ok && len(addresses) > 1 {
+		deweys *= int64(len(addresses))
+	}
+	return deweys, nil
+}
+
+// SpendingLimitsOpts contains default spending limits in deweys, 0 means no limit.
+type SpendingLimitsOpts struct {
+	Daily          int64
+	PerTransaction int64
+}
+
+// SpendingLimits keeps track of LBC sent out of user wallets and rejects calls exceeding the limits.
+// Limits can be overridden for specific users with SetUserSpendingLimit, overrides apply
+// even if there are no default limits.
+type SpendingLimits struct {
+	opts   SpendingLimitsOpts
+	logger monitor.ModuleLogger
+}
+
+// NewSpendingLimits creates spending limits checker, which should be passed to Service.SetSpendingLimits.
+func NewSpendingLimits(opts SpendingLimitsOpts) *SpendingLimits {
+	return &SpendingLimits{opts: opts, logger: monitor.NewModuleLogger("spending_limits")}
+}
+
+// limitsFor returns limits applying to the user, user-specific limits take precedence over the defaults.
+func (s *SpendingLimits) limitsFor(exec boil.Executor, uid int) (SpendingLimitsOpts, error) {
+	limits := s.opts
+	override, err := models.FindSpendingLimit(exec, uid)
+	if err == sql.ErrNoRows {
+		return limits, nil
+	} else if err != nil {
+		return limits, err
+	}
+	if override.DailyLimit.Valid {
+		limits.Daily = override.DailyLimit.Int64
+	}
+	if override.TransactionLimit.Valid {
+		limits.PerTransaction = override.TransactionLimit.Int64
+	}
+	return limits, nil
+}
+
+// spentToday returns the number of deweys the user has sent out during spendingWindow.
+func spentToday(exec boil.Executor, uid int) (int64, error) {
+	var spent int64
+	err := models.Spendings(
+		qm.Select("COALESCE(SUM(amount), 0)"),
+		models.SpendingWhere.UserID.EQ(uid),
+		models.SpendingWhere.CreatedAt.GT(time.Now().Add(-spendingWindow)),
+	).QueryRow(exec).Scan(&spent)
+	return spent, err
+}
+
+// reserve returns an error if sending the amount would exceed user limits, otherwise the amount is recorded
+// as spent right away. Check and record are done in one transaction holding a per-user lock, so concurrent calls
+// from any of user's wallets or from other lbrytv replicas can't exceed the limits together.
+// Reserved spending should be cancelled if the call fails.
+func (s *SpendingLimits) reserve(uid int, method string, amount int64) (*models.Spending, CallError) {
+	tx, err := boil.BeginTx(context.Background(), nil)
+	if err != nil {
+		return nil, NewInternalError(err)
+	}
+	defer tx.Rollback()
+
+	if _, err := queries.Raw("SELECT pg_advisory_xact_lock($1)", spendingLockKey(uid)).Exec(tx); err != nil {
+		return nil, NewInternalError(err)
+	}
+	limits, err := s.limitsFor(tx, uid)
+	if err != nil {
+		return nil, NewInternalError(err)
+	}
+	if limits.PerTransaction != 0 && amount > limits.PerTransaction {
+		return nil, limitExceededError(fmt.Errorf("transaction limit of %v LBC exceeded", lbrynet.FormatLBC(limits.PerTransaction)))
+	}
+	if limits.Daily != 0 {
+		spent, err := spentToday(tx, uid)
+		if err != nil {
+			return nil, NewInternalError(err)
+		}
+		if spent+amount > limits.Daily {
+			return nil, limitExceededError(fmt.Errorf(
+				"daily limit of %v LBC exceeded, %v LBC can be sent", lbrynet.FormatLBC(limits.Daily), lbrynet.FormatLBC(max64(limits.Daily-spent, 0)),
+			))
+		}
+	}
+
+	spending := &models.Spending{UserID: uid, Method: method, Amount: amount}
+	if err := spending.Insert(tx, boil.Infer()); err != nil {
+		return nil, NewInternalError(err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, NewInternalError(err)
+	}
+	return spending, nil
+}
+
+// cancel removes reserved spending of a call which didn't send anything.
+func (s *SpendingLimits) cancel(spending *models.Spending) {
+	if _, err := spending.DeleteG(); err != nil {
+		s.logger.LogF(monitor.F{"user_id": spending.UserID, "method": spending.Method, "amount": spending.Amount}).
+			Errorf("error cancelling spending: %v", err)
+	}
+}
+
+// spendingLockKey maps user ID to a Postgres advisory lock key.
+func spendingLockKey(uid int) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(fmt.Sprintf("spending:%v", uid)))
+	return int64(h.Sum64())
+}
+
+// SetUserSpendingLimit overrides default limits for the user, null values keep the default limit.
+func SetUserSpendingLimit(uid int, daily, perTransaction null.Int64) (*models.SpendingLimit, error) {
+	l := &models.SpendingLimit{UserID: uid, DailyLimit: daily, TransactionLimit: perTransaction}
+	err := l.UpsertG(
+		true, []string{models.SpendingLimitColumns.UserID},
+		boil.Whitelist(
+			models.SpendingLimitColumns.DailyLimit, models.SpendingLimitColumns.TransactionLimit, models.SpendingLimitColumns.UpdatedAt,
+		),
+		boil.Infer(),
+	)
+	return l, err
+}
+
+// RemoveUserSpendingLimit restores default limits for the user.
+func RemoveUserSpendingLimit(uid int) error {
+	_, err := models.SpendingLimits(models.SpendingLimitWhere.UserID.EQ(uid)).DeleteAll(boil.GetDB())
+	return err
+}
+
+// spendingUserID returns ID of the user whose spending limits apply to SDK wallet wid,
+// limits are shared by all wallets of the user.
+// Guests have no limits of their own so their wallets cannot send LBC out at all.
+func spendingUserID(wid string) (int, CallError) {
+	if _, err := lbrynet.ParseGuestWalletID(wid); err == nil {
+		return 0, limitExceededError(errors.New("guest wallets cannot send LBC"))
+	}
+	uid, err := users.WalletOwnerID(wid)
+	if err != nil {
+		return 0, NewInternalError(err)
+	}
+	return uid, nil
+}
+
+func limitExceededError(e error) ClassifiedError {
+	return ClassifiedError{GenericError{e, ErrSpendingLimitExceeded}, ErrorKindSpendingLimitExceeded}
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
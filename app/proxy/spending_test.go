@@ -0,0 +1,197 @@
+package proxy
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+)
+
+func TestSpendingAmount(t *testing.T) {
+	cases := []struct {
+		query  string
+		deweys int64
+	}{
+		{`{"method": "wallet_send", "params": {"amount": "1.5", "addresses": "bX"}}`, 150000000},
+		{`{"method": "wallet_send", "params": {"amount": "1.5", "addresses": ["bX", "bY"]}}`, 300000000},
+		{`{"method": "account_send", "params": {"amount": 2, "addresses": "bX"}}`, 200000000},
+		{`{"method": "support_create", "params": {"amount": "1.0", "claim_id": "abc", "tip": true}}`, 100000000},
+		{`{"method": "support_create", "params": {"amount": "1.0", "claim_id": "abc"}}`, 0},
+		{`{"method": "stream_create", "params": {"bid": "1.0"}}`, 0},
+	}
+	for _, c := range cases {
+		q, err := NewQuery([]byte(c.query))
+		require.NoError(t, err)
+		deweys, err := spendingAmount(q)
+		require.NoError(t, err, c.query)
+		assert.Equal(t, c.deweys, deweys, c.query)
+	}
+
+	q, _ := NewQuery([]byte(`{"method": "wallet_send", "params": {"addresses": "bX"}}`))
+	_, err := spendingAmount(q)
+	assert.Error(t, err)
+}
+
+func TestCallerSpendingLimits(t *testing.T) {
+	ts, requests := launchCountingSDK()
+	defer ts.Close()
+
+	uid := rand.Int31()
+	wid := lbrynet.MakeWalletID(int(uid))
+	u := &models.User{ID: int(uid), WalletID: wid}
+	require.NoError(t, u.InsertG(boil.Infer()))
+	defer u.DeleteG()
+
+	svc := NewService(ts.URL)
+	svc.SetSpendingLimits(NewSpendingLimits(SpendingLimitsOpts{Daily: 2 * lbrynet.DeweysPerLBC, PerTransaction: lbrynet.DeweysPerLBC}))
+	call := func(method string, params map[string]interface{}) CallError {
+		c := svc.NewCaller()
+		c.SetWalletID(wid)
+		_, err := c.call(newRawRequest(t, method, params))
+		return err
+	}
+
+	err := call("wallet_send", map[string]interface{}{"amount": "1.5", "addresses": "bX"})
+	require.NotNil(t, err)
+	assert.Equal(t, ErrSpendingLimitExceeded, err.Code())
+	assert.Contains(t, err.Error(), "transaction limit of 1 LBC exceeded")
+
+	require.Nil(t, call("wallet_send", map[string]interface{}{"amount": "1.0", "addresses": "bX"}))
+	require.Nil(t, call("support_create", map[string]interface{}{"amount": "0.8", "claim_id": "abc", "tip": true}))
+	// Supports stay in the wallet so they're not limited
+	require.Nil(t, call("support_create", map[string]interface{}{"amount": "5.0", "claim_id": "abc"}))
+
+	err = call("wallet_send", map[string]interface{}{"amount": "0.5", "addresses": "bX"})
+	require.NotNil(t, err)
+	assert.Equal(t, ErrSpendingLimitExceeded, err.Code())
+	assert.Contains(t, err.Error(), "daily limit of 2 LBC exceeded, 0.2 LBC can be sent")
+	assert.Len(t, requests(), 3)
+
+	_, serr := SetUserSpendingLimit(int(uid), null.Int64From(10*lbrynet.DeweysPerLBC), null.Int64{})
+	require.NoError(t, serr)
+	require.Nil(t, call("wallet_send", map[string]interface{}{"amount": "0.5", "addresses": "bX"}))
+	// Default per-transaction limit still applies
+	err = call("wallet_send", map[string]interface{}{"amount": "1.5", "addresses": "bX"})
+	require.NotNil(t, err)
+
+	require.NoError(t, RemoveUserSpendingLimit(int(uid)))
+	err = call("wallet_send", map[string]interface{}{"amount": "0.5", "addresses": "bX"})
+	require.NotNil(t, err)
+	assert.Equal(t, ErrSpendingLimitExceeded, err.Code())
+}
+
+func TestCallerSpendingLimitsGuestWallet(t *testing.T) {
+	ts, requests := launchCountingSDK()
+	defer ts.Close()
+
+	svc := NewService(ts.URL)
+	svc.SetSpendingLimits(NewSpendingLimits(SpendingLimitsOpts{}))
+	c := svc.NewCaller()
+	c.SetWalletID(lbrynet.MakeGuestWalletID(rand.Int()))
+	_, err := c.call(newRawRequest(t, "wallet_send", map[string]interface{}{"amount": "0.5", "addresses": "bX"}))
+	require.NotNil(t, err)
+	assert.Equal(t, ErrSpendingLimitExceeded, err.Code())
+	assert.Contains(t, err.Error(), "guest wallets cannot send LBC")
+	assert.Len(t, requests(), 0)
+}
+
+func TestCallerSpendingLimitsOverrideWithoutDefaults(t *testing.T) {
+	ts, requests := launchCountingSDK()
+	defer ts.Close()
+
+	uid := rand.Int31()
+	wid := lbrynet.MakeWalletID(int(uid))
+	u := &models.User{ID: int(uid), WalletID: wid}
+	require.NoError(t, u.InsertG(boil.Infer()))
+	defer u.DeleteG()
+
+	svc := NewService(ts.URL)
+	svc.SetSpendingLimits(NewSpendingLimits(SpendingLimitsOpts{}))
+	_, serr := SetUserSpendingLimit(int(uid), null.Int64From(lbrynet.DeweysPerLBC), null.Int64{})
+	require.NoError(t, serr)
+
+	// Calls are made concurrently, only one of them fits into the limit
+	var wg sync.WaitGroup
+	errs := make(chan CallError, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c := svc.NewCaller()
+			c.SetWalletID(wid)
+			_, err := c.call(newRawRequest(t, "wallet_send", map[string]interface{}{"amount": "0.6", "addresses": "bX"}))
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	rejected := 0
+	for err := range errs {
+		if err != nil {
+			assert.Equal(t, ErrSpendingLimitExceeded, err.Code())
+			rejected++
+		}
+	}
+	assert.Equal(t, 4, rejected)
+	assert.Len(t, requests(), 1)
+}
+
+func TestCallerSpendingLimitsAdditionalWallet(t *testing.T) {
+	ts, requests := launchCountingSDK()
+	defer ts.Close()
+
+	uid := rand.Int31()
+	u := &models.User{ID: int(uid), WalletID: lbrynet.MakeWalletID(int(uid))}
+	require.NoError(t, u.InsertG(boil.Infer()))
+	defer u.DeleteG()
+	wallet := &models.Wallet{UserID: u.ID, WalletID: lbrynet.MakeAdditionalWalletID(u.ID, int(uid)), Name: "Second"}
+	require.NoError(t, wallet.InsertG(boil.Infer()))
+	defer wallet.DeleteG()
+
+	svc := NewService(ts.URL)
+	svc.SetSpendingLimits(NewSpendingLimits(SpendingLimitsOpts{Daily: lbrynet.DeweysPerLBC}))
+	call := func(wid, amount string) CallError {
+		c := svc.NewCaller()
+		c.SetWalletID(wid)
+		_, err := c.call(newRawRequest(t, "wallet_send", map[string]interface{}{"amount": amount, "addresses": "bX"}))
+		return err
+	}
+
+	require.Nil(t, call(u.WalletID, "0.8"))
+	// Limits are shared by all wallets of the user
+	err := call(wallet.WalletID, "0.5")
+	require.NotNil(t, err)
+	assert.Equal(t, ErrSpendingLimitExceeded, err.Code())
+	require.Nil(t, call(wallet.WalletID, "0.2"))
+	assert.Len(t, requests(), 2)
+}
+
+func TestCallerSpendingLimitsFailedCall(t *testing.T) {
+	ts := launchDummyAPIServer([]byte(`{"jsonrpc": "2.0", "error": {"code": -32500, "message": "Not enough funds to cover this transaction."}, "id": 0}`))
+	defer ts.Close()
+
+	uid := rand.Int31()
+	wid := lbrynet.MakeWalletID(int(uid))
+	u := &models.User{ID: int(uid), WalletID: wid}
+	require.NoError(t, u.InsertG(boil.Infer()))
+	defer u.DeleteG()
+
+	svc := NewService(ts.URL)
+	svc.SetSpendingLimits(NewSpendingLimits(SpendingLimitsOpts{Daily: lbrynet.DeweysPerLBC}))
+	c := svc.NewCaller()
+	c.SetWalletID(wid)
+	_, err := c.call(newRawRequest(t, "wallet_send", map[string]interface{}{"amount": "1.0", "addresses": "bX"}))
+	require.Nil(t, err)
+
+	// Nothing was sent so nothing is counted
+	spent, serr := spentToday(boil.GetDB(), int(uid))
+	require.NoError(t, serr)
+	assert.EqualValues(t, 0, spent)
+}
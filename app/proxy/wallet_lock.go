@@ -0,0 +1,187 @@
+package proxy
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/monitor"
+)
+
+// ErrWalletBusy means the call couldn't be made because other calls for the same wallet are taking too long.
+const ErrWalletBusy int = -32095
+
+// ErrorKindWalletBusy is supplied in `data.kind` of ErrWalletBusy responses.
+const ErrorKindWalletBusy = "wallet_busy"
+
+// NewWalletBusyError is for mutating calls which couldn't acquire wallet lock.
+func NewWalletBusyError(e error) ClassifiedError {
+	return ClassifiedError{GenericError{e, ErrWalletBusy}, ErrorKindWalletBusy}
+}
+
+// WalletLockerOpts contains wallet locking settings.
+type WalletLockerOpts struct {
+	// QueueSize is how many calls can wait for a lock on the same wallet, calls beyond that are rejected.
+	QueueSize int
+	// Timeout is how long a call can wait for a lock before being rejected.
+	Timeout time.Duration
+	// DB is used for taking Postgres advisory locks so calls are serialized across lbrytv replicas.
+	// Locks are only held locally if DB is nil.
+	DB *sql.DB
+	// MaxHeld is how many advisory locks can be held at once. Every lock occupies a DB connection
+	// for the whole SDK call, so this keeps slow calls from draining the connection pool. 0 means no limit.
+	MaxHeld int
+}
+
+type walletQueue struct {
+	sem     chan struct{}
+	pending int
+}
+
+// WalletLocker serializes wallet-mutating SDK calls per wallet so concurrent transactions
+// don't compete for the same outputs inside the SDK.
+type WalletLocker struct {
+	opts   WalletLockerOpts
+	mu     sync.Mutex
+	queues map[string]*walletQueue
+	// held has a slot for every advisory lock being held, it's nil if their number is not limited
+	held   chan struct{}
+	logger monitor.ModuleLogger
+}
+
+// NewWalletLocker creates a wallet locker, which should be passed to Service.SetWalletLocker.
+func NewWalletLocker(opts WalletLockerOpts) *WalletLocker {
+	l := &WalletLocker{
+		opts:   opts,
+		queues: map[string]*walletQueue{},
+		logger: monitor.NewModuleLogger("wallet_locker"),
+	}
+	if opts.MaxHeld > 0 {
+		l.held = make(chan struct{}, opts.MaxHeld)
+	}
+	return l
+}
+
+// Lock waits until the wallet is free and locks it. The returned function releases the lock
+// and must be called once the call is done.
+func (l *WalletLocker) Lock(wid string) (func(), CallError) {
+	deadline := time.Now().Add(l.opts.Timeout)
+
+	l.mu.Lock()
+	q, ok := l.queues[wid]
+	if !ok {
+		q = &walletQueue{sem: make(chan struct{}, 1)}
+		l.queues[wid] = q
+	}
+	// One of pending calls is holding the lock, the rest are waiting for it
+	if q.pending > l.opts.QueueSize {
+		l.mu.Unlock()
+		return nil, NewWalletBusyError(errors.New("too many calls are waiting for the wallet"))
+	}
+	q.pending++
+	l.mu.Unlock()
+
+	timer := time.NewTimer(l.opts.Timeout)
+	defer timer.Stop()
+	select {
+	case q.sem <- struct{}{}:
+	case <-timer.C:
+		l.leave(wid, q)
+		return nil, NewWalletBusyError(errors.New("timed out waiting for the wallet"))
+	}
+
+	if l.opts.DB == nil {
+		return func() { l.release(wid, q) }, nil
+	}
+
+	if l.held != nil {
+		select {
+		case l.held <- struct{}{}:
+		case <-timer.C:
+			l.release(wid, q)
+			return nil, NewWalletBusyError(errors.New("timed out waiting for the wallet"))
+		}
+	}
+
+	conn, err := l.lockDB(wid, deadline)
+	if err != nil {
+		l.releaseHeld()
+		l.release(wid, q)
+		// Cancelled query error doesn't say why it was cancelled
+		if !time.Now().Before(deadline) {
+			return nil, NewWalletBusyError(errors.New("timed out waiting for the wallet"))
+		}
+		return nil, NewInternalError(fmt.Errorf("cannot lock wallet: %v", err))
+	}
+	return func() {
+		l.unlockDB(wid, conn)
+		l.releaseHeld()
+		l.release(wid, q)
+	}, nil
+}
+
+// lockDB takes a session-level advisory lock on a connection taken from the pool for the duration of the lock.
+// Unlike a transaction-level lock it doesn't keep a transaction open, the lock is released by unlockDB.
+func (l *WalletLocker) lockDB(wid string, deadline time.Time) (*sql.Conn, error) {
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	conn, err := l.opts.DB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", walletLockKey(wid)); err != nil {
+		// Lock could have been granted right before the query got cancelled
+		l.unlockDB(wid, conn)
+		return nil, err
+	}
+	return conn, nil
+}
+
+// unlockDB releases the advisory lock and returns the connection to the pool. If the lock might still be held
+// the connection is closed instead, otherwise the wallet would stay locked for as long as the connection is pooled.
+func (l *WalletLocker) unlockDB(wid string, conn *sql.Conn) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// Unlocking a lock which isn't held only makes Postgres issue a warning and return false
+	var unlocked bool
+	err := conn.QueryRowContext(ctx, "SELECT pg_advisory_unlock($1)", walletLockKey(wid)).Scan(&unlocked)
+	if err != nil {
+		l.logger.LogF(monitor.F{"wallet_id": wid}).Errorf("error releasing wallet lock: %v", err)
+	}
+	if err != nil || !unlocked {
+		conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+	}
+	conn.Close()
+}
+
+func (l *WalletLocker) releaseHeld() {
+	if l.held != nil {
+		<-l.held
+	}
+}
+
+func (l *WalletLocker) release(wid string, q *walletQueue) {
+	<-q.sem
+	l.leave(wid, q)
+}
+
+func (l *WalletLocker) leave(wid string, q *walletQueue) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	q.pending--
+	if q.pending == 0 {
+		delete(l.queues, wid)
+	}
+}
+
+// walletLockKey maps wallet ID to a Postgres advisory lock key.
+func walletLockKey(wid string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("wallet:" + wid))
+	return int64(h.Sum64())
+}
@@ -0,0 +1,207 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ybbus/jsonrpc"
+)
+
+// concurrencyTracker records maximum number of simultaneous calls by method.
+type concurrencyTracker struct {
+	mu      sync.Mutex
+	current map[string]int
+	max     map[string]int
+}
+
+func newConcurrencyTracker() *concurrencyTracker {
+	return &concurrencyTracker{current: map[string]int{}, max: map[string]int{}}
+}
+
+func (t *concurrencyTracker) enter(method string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.current[method]++
+	if t.current[method] > t.max[method] {
+		t.max[method] = t.current[method]
+	}
+}
+
+func (t *concurrencyTracker) leave(method string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.current[method]--
+}
+
+func (t *concurrencyTracker) maxFor(method string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.max[method]
+}
+
+func TestWalletLockerSerializes(t *testing.T) {
+	l := NewWalletLocker(WalletLockerOpts{QueueSize: 10, Timeout: time.Second})
+	tracker := newConcurrencyTracker()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		for _, wid := range []string{"lbrytv-id.1.wallet", "lbrytv-id.2.wallet"} {
+			wg.Add(1)
+			go func(wid string) {
+				defer wg.Done()
+				unlock, err := l.Lock(wid)
+				require.Nil(t, err)
+				tracker.enter(wid)
+				time.Sleep(10 * time.Millisecond)
+				tracker.leave(wid)
+				unlock()
+			}(wid)
+		}
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, tracker.maxFor("lbrytv-id.1.wallet"))
+	assert.Equal(t, 1, tracker.maxFor("lbrytv-id.2.wallet"))
+	assert.Empty(t, l.queues)
+}
+
+func TestWalletLockerQueueFull(t *testing.T) {
+	l := NewWalletLocker(WalletLockerOpts{QueueSize: 1, Timeout: time.Second})
+	wid := "lbrytv-id.1.wallet"
+
+	unlock, err := l.Lock(wid)
+	require.Nil(t, err)
+
+	waiting := make(chan CallError)
+	go func() {
+		unlock, err := l.Lock(wid)
+		if err == nil {
+			unlock()
+		}
+		waiting <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	_, err = l.Lock(wid)
+	require.NotNil(t, err)
+	assert.Equal(t, ErrWalletBusy, err.Code())
+	assert.Equal(t, ErrorKindWalletBusy, err.AsRPCResponse().Error.Data.(map[string]interface{})["kind"])
+
+	unlock()
+	assert.Nil(t, <-waiting)
+}
+
+func TestWalletLockerTimeout(t *testing.T) {
+	l := NewWalletLocker(WalletLockerOpts{QueueSize: 10, Timeout: 20 * time.Millisecond})
+	wid := "lbrytv-id.1.wallet"
+
+	unlock, err := l.Lock(wid)
+	require.Nil(t, err)
+	defer unlock()
+
+	_, err = l.Lock(wid)
+	require.NotNil(t, err)
+	assert.Equal(t, ErrWalletBusy, err.Code())
+	assert.EqualError(t, err, "timed out waiting for the wallet")
+}
+
+func TestWalletLockerDB(t *testing.T) {
+	// Lockers with a shared DB act like lbrytv replicas
+	opts := WalletLockerOpts{QueueSize: 10, Timeout: 100 * time.Millisecond, DB: storage.Conn.DB.DB}
+	l1, l2 := NewWalletLocker(opts), NewWalletLocker(opts)
+	wid := "lbrytv-id.1.wallet"
+
+	unlock, err := l1.Lock(wid)
+	require.Nil(t, err)
+
+	_, err = l2.Lock(wid)
+	require.NotNil(t, err)
+	assert.Equal(t, ErrWalletBusy, err.Code())
+
+	unlock()
+	unlock, err = l2.Lock(wid)
+	require.Nil(t, err)
+	unlock()
+}
+
+func TestWalletLockerDBMaxHeld(t *testing.T) {
+	l := NewWalletLocker(WalletLockerOpts{QueueSize: 10, Timeout: 100 * time.Millisecond, DB: storage.Conn.DB.DB, MaxHeld: 1})
+
+	unlock, err := l.Lock("lbrytv-id.1.wallet")
+	require.Nil(t, err)
+
+	// Another wallet is free but there's no room for one more lock
+	_, err = l.Lock("lbrytv-id.2.wallet")
+	require.NotNil(t, err)
+	assert.Equal(t, ErrWalletBusy, err.Code())
+
+	unlock()
+	unlock, err = l.Lock("lbrytv-id.2.wallet")
+	require.Nil(t, err)
+	unlock()
+}
+
+func TestWalletLockerDBDiscardsUnreleasedConn(t *testing.T) {
+	db := storage.Conn.DB.DB
+	l := NewWalletLocker(WalletLockerOpts{QueueSize: 10, Timeout: 100 * time.Millisecond, DB: db})
+	wid := "lbrytv-id.1.wallet"
+
+	conn, err := l.lockDB(wid, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	// Lock is gone so unlocking returns false and the connection can't be trusted to be clean
+	_, err = conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock_all()")
+	require.NoError(t, err)
+	open := db.Stats().OpenConnections
+	l.unlockDB(wid, conn)
+	assert.Equal(t, open-1, db.Stats().OpenConnections)
+
+	conn, err = l.lockDB(wid, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	open = db.Stats().OpenConnections
+	l.unlockDB(wid, conn)
+	assert.Equal(t, open, db.Stats().OpenConnections)
+}
+
+func TestCallerSerializesWriteMethods(t *testing.T) {
+	tracker := newConcurrencyTracker()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := &jsonrpc.RPCRequest{}
+		json.NewDecoder(r.Body).Decode(req)
+		tracker.enter(req.Method)
+		time.Sleep(50 * time.Millisecond)
+		tracker.leave(req.Method)
+		json.NewEncoder(w).Encode(jsonrpc.RPCResponse{JSONRPC: "2.0", Result: map[string]interface{}{}})
+	}))
+	defer ts.Close()
+
+	svc := NewService(ts.URL)
+	svc.SetWalletLocker(NewWalletLocker(WalletLockerOpts{QueueSize: 10, Timeout: time.Second}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		for _, method := range []string{"wallet_send", "wallet_balance"} {
+			wg.Add(1)
+			go func(method string) {
+				defer wg.Done()
+				c := svc.NewCaller()
+				c.SetWalletID("lbrytv-id.1.wallet")
+				r, err := c.call(newRawRequest(t, method, nil))
+				require.Nil(t, err)
+				require.Nil(t, r.Error)
+			}(method)
+		}
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, tracker.maxFor("wallet_send"))
+	assert.Equal(t, 3, tracker.maxFor("wallet_balance"))
+}
@@ -0,0 +1,68 @@
+package proxy
+
+import (
+	"sync"
+
+	"github.com/lbryio/lbrytv/app/users"
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+
+	"github.com/ybbus/jsonrpc"
+)
+
+type walletReload struct {
+	done chan struct{}
+	err  error
+}
+
+// walletReloads contains wallet reloads in progress so concurrent calls for the same wallet
+// wait for a single wallet_add instead of issuing their own.
+var walletReloads = struct {
+	sync.Mutex
+	inProgress map[string]*walletReload
+}{inProgress: map[string]*walletReload{}}
+
+// isWalletNotLoaded returns true if the SDK responded that it doesn't have the wallet loaded,
+// which normally happens after the SDK is restarted.
+func isWalletNotLoaded(e *jsonrpc.RPCError) bool {
+	return e != nil && ClassifySDKError(e).Code == ErrSDKWalletNotLoaded
+}
+
+// reloadWallet loads wallet back into the SDK. Concurrent reloads of the same wallet are serialized,
+// all callers get the result of the reload which was started first.
+func reloadWallet(wid string) error {
+	walletReloads.Lock()
+	if r, ok := walletReloads.inProgress[wid]; ok {
+		walletReloads.Unlock()
+		<-r.done
+		return r.err
+	}
+	r := &walletReload{done: make(chan struct{})}
+	walletReloads.inProgress[wid] = r
+	walletReloads.Unlock()
+
+	// Other wallets are likely gone too if the SDK has restarted
+	users.ResetLoadedWallets()
+	r.err = addWallet(wid)
+
+	walletReloads.Lock()
+	delete(walletReloads.inProgress, wid)
+	walletReloads.Unlock()
+	close(r.done)
+	return r.err
+}
+
+func addWallet(wid string) error {
+	id, err := lbrynet.ParseGuestWalletID(wid)
+	if err != nil {
+		if id, err = users.WalletOwnerID(wid); err != nil {
+			return err
+		}
+	}
+	if err := lbrynet.LoadWalletByID(wid, id); err != nil {
+		return err
+	}
+	if lbrynet.Wallets != nil {
+		lbrynet.Wallets.MarkLoaded(wid)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package proxy
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/lbryio/lbrytv/config"
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/ybbus/jsonrpc"
+)
+
+func countWalletAdds(wid string) int {
+	var n int
+	for _, r := range sdk.Requests("wallet_add") {
+		if p, ok := r.Params.(map[string]interface{}); ok && p["wallet_id"] == wid {
+			n++
+		}
+	}
+	return n
+}
+
+func TestCallerReloadsUnloadedWallet(t *testing.T) {
+	uid := rand.Int()
+	wid, err := lbrynet.InitializeWallet(uid)
+	require.NoError(t, err)
+	_, err = lbrynet.WalletRemove(uid)
+	require.NoError(t, err)
+
+	c := NewService(config.GetLbrynet()).NewCaller()
+	c.SetWalletID(wid)
+	r, callErr := c.call(newRawRequest(t, "account_balance", nil))
+	require.Nil(t, callErr)
+	require.Nil(t, r.Error)
+	assert.NotNil(t, r.Result)
+	assert.Equal(t, 1, countWalletAdds(wid))
+
+	// Wallet stays loaded so subsequent calls don't need reloading
+	r, callErr = c.call(newRawRequest(t, "account_balance", nil))
+	require.Nil(t, callErr)
+	require.Nil(t, r.Error)
+	assert.Equal(t, 1, countWalletAdds(wid))
+}
+
+func TestCallerReloadsUnloadedGuestWallet(t *testing.T) {
+	gid := rand.Int()
+	wid, err := lbrynet.InitializeGuestWallet(gid)
+	require.NoError(t, err)
+	require.NoError(t, lbrynet.RemoveGuestWallet(gid))
+
+	c := NewService(config.GetLbrynet()).NewCaller()
+	c.SetWalletID(wid)
+	r, callErr := c.call(newRawRequest(t, "account_balance", nil))
+	require.Nil(t, callErr)
+	require.Nil(t, r.Error)
+	assert.Equal(t, 1, countWalletAdds(wid))
+}
+
+func TestCallerReloadsUnloadedAdditionalWallet(t *testing.T) {
+	uid := rand.Int31()
+	u := &models.User{ID: int(uid), WalletID: lbrynet.MakeWalletID(int(uid))}
+	require.NoError(t, u.InsertG(boil.Infer()))
+	defer u.DeleteG()
+	wallet := &models.Wallet{UserID: u.ID, WalletID: lbrynet.MakeAdditionalWalletID(u.ID, int(uid)), Name: "Second"}
+	require.NoError(t, wallet.InsertG(boil.Infer()))
+	defer wallet.DeleteG()
+	require.NoError(t, lbrynet.InitializeWalletByID(wallet.WalletID, u.ID))
+	require.NoError(t, lbrynet.RemoveWalletByID(wallet.WalletID, u.ID))
+
+	c := NewService(config.GetLbrynet()).NewCaller()
+	c.SetWalletID(wallet.WalletID)
+	r, callErr := c.call(newRawRequest(t, "account_balance", nil))
+	require.Nil(t, callErr)
+	require.Nil(t, r.Error)
+	assert.Equal(t, 1, countWalletAdds(wallet.WalletID))
+}
+
+func TestCallerReloadWalletFailure(t *testing.T) {
+	// Wallet has never been created so it cannot be loaded
+	wid := lbrynet.MakeWalletID(rand.Int())
+
+	c := NewService(config.GetLbrynet()).NewCaller()
+	c.SetWalletID(wid)
+	r, callErr := c.call(newRawRequest(t, "account_balance", nil))
+	require.Nil(t, callErr)
+	require.NotNil(t, r.Error)
+	assert.Equal(t, ErrSDKWalletNotLoaded, r.Error.Code)
+	assert.Equal(t, ErrorKindWalletNotLoaded, r.Error.Data.(map[string]interface{})["kind"])
+	assert.Equal(t, 1, countWalletAdds(wid))
+}
+
+func TestReloadWalletWaitsForReloadInProgress(t *testing.T) {
+	wid := lbrynet.MakeWalletID(rand.Int())
+	inProgress := &walletReload{done: make(chan struct{})}
+	walletReloads.Lock()
+	walletReloads.inProgress[wid] = inProgress
+	walletReloads.Unlock()
+
+	result := make(chan error)
+	go func() { result <- reloadWallet(wid) }()
+
+	select {
+	case <-result:
+		t.Fatal("reloadWallet returned before the reload in progress was finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	inProgress.err = errors.New("reload failed")
+	walletReloads.Lock()
+	delete(walletReloads.inProgress, wid)
+	walletReloads.Unlock()
+	close(inProgress.done)
+
+	assert.EqualError(t, <-result, "reload failed")
+	assert.Equal(t, 0, countWalletAdds(wid))
+}
+
+func TestIsWalletNotLoaded(t *testing.T) {
+	assert.True(t, isWalletNotLoaded(&jsonrpc.RPCError{Code: -32500, Message: "Couldn't find wallet: lbrytv-id.1.wallet."}))
+	assert.False(t, isWalletNotLoaded(&jsonrpc.RPCError{Code: -32500, Message: "Not enough funds to cover this transaction."}))
+	assert.False(t, isWalletNotLoaded(nil))
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	"github.com/lbryio/lbrytv/app/proxy"
+	"github.com/lbryio/lbrytv/internal/maintenance"
 
 	"github.com/ybbus/jsonrpc"
 )
@@ -38,6 +39,8 @@ func (e Error) Message() string {
 
 var ErrUnauthorized = Error{code: proxy.ErrProxy, message: "authentication required"}
 
+var ErrForbiddenScope = Error{code: proxy.ErrMethodUnavailable, message: "method is outside of credentials scopes"}
+
 func NewAuthError(err error) Error {
 	return Error{code: proxy.ErrAuthFailed, message: err.Error()}
 }
@@ -45,3 +48,9 @@ func NewAuthError(err error) Error {
 func NewInternalError(err error) Error {
 	return Error{code: proxy.ErrInternal, message: err.Error()}
 }
+
+// maintenanceResponse is returned for uploads made while maintenance mode is on.
+func maintenanceResponse() []byte {
+	b, _ := proxy.MarshalResponse(proxy.NewMaintenanceError(maintenance.Get()).AsRPCResponse())
+	return b
+}
@@ -12,8 +12,11 @@ import (
 	"path"
 	"testing"
 
+	"github.com/lbryio/lbrytv/app/proxy"
 	"github.com/lbryio/lbrytv/app/users"
 	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/internal/maintenance"
+	"github.com/lbryio/lbrytv/internal/monitor"
 	"github.com/ybbus/jsonrpc"
 
 	"github.com/stretchr/testify/assert"
@@ -24,13 +27,15 @@ type DummyPublisher struct {
 	called    bool
 	filePath  string
 	accountID string
+	requestID string
 	rawQuery  []byte
 }
 
-func (p *DummyPublisher) Publish(filePath, accountID string, rawQuery []byte) []byte {
+func (p *DummyPublisher) Publish(filePath, accountID, requestID string, rawQuery []byte) []byte {
 	p.called = true
 	p.filePath = filePath
 	p.accountID = accountID
+	p.requestID = requestID
 	p.rawQuery = rawQuery
 	return []byte(lbrynet.ExampleStreamCreateResponse)
 }
@@ -45,7 +50,8 @@ func TestUploadHandler(t *testing.T) {
 	pubHandler, err := NewUploadHandler(UploadOpts{Path: os.TempDir(), Publisher: publisher})
 	assert.Nil(t, err)
 
-	http.HandlerFunc(authenticator.Wrap(pubHandler.Handle)).ServeHTTP(rr, req)
+	req.Header.Set(monitor.RequestIDHeader, "req-1")
+	monitor.RequestIDMiddleware(authenticator.Wrap(pubHandler.Handle)).ServeHTTP(rr, req)
 	response := rr.Result()
 	respBody, _ := ioutil.ReadAll(response.Body)
 
@@ -53,6 +59,7 @@ func TestUploadHandler(t *testing.T) {
 	assert.Equal(t, lbrynet.ExampleStreamCreateResponse, string(respBody))
 
 	require.True(t, publisher.called)
+	assert.Equal(t, "req-1", publisher.requestID)
 	expectedPath := path.Join(os.TempDir(), "UPldrAcc", ".*_lbry_auto_test_file")
 	assert.Regexp(t, expectedPath, publisher.filePath)
 	assert.Equal(t, "UPldrAcc", publisher.accountID)
@@ -130,3 +137,47 @@ func TestNewUploadHandler(t *testing.T) {
 	assert.Error(t, err, "need either a ProxyService or a Publisher instance")
 	assert.Nil(t, h)
 }
+
+func TestUploadHandlerMaintenance(t *testing.T) {
+	var rpcResponse jsonrpc.RPCResponse
+	maintenance.Set(maintenance.Status{Enabled: true, Message: "upgrading"})
+	defer maintenance.Set(maintenance.Status{})
+
+	req := CreatePublishRequest(t, []byte("test file"))
+	req.Header.Set(users.TokenHeader, "uPldrToken")
+
+	rr := httptest.NewRecorder()
+	authenticator := users.NewAuthenticator(&users.TestUserRetriever{WalletID: "UPldrAcc", Token: "uPldrToken"})
+	publisher := &DummyPublisher{}
+	pubHandler, err := NewUploadHandler(UploadOpts{Path: os.TempDir(), Publisher: publisher})
+	require.Nil(t, err)
+
+	http.HandlerFunc(authenticator.Wrap(pubHandler.Handle)).ServeHTTP(rr, req)
+
+	err = json.Unmarshal(rr.Body.Bytes(), &rpcResponse)
+	require.Nil(t, err)
+	assert.Equal(t, proxy.ErrMaintenance, rpcResponse.Error.Code)
+	assert.Equal(t, "upgrading", rpcResponse.Error.Message)
+	require.False(t, publisher.called)
+}
+
+func TestUploadHandlerScopes(t *testing.T) {
+	var rpcResponse jsonrpc.RPCResponse
+	publisher := &DummyPublisher{}
+	pubHandler, err := NewUploadHandler(UploadOpts{Path: os.TempDir(), Publisher: publisher})
+	require.Nil(t, err)
+
+	req := CreatePublishRequest(t, []byte("test file"))
+	rr := httptest.NewRecorder()
+	pubHandler.Handle(rr, &users.AuthenticatedRequest{Request: req, WalletID: "UPldrAcc", Scopes: users.Scopes{"claim_list"}})
+
+	err = json.Unmarshal(rr.Body.Bytes(), &rpcResponse)
+	require.Nil(t, err)
+	assert.Equal(t, proxy.ErrMethodUnavailable, rpcResponse.Error.Code)
+	require.False(t, publisher.called)
+
+	req = CreatePublishRequest(t, []byte("test file"))
+	rr = httptest.NewRecorder()
+	pubHandler.Handle(rr, &users.AuthenticatedRequest{Request: req, WalletID: "UPldrAcc", Scopes: users.Scopes{"stream_create"}})
+	require.True(t, publisher.called)
+}
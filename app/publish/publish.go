@@ -12,6 +12,7 @@ import (
 	"github.com/lbryio/lbrytv/app/proxy"
 	"github.com/lbryio/lbrytv/app/users"
 	"github.com/lbryio/lbrytv/config"
+	"github.com/lbryio/lbrytv/internal/maintenance"
 	"github.com/lbryio/lbrytv/internal/monitor"
 
 	"github.com/gorilla/mux"
@@ -28,9 +29,9 @@ const fileNameParam = "file_path"
 var logger = monitor.NewModuleLogger("publish")
 
 // Publisher is responsible for sending data to lbrynet
-// and should take file path, account ID and client query as a slice of bytes.
+// and should take file path, wallet ID, request ID and client query as a slice of bytes.
 type Publisher interface {
-	Publish(string, string, []byte) []byte
+	Publish(string, string, string, []byte) []byte
 }
 
 // LbrynetPublisher is an implementation of SDK publisher.
@@ -75,11 +76,12 @@ func NewUploadHandler(opts UploadOpts) (*UploadHandler, error) {
 	}, nil
 }
 
-// Publish takes a file path, account ID and client JSON-RPC query,
+// Publish takes a file path, wallet ID, client request ID and client JSON-RPC query,
 // patches the query and sends it to the SDK for processing.
 // Resulting response is then returned back as a slice of bytes.
-func (p *LbrynetPublisher) Publish(filePath, walletID string, rawQuery []byte) []byte {
+func (p *LbrynetPublisher) Publish(filePath, walletID, requestID string, rawQuery []byte) []byte {
 	c := p.Service.NewCaller()
+	c.SetRequestID(requestID)
 	c.SetWalletID(walletID)
 	c.SetPreprocessor(func(q *proxy.Query) {
 		params := q.ParamsAsMap()
@@ -95,6 +97,10 @@ func (p *LbrynetPublisher) Publish(filePath, walletID string, rawQuery []byte) [
 // in a mux.Router.
 func (h UploadHandler) Handle(w http.ResponseWriter, r *users.AuthenticatedRequest) {
 	w.WriteHeader(http.StatusOK)
+	if maintenance.Active() {
+		w.Write(maintenanceResponse())
+		return
+	}
 	if !r.IsAuthenticated() {
 		var authErr Error
 		if r.AuthFailed() {
@@ -106,6 +112,14 @@ func (h UploadHandler) Handle(w http.ResponseWriter, r *users.AuthenticatedReque
 		return
 	}
 
+	if r.Scopes != nil {
+		q, err := proxy.NewQuery([]byte(r.FormValue(JSONRPCFieldName)))
+		if err != nil || !r.Scopes.Allows(q.Method()) {
+			w.Write(ErrForbiddenScope.AsBytes())
+			return
+		}
+	}
+
 	f, err := h.saveFile(r)
 	if err != nil {
 		logger.Log().Error(err)
@@ -114,7 +128,7 @@ func (h UploadHandler) Handle(w http.ResponseWriter, r *users.AuthenticatedReque
 		return
 	}
 
-	response := h.Publisher.Publish(f.Name(), r.WalletID, []byte(r.FormValue(JSONRPCFieldName)))
+	response := h.Publisher.Publish(f.Name(), r.WalletID, monitor.RequestID(r.Context()), []byte(r.FormValue(JSONRPCFieldName)))
 
 	if err := os.Remove(f.Name()); err != nil {
 		monitor.CaptureException(err, map[string]string{"file_path": f.Name()})
@@ -7,17 +7,20 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
-	"os/exec"
 	"path"
 	"testing"
 
 	"github.com/lbryio/lbrytv/app/proxy"
 	"github.com/lbryio/lbrytv/app/users"
 	"github.com/lbryio/lbrytv/config"
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/internal/lbrynettest"
+	"github.com/lbryio/lbrytv/internal/monitor"
 	"github.com/lbryio/lbrytv/internal/storage"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/ybbus/jsonrpc"
 )
 
 // func init() {
@@ -30,13 +33,6 @@ func prettyPrint(i interface{}) {
 	fmt.Println(string(s))
 }
 
-func copyToDocker(t *testing.T, fileName string) {
-	cmd := fmt.Sprintf(`docker cp %v lbrytv_lbrynet_1:/storage`, fileName)
-	if _, err := exec.Command("bash", "-c", cmd).Output(); err != nil {
-		t.Skipf("skipping TestLbrynetPublisher (cannot copy %v to docker container: %v)", fileName, err)
-	}
-}
-
 func launchDummyAPIServer() *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
@@ -87,6 +83,9 @@ func TestLbrynetPublisher(t *testing.T) {
 	config.Override("InternalAPIHost", ts.URL)
 	defer config.RestoreOverridden()
 
+	sdk, sdkCleanup := lbrynettest.UseTestServer(&lbrynet.Client)
+	defer sdkCleanup()
+
 	p := &LbrynetPublisher{proxy.NewService(config.GetLbrynet())}
 
 	walletSvc := users.NewWalletService()
@@ -102,8 +101,6 @@ func TestLbrynetPublisher(t *testing.T) {
 	require.Nil(t, err)
 	defer os.Remove(f.Name())
 
-	copyToDocker(t, f.Name())
-
 	query := []byte(`{
 		"jsonrpc": "2.0",
 		"method": "stream_create",
@@ -124,8 +121,17 @@ func TestLbrynetPublisher(t *testing.T) {
 		"id": 1567580184168
 	}`)
 
-	rawResp := p.Publish(path.Join("/storage", path.Base(f.Name())), u.WalletID, query)
+	rawResp := p.Publish(path.Join("/storage", path.Base(f.Name())), u.WalletID, "req-1", query)
+
+	var rpcResponse jsonrpc.RPCResponse
+	require.Nil(t, json.Unmarshal(rawResp, &rpcResponse))
+	require.Nil(t, rpcResponse.Error)
+	assert.Equal(t, "test", rpcResponse.Result.(map[string]interface{})["outputs"].([]interface{})[0].(map[string]interface{})["name"])
 
-	// This is all we can check for now without running on testnet or crediting some funds to the test account
-	assert.Regexp(t, "Not enough funds to cover this transaction", string(rawResp))
+	requests := sdk.Requests("stream_create")
+	require.Len(t, requests, 1)
+	sentParams := requests[0].Params.(map[string]interface{})
+	assert.Equal(t, path.Join("/storage", path.Base(f.Name())), sentParams["file_path"])
+	assert.Equal(t, u.WalletID, sentParams["wallet_id"])
+	assert.Equal(t, "req-1", sdk.RequestHeaders("stream_create")[0].Get(monitor.RequestIDHeader))
 }
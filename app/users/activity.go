@@ -0,0 +1,142 @@
+package users
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/metrics"
+	"github.com/lbryio/lbrytv/internal/monitor"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+)
+
+// sessionGap is the inactivity period after which user is considered to have logged in again.
+const sessionGap = time.Hour
+
+// Activity records when and from where users were last seen, it is nil when recording is disabled.
+var Activity *ActivityRecorder
+
+// ActivityUpdatesDroppedTotal counts user activity updates dropped because the queue was full.
+var ActivityUpdatesDroppedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Subsystem: "users",
+	Name:      "activity_updates_dropped_total",
+	Help:      "Number of user activity updates dropped because the update queue was full.",
+})
+
+func init() {
+	metrics.Registry.MustRegister(ActivityUpdatesDroppedTotal)
+}
+
+// ActivityRecorderOpts contains user activity recording settings.
+type ActivityRecorderOpts struct {
+	// Interval is how often a single user record is updated at most.
+	Interval time.Duration
+	// QueueSize is how many updates can wait for being written to the database.
+	QueueSize int
+}
+
+type activity struct {
+	uid   int
+	email string
+	ip    string
+}
+
+// ActivityRecorder updates email, last seen time, last IP and login counter of users in the background,
+// so authenticating requests don't wait for the database. Updates for the same user are throttled.
+type ActivityRecorder struct {
+	opts   ActivityRecorderOpts
+	queue  chan activity
+	stop   chan struct{}
+	done   chan struct{}
+	logger monitor.ModuleLogger
+
+	mu   sync.Mutex
+	seen map[int]time.Time
+}
+
+// NewActivityRecorder creates an ActivityRecorder, Start should be called before it records anything.
+func NewActivityRecorder(opts ActivityRecorderOpts) *ActivityRecorder {
+	return &ActivityRecorder{
+		opts:   opts,
+		queue:  make(chan activity, opts.QueueSize),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+		logger: monitor.NewModuleLogger("activity"),
+		seen:   map[int]time.Time{},
+	}
+}
+
+// Record queues an update of user record unless it has been updated within Interval.
+// An empty email doesn't overwrite the stored one.
+func (r *ActivityRecorder) Record(uid int, email, ip string) {
+	r.mu.Lock()
+	if last, ok := r.seen[uid]; ok && time.Since(last) < r.opts.Interval {
+		r.mu.Unlock()
+		return
+	}
+	r.seen[uid] = time.Now()
+	r.mu.Unlock()
+
+	select {
+	case r.queue <- activity{uid: uid, email: email, ip: ip}:
+	default:
+		ActivityUpdatesDroppedTotal.Inc()
+		// Let the next request try again
+		r.mu.Lock()
+		delete(r.seen, uid)
+		r.mu.Unlock()
+	}
+}
+
+func (r *ActivityRecorder) save(a activity) error {
+	_, err := queries.Raw(
+		`UPDATE users SET
+			email = COALESCE(NULLIF($2, ''), email),
+			last_ip = $3,
+			login_count = login_count + CASE WHEN last_seen_at IS NULL OR last_seen_at < now() - $4 * interval '1 second' THEN 1 ELSE 0 END,
+			last_seen_at = now()
+		WHERE id = $1`,
+		a.uid, a.email, a.ip, int(sessionGap.Seconds()),
+	).Exec(boil.GetDB())
+	return err
+}
+
+// prune forgets users who haven't been seen within Interval so the throttling map doesn't grow indefinitely.
+func (r *ActivityRecorder) prune() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for uid, last := range r.seen {
+		if time.Since(last) >= r.opts.Interval {
+			delete(r.seen, uid)
+		}
+	}
+}
+
+// Start launches writing queued updates to the database.
+func (r *ActivityRecorder) Start() {
+	go func() {
+		defer close(r.done)
+		ticker := time.NewTicker(r.opts.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case a := <-r.queue:
+				if err := r.save(a); err != nil {
+					r.logger.LogF(monitor.F{"id": a.uid}).Errorf("cannot save user activity: %v", err)
+				}
+			case <-ticker.C:
+				r.prune()
+			case <-r.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops writing updates, waiting for the one in progress to finish.
+func (r *ActivityRecorder) Stop() {
+	close(r.stop)
+	<-r.done
+}
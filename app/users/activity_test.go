@@ -0,0 +1,56 @@
+package users
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/models"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/boil"
+)
+
+func TestActivityRecorderThrottling(t *testing.T) {
+	r := NewActivityRecorder(ActivityRecorderOpts{Interval: time.Minute, QueueSize: 2})
+
+	r.Record(1, "user@domain.com", "8.8.8.8")
+	r.Record(1, "user@domain.com", "8.8.4.4")
+	r.Record(2, "", "8.8.8.8")
+	assert.Len(t, r.queue, 2)
+
+	// Queue is full
+	dropped := testutil.ToFloat64(ActivityUpdatesDroppedTotal)
+	r.Record(3, "", "8.8.8.8")
+	assert.Len(t, r.queue, 2)
+	assert.Equal(t, dropped+1, testutil.ToFloat64(ActivityUpdatesDroppedTotal))
+	assert.NotContains(t, r.seen, 3)
+
+	a := <-r.queue
+	assert.Equal(t, activity{uid: 1, email: "user@domain.com", ip: "8.8.8.8"}, a)
+
+	r.seen[1] = time.Now().Add(-2 * time.Minute)
+	r.prune()
+	assert.NotContains(t, r.seen, 1)
+	assert.Contains(t, r.seen, 2)
+}
+
+func TestActivityRecorderSave(t *testing.T) {
+	testFuncSetup()
+
+	u := &models.User{ID: dummyUserID, WalletID: lbrynet.MakeWalletID(dummyUserID)}
+	require.NoError(t, u.InsertG(boil.Infer()))
+
+	r := NewActivityRecorder(ActivityRecorderOpts{Interval: time.Minute})
+	require.NoError(t, r.save(activity{uid: u.ID, email: "user@domain.com", ip: "8.8.8.8"}))
+	require.NoError(t, r.save(activity{uid: u.ID, ip: "8.8.4.4"}))
+
+	require.NoError(t, u.ReloadG())
+	assert.Equal(t, "user@domain.com", u.Email)
+	assert.Equal(t, "8.8.4.4", u.LastIP)
+	assert.True(t, u.LastSeenAt.Valid)
+	// Second update is within the same session
+	assert.Equal(t, 1, u.LoginCount)
+}
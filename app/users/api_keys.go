@@ -0,0 +1,271 @@
+package users
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/monitor"
+	"github.com/lbryio/lbrytv/models"
+
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+	"github.com/volatiletech/sqlboiler/types"
+)
+
+// APIKeyHeader is the name of HTTP header containing API key issued for server-to-server integrations.
+const APIKeyHeader = "X-Lbry-Api-Key"
+
+// apiKeyPrefix makes API keys recognizable, e.g. by secret scanners.
+const apiKeyPrefix = "lbrytv_"
+
+// ErrInvalidAPIKey is returned for unknown, revoked and expired API keys.
+var ErrInvalidAPIKey = errors.New("invalid API key")
+
+// Scopes lists SDK methods authenticated client is allowed to call. nil Scopes allow everything.
+type Scopes []string
+
+// Allows checks if method is within scopes.
+func (s Scopes) Allows(method string) bool {
+	if s == nil {
+		return true
+	}
+	for _, m := range s {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopedRetriever is an interface for retrieval of users with credentials limited to a set of methods.
+type ScopedRetriever interface {
+	RetrieveScoped(q Query) (*models.User, Scopes, error)
+}
+
+// APIKeyOpts contains API key settings.
+type APIKeyOpts struct {
+	// UserID is the user whose wallet the key gives access to
+	UserID int
+	// Name describes who the key is issued to
+	Name   string
+	Scopes []string
+	// AllowedIPs are IP addresses or CIDR ranges the key can be used from, empty means no restriction.
+	AllowedIPs []string
+	// Expires is when the key stops working, zero means never.
+	Expires time.Time
+}
+
+func apiKeyHash(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(h[:])
+}
+
+// CreateAPIKey issues a new API key. The key itself is not stored and is only returned here.
+func CreateAPIKey(opts APIKeyOpts) (string, *models.APIKey, error) {
+	if opts.Name == "" {
+		return "", nil, errors.New("API key name is required")
+	}
+	if len(opts.Scopes) == 0 {
+		return "", nil, errors.New("at least one scope is required")
+	}
+	for _, a := range opts.AllowedIPs {
+		if _, err := parseIPRange(a); err != nil {
+			return "", nil, err
+		}
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, err
+	}
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	k := &models.APIKey{
+		UserID:     opts.UserID,
+		Name:       opts.Name,
+		KeyHash:    apiKeyHash(key),
+		Scopes:     types.StringArray(opts.Scopes),
+		AllowedIps: types.StringArray(opts.AllowedIPs),
+	}
+	if k.AllowedIps == nil {
+		k.AllowedIps = types.StringArray{}
+	}
+	if !opts.Expires.IsZero() {
+		k.ExpiresAt = null.TimeFrom(opts.Expires)
+	}
+	if err := k.InsertG(boil.Infer()); err != nil {
+		return "", nil, err
+	}
+	return key, k, nil
+}
+
+// RevokeAPIKey disables API key with a given ID.
+func RevokeAPIKey(id int) error {
+	k, err := models.FindAPIKeyG(id)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("API key %v not found", id)
+	} else if err != nil {
+		return err
+	}
+	k.RevokedAt = null.TimeFrom(time.Now())
+	_, err = k.UpdateG(boil.Whitelist(models.APIKeyColumns.RevokedAt))
+	return err
+}
+
+// ListAPIKeys returns API keys of a user, or all API keys if userID is 0.
+func ListAPIKeys(userID int) (models.APIKeySlice, error) {
+	mods := []qm.QueryMod{qm.OrderBy(models.APIKeyColumns.ID)}
+	if userID != 0 {
+		mods = append(mods, models.APIKeyWhere.UserID.EQ(userID))
+	}
+	return models.APIKeys(mods...).AllG()
+}
+
+// parseIPRange parses a single IP address or CIDR range.
+func parseIPRange(s string) (*net.IPNet, error) {
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %q", s)
+		}
+		bits := 8 * net.IPv6len
+		if ip.To4() != nil {
+			ip, bits = ip.To4(), 8*net.IPv4len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid IP range %q", s)
+	}
+	return n, nil
+}
+
+func isIPAllowed(ip string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, a := range allowed {
+		if n, err := parseIPRange(a); err == nil && n.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// trustedProxies are proxies in front of lbrytv whose X-Forwarded-For entries are trusted by GetPeerIPAddress.
+var trustedProxies []*net.IPNet
+
+// SetTrustedProxies sets IP addresses or CIDR ranges of proxies in front of lbrytv.
+func SetTrustedProxies(ranges []string) error {
+	nets := make([]*net.IPNet, 0, len(ranges))
+	for _, r := range ranges {
+		n, err := parseIPRange(r)
+		if err != nil {
+			return err
+		}
+		nets = append(nets, n)
+	}
+	trustedProxies = nets
+	return nil
+}
+
+func isTrustedProxy(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, n := range trustedProxies {
+		if n.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPeerIPAddress returns the address of the client connection. If the connection comes from a trusted proxy,
+// the address that proxy appended to X-Forwarded-For is taken instead, as long as proxies stay trusted.
+// Unlike GetIPAddressForRequest, the result can't be spoofed by the client, so it's used for API key allowlists.
+func GetPeerIPAddress(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	hops := strings.Split(strings.Join(r.Header["X-Forwarded-For"], ","), ",")
+	for i := len(hops) - 1; i >= 0 && isTrustedProxy(ip); i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			break
+		}
+		ip = hop
+	}
+	return ip
+}
+
+// APIKeyRetriever authenticates server-to-server integrations by API keys supplied in APIKeyHeader.
+type APIKeyRetriever struct {
+	service *WalletService
+}
+
+// NewAPIKeyRetriever returns ScopedRetriever for API keys.
+func NewAPIKeyRetriever() *APIKeyRetriever {
+	return &APIKeyRetriever{&WalletService{UserService{logger: monitor.NewModuleLogger("api_keys")}}}
+}
+
+// RetrieveScoped checks API key supplied in q.Token and returns user it is bound to along with the key scopes.
+func (r *APIKeyRetriever) RetrieveScoped(q Query) (*models.User, Scopes, error) {
+	log := r.service.logger.LogF(monitor.F{"ip": q.PeerIP, monitor.RequestIDF: q.RequestID})
+
+	k, err := models.APIKeys(models.APIKeyWhere.KeyHash.EQ(apiKeyHash(q.Token))).OneG()
+	if err == sql.ErrNoRows {
+		log.Info("unknown API key")
+		return nil, nil, ErrInvalidAPIKey
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	log = log.WithField("api_key_id", k.ID).WithField("id", k.UserID)
+	if k.RevokedAt.Valid {
+		log.Info("revoked API key used")
+		return nil, nil, ErrInvalidAPIKey
+	}
+	if k.ExpiresAt.Valid && time.Now().After(k.ExpiresAt.Time) {
+		log.Info("expired API key used")
+		return nil, nil, ErrInvalidAPIKey
+	}
+	// MetaRemoteIP comes from headers the client can set, so only the connection address is checked
+	if !isIPAllowed(q.PeerIP, k.AllowedIps) {
+		log.Warn("API key used from an IP address not on its allowlist")
+		return nil, nil, fmt.Errorf("API key cannot be used from %v", q.PeerIP)
+	}
+
+	// Avoid rewriting the row on every request
+	_, err = queries.Raw(
+		`UPDATE api_keys SET last_used_at = now() WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute')`,
+		k.ID,
+	).Exec(boil.GetDB())
+	if err != nil {
+		log.Errorf("cannot update API key usage time: %v", err)
+	}
+
+	u, err := r.service.retrieveLocalUser(k.UserID, log)
+	if err != nil {
+		return nil, nil, err
+	}
+	return u, Scopes(k.Scopes), nil
+}
@@ -0,0 +1,131 @@
+package users
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/boil"
+)
+
+func TestScopesAllows(t *testing.T) {
+	assert.True(t, Scopes(nil).Allows("wallet_send"))
+	assert.False(t, Scopes{}.Allows("wallet_send"))
+
+	s := Scopes{"publish", "claim_list"}
+	assert.True(t, s.Allows("publish"))
+	assert.True(t, s.Allows("claim_list"))
+	assert.False(t, s.Allows("wallet_send"))
+}
+
+func TestIsIPAllowed(t *testing.T) {
+	allowed := []string{"8.8.8.8", "1.2.3.0/24", "2001:db8::/32"}
+	assert.True(t, isIPAllowed("8.8.8.8", nil))
+	assert.True(t, isIPAllowed("8.8.8.8", allowed))
+	assert.True(t, isIPAllowed("1.2.3.55", allowed))
+	assert.True(t, isIPAllowed("2001:db8::1", allowed))
+	assert.False(t, isIPAllowed("8.8.4.4", allowed))
+	assert.False(t, isIPAllowed("1.2.4.1", allowed))
+	assert.False(t, isIPAllowed("garbage", allowed))
+
+	_, err := parseIPRange("1.2.3.0/33")
+	assert.EqualError(t, err, `invalid IP range "1.2.3.0/33"`)
+	_, err = parseIPRange("1.2.3")
+	assert.EqualError(t, err, `invalid IP address "1.2.3"`)
+}
+
+func TestGetPeerIPAddress(t *testing.T) {
+	defer SetTrustedProxies(nil)
+	r, _ := http.NewRequest("POST", "/api/v1/proxy", nil)
+	r.RemoteAddr = "8.8.4.4:34567"
+	r.Header.Set("X-Forwarded-For", "8.8.8.8, 1.1.1.1")
+	assert.Equal(t, "8.8.4.4", GetPeerIPAddress(r))
+
+	require.NoError(t, SetTrustedProxies([]string{"10.0.0.0/8"}))
+	assert.Equal(t, "8.8.4.4", GetPeerIPAddress(r))
+
+	// Only hops added by trusted proxies are taken, the rest could have been sent by the client
+	r.RemoteAddr = "10.0.0.2:34567"
+	assert.Equal(t, "1.1.1.1", GetPeerIPAddress(r))
+	r.Header.Set("X-Forwarded-For", "8.8.8.8, 1.1.1.1, 10.0.0.1")
+	assert.Equal(t, "1.1.1.1", GetPeerIPAddress(r))
+	r.Header.Set("X-Forwarded-For", "garbage")
+	assert.Equal(t, "10.0.0.2", GetPeerIPAddress(r))
+
+	r.RemoteAddr = "[2001:db8::1]:34567"
+	assert.Equal(t, "2001:db8::1", GetPeerIPAddress(r))
+
+	assert.EqualError(t, SetTrustedProxies([]string{"abc"}), `invalid IP address "abc"`)
+}
+
+func TestCreateAPIKeyValidation(t *testing.T) {
+	_, _, err := CreateAPIKey(APIKeyOpts{UserID: 1, Scopes: []string{"publish"}})
+	assert.EqualError(t, err, "API key name is required")
+	_, _, err = CreateAPIKey(APIKeyOpts{UserID: 1, Name: "partner"})
+	assert.EqualError(t, err, "at least one scope is required")
+	_, _, err = CreateAPIKey(APIKeyOpts{UserID: 1, Name: "partner", Scopes: []string{"publish"}, AllowedIPs: []string{"abc"}})
+	assert.EqualError(t, err, `invalid IP address "abc"`)
+}
+
+func TestAPIKeyRetriever(t *testing.T) {
+	testFuncSetup()
+
+	u := &models.User{ID: dummyUserID, WalletID: lbrynet.MakeWalletID(dummyUserID)}
+	require.NoError(t, u.InsertG(boil.Infer()))
+
+	key, k, err := CreateAPIKey(APIKeyOpts{
+		UserID: dummyUserID, Name: "partner", Scopes: []string{"publish", "claim_list"}, AllowedIPs: []string{"8.8.8.0/24"},
+	})
+	require.NoError(t, err)
+	assert.Regexp(t, "^lbrytv_", key)
+	assert.NotEqual(t, key, k.KeyHash)
+
+	r := NewAPIKeyRetriever()
+	ru, scopes, err := r.RetrieveScoped(Query{Token: key, PeerIP: "8.8.8.8"})
+	require.NoError(t, err)
+	assert.Equal(t, u.WalletID, ru.WalletID)
+	assert.Equal(t, Scopes{"publish", "claim_list"}, scopes)
+
+	_, _, err = r.RetrieveScoped(Query{Token: key, MetaRemoteIP: "8.8.8.8", PeerIP: "8.8.4.4"})
+	assert.EqualError(t, err, "API key cannot be used from 8.8.4.4")
+	_, _, err = r.RetrieveScoped(Query{Token: key + "x", PeerIP: "8.8.8.8"})
+	assert.Equal(t, ErrInvalidAPIKey, err)
+
+	require.NoError(t, RevokeAPIKey(k.ID))
+	_, _, err = r.RetrieveScoped(Query{Token: key, PeerIP: "8.8.8.8"})
+	assert.Equal(t, ErrInvalidAPIKey, err)
+
+	expiredKey, _, err := CreateAPIKey(APIKeyOpts{
+		UserID: dummyUserID, Name: "partner", Scopes: []string{"publish"}, Expires: time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+	_, _, err = r.RetrieveScoped(Query{Token: expiredKey, PeerIP: "8.8.8.8"})
+	assert.Equal(t, ErrInvalidAPIKey, err)
+
+	// Client can't get around the allowlist by supplying forwarded headers
+	spoofedKey, _, err := CreateAPIKey(APIKeyOpts{
+		UserID: dummyUserID, Name: "partner", Scopes: []string{"publish"}, AllowedIPs: []string{"8.8.8.8"},
+	})
+	require.NoError(t, err)
+	authenticator := NewAuthenticator(&DummyRetriever{})
+	authenticator.SetAPIKeyRetriever(r)
+	req, _ := http.NewRequest("POST", "/api/v1/proxy", nil)
+	req.RemoteAddr = "8.8.4.4:34567"
+	req.Header.Set(APIKeyHeader, spoofedKey)
+	req.Header.Set("X-Forwarded-For", "8.8.8.8")
+	req.Header.Set("X-Real-Ip", "8.8.8.8")
+	_, _, err = authenticator.Authenticate(req)
+	assert.EqualError(t, err, "API key cannot be used from 8.8.4.4")
+	req.RemoteAddr = "8.8.8.8:34567"
+	_, _, err = authenticator.Authenticate(req)
+	require.NoError(t, err)
+
+	keys, err := ListAPIKeys(dummyUserID)
+	require.NoError(t, err)
+	assert.Len(t, keys, 3)
+}
@@ -0,0 +1,223 @@
+package users
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Results of auth cache lookups as reported in AuthCacheLookupsTotal.
+const (
+	AuthCacheHit         = "hit"
+	AuthCacheNegativeHit = "negative_hit"
+	AuthCacheMiss        = "miss"
+)
+
+// AuthCache stores internal-apis authentication results, it is nil when caching is disabled.
+var AuthCache *TokenCache
+
+// AuthCacheLookupsTotal counts auth cache lookups by result.
+var AuthCacheLookupsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "users",
+	Name:      "auth_cache_lookups_total",
+	Help:      "Number of internal-apis authentication cache lookups by result.",
+}, []string{"result"})
+
+func init() {
+	metrics.Registry.MustRegister(AuthCacheLookupsTotal)
+}
+
+// TokenCacheOpts contains auth cache settings.
+type TokenCacheOpts struct {
+	// TTL is for how long successful authentication results are kept.
+	TTL time.Duration
+	// NegativeTTL is for how long tokens rejected by internal-apis are kept, 0 disables negative caching.
+	NegativeTTL time.Duration
+	// MaxSize is the maximum number of cached tokens, least recently used ones are evicted when it is exceeded.
+	// 0 means no limit.
+	MaxSize int
+}
+
+type cachedAuth struct {
+	key     string
+	user    *RemoteUser
+	err     error
+	expires time.Time
+}
+
+// TokenCache keeps internal-apis user lookup results by auth token so every request doesn't have to
+// make a roundtrip to internal-apis. Tokens are stored hashed.
+type TokenCache struct {
+	opts  TokenCacheOpts
+	mu    sync.Mutex
+	lru   *list.List
+	items map[string]*list.Element
+}
+
+// NewTokenCache creates an empty auth cache.
+func NewTokenCache(opts TokenCacheOpts) *TokenCache {
+	return &TokenCache{
+		opts:  opts,
+		lru:   list.New(),
+		items: map[string]*list.Element{},
+	}
+}
+
+func tokenCacheKey(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+// Get returns cached result of authenticating token with internal-apis, err is set for rejected tokens.
+// ok is false if the token is not in cache or its entry has expired.
+func (c *TokenCache) Get(token string) (u *RemoteUser, ok bool, err error) {
+	key := tokenCacheKey(token)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, found := c.items[key]
+	if !found {
+		AuthCacheLookupsTotal.WithLabelValues(AuthCacheMiss).Inc()
+		return nil, false, nil
+	}
+	entry := el.Value.(*cachedAuth)
+	if time.Now().After(entry.expires) {
+		c.remove(el)
+		AuthCacheLookupsTotal.WithLabelValues(AuthCacheMiss).Inc()
+		return nil, false, nil
+	}
+	c.lru.MoveToFront(el)
+	if entry.err != nil {
+		AuthCacheLookupsTotal.WithLabelValues(AuthCacheNegativeHit).Inc()
+		return nil, true, entry.err
+	}
+	AuthCacheLookupsTotal.WithLabelValues(AuthCacheHit).Inc()
+	u = &RemoteUser{}
+	*u = *entry.user
+	return u, true, nil
+}
+
+// Set stores internal-apis user for token.
+func (c *TokenCache) Set(token string, u *RemoteUser) {
+	cu := &RemoteUser{}
+	*cu = *u
+	cu.verified = false
+	c.put(&cachedAuth{key: tokenCacheKey(token), user: cu, expires: time.Now().Add(c.opts.TTL)})
+}
+
+// SetRejected stores the error internal-apis responded with for an invalid token.
+// It's a no-op if negative caching is disabled.
+func (c *TokenCache) SetRejected(token string, err error) {
+	if c.opts.NegativeTTL == 0 {
+		return
+	}
+	c.put(&cachedAuth{key: tokenCacheKey(token), err: err, expires: time.Now().Add(c.opts.NegativeTTL)})
+}
+
+func (c *TokenCache) put(entry *cachedAuth) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[entry.key]; ok {
+		el.Value = entry
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.items[entry.key] = c.lru.PushFront(entry)
+	for c.opts.MaxSize > 0 && c.lru.Len() > c.opts.MaxSize {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *TokenCache) remove(el *list.Element) {
+	c.lru.Remove(el)
+	delete(c.items, el.Value.(*cachedAuth).key)
+}
+
+// Invalidate removes token from cache so it is checked with internal-apis on its next use.
+func (c *TokenCache) Invalidate(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[tokenCacheKey(token)]; ok {
+		c.remove(el)
+	}
+}
+
+// InvalidateUser removes all cached tokens of internal-apis user with a given ID.
+func (c *TokenCache) InvalidateUser(id int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for el := c.lru.Front(); el != nil; {
+		next := el.Next()
+		if entry := el.Value.(*cachedAuth); entry.user != nil && entry.user.ID == id {
+			c.remove(el)
+		}
+		el = next
+	}
+}
+
+// Flush removes everything from cache.
+func (c *TokenCache) Flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Init()
+	c.items = map[string]*list.Element{}
+}
+
+// Count returns the number of cached tokens, including expired ones that haven't been evicted yet.
+func (c *TokenCache) Count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// authenticateRemoteUser verifies token with internal-apis, falling back to recently verified tokens
+// when internal-apis cannot be reached.
+func authenticateRemoteUser(q Query) (*RemoteUser, error) {
+	u, err := getCachedRemoteUser(q)
+	if err == nil || AuthFallback == nil {
+		return u, err
+	}
+	if _, rejected := err.(RemoteAuthError); rejected {
+		return nil, err
+	}
+	return AuthFallback.Authenticate(q, err)
+}
+
+// getCachedRemoteUser is getRemoteUser going through AuthCache when it's enabled.
+// Users returned from cache don't have verified flag set.
+// Users without confirmed email are not cached so they can log in as soon as they confirm it.
+func getCachedRemoteUser(q Query) (*RemoteUser, error) {
+	if AuthCache != nil {
+		if u, ok, err := AuthCache.Get(q.Token); ok {
+			return u, err
+		}
+	}
+	u, err := getRemoteUser(q)
+	if err != nil {
+		if _, rejected := err.(RemoteAuthError); rejected {
+			if AuthCache != nil {
+				AuthCache.SetRejected(q.Token, err)
+			}
+			// Revoked token must not be accepted from storage during the next internal-apis outage
+			if AuthFallback != nil {
+				if fErr := AuthFallback.Forget(q.Token); fErr != nil {
+					logger.Log().Errorf("cannot remove rejected token from storage: %v", fErr)
+				}
+			}
+		}
+		return nil, err
+	}
+	u.verified = true
+	if AuthCache != nil && u.Email != "" {
+		AuthCache.Set(q.Token, u)
+	}
+	return u, nil
+}
@@ -0,0 +1,115 @@
+package users
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lbryio/lbrytv/config"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenCache(t *testing.T) {
+	c := NewTokenCache(TokenCacheOpts{TTL: 50 * time.Millisecond, NegativeTTL: time.Minute, MaxSize: 2})
+	hits := testutil.ToFloat64(AuthCacheLookupsTotal.WithLabelValues(AuthCacheHit))
+	misses := testutil.ToFloat64(AuthCacheLookupsTotal.WithLabelValues(AuthCacheMiss))
+
+	_, ok, _ := c.Get("abc")
+	assert.False(t, ok)
+
+	c.Set("abc", &RemoteUser{ID: 1, Email: "user@domain.com"})
+	u, ok, err := c.Get("abc")
+	require.True(t, ok)
+	require.NoError(t, err)
+	assert.Equal(t, &RemoteUser{ID: 1, Email: "user@domain.com"}, u)
+	for k := range c.items {
+		assert.NotContains(t, k, "abc")
+	}
+
+	c.SetRejected("bad", RemoteAuthError{"could not authenticate user"})
+	_, ok, err = c.Get("bad")
+	require.True(t, ok)
+	assert.EqualError(t, err, "could not authenticate user")
+
+	// Least recently used token gets evicted
+	c.Set("def", &RemoteUser{ID: 2})
+	assert.Equal(t, 2, c.Count())
+	_, ok, _ = c.Get("abc")
+	assert.False(t, ok)
+
+	time.Sleep(60 * time.Millisecond)
+	_, ok, _ = c.Get("def")
+	assert.False(t, ok)
+
+	assert.Equal(t, hits+1, testutil.ToFloat64(AuthCacheLookupsTotal.WithLabelValues(AuthCacheHit)))
+	assert.Equal(t, misses+3, testutil.ToFloat64(AuthCacheLookupsTotal.WithLabelValues(AuthCacheMiss)))
+}
+
+func TestTokenCacheInvalidate(t *testing.T) {
+	c := NewTokenCache(TokenCacheOpts{TTL: time.Minute})
+	c.Set("abc", &RemoteUser{ID: 1})
+	c.Set("abc2", &RemoteUser{ID: 1})
+	c.Set("def", &RemoteUser{ID: 2})
+	c.SetRejected("bad", errors.New("could not authenticate user"))
+	// Negative caching is disabled
+	assert.Equal(t, 3, c.Count())
+
+	c.Invalidate("def")
+	_, ok, _ := c.Get("def")
+	assert.False(t, ok)
+
+	c.InvalidateUser(1)
+	assert.Equal(t, 0, c.Count())
+
+	c.Set("def", &RemoteUser{ID: 2})
+	c.Flush()
+	assert.Equal(t, 0, c.Count())
+}
+
+func TestGetCachedRemoteUser(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		r.ParseForm()
+		switch r.PostForm.Get("auth_token") {
+		case "abc":
+			w.Write([]byte(`{"success": true, "error": null, "data": {"id": 751365, "primary_email": "user@domain.com"}}`))
+		case "unconfirmed":
+			w.Write([]byte(`{"success": true, "error": null, "data": {"id": 751366, "primary_email": null}}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"success": false, "error": "could not authenticate user", "data": null}`))
+		}
+	}))
+	defer ts.Close()
+	config.Override("InternalAPIHost", ts.URL)
+	defer config.RestoreOverridden()
+
+	AuthCache = NewTokenCache(TokenCacheOpts{TTL: time.Minute, NegativeTTL: time.Minute})
+	defer func() { AuthCache = nil }()
+
+	for i := 0; i < 3; i++ {
+		u, err := getCachedRemoteUser(Query{Token: "abc"})
+		require.NoError(t, err)
+		assert.Equal(t, 751365, u.ID)
+
+		_, err = getCachedRemoteUser(Query{Token: "invalid"})
+		assert.EqualError(t, err, "could not authenticate user")
+
+		u, err = getCachedRemoteUser(Query{Token: "unconfirmed"})
+		require.NoError(t, err)
+		assert.Equal(t, "", u.Email)
+	}
+	assert.EqualValues(t, 5, atomic.LoadInt32(&calls))
+
+	AuthCache.InvalidateUser(751365)
+	_, err := getCachedRemoteUser(Query{Token: "abc"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 6, atomic.LoadInt32(&calls))
+}
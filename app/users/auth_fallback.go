@@ -0,0 +1,161 @@
+package users
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/metrics"
+	"github.com/lbryio/lbrytv/internal/monitor"
+	"github.com/lbryio/lbrytv/models"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+)
+
+// Results of authenticating users from stored tokens as reported in AuthFallbacksTotal.
+const (
+	AuthFallbackAuthenticated = "authenticated"
+	AuthFallbackUnknownToken  = "unknown_token"
+	AuthFallbackError         = "error"
+)
+
+// fallbackAlertInterval is how often internal-apis outages are reported to Sentry while fallback is in use.
+const fallbackAlertInterval = 5 * time.Minute
+
+// AuthFallback authenticates users when internal-apis cannot be reached, it is nil when fallback is disabled.
+var AuthFallback *TokenFallback
+
+// AuthFallbacksTotal counts attempts to authenticate users from stored tokens by result.
+var AuthFallbacksTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "users",
+	Name:      "auth_fallbacks_total",
+	Help:      "Number of users authenticated from stored tokens while internal-apis was unavailable, by result.",
+}, []string{"result"})
+
+func init() {
+	metrics.Registry.MustRegister(AuthFallbacksTotal)
+}
+
+// TokenFallback stores hashes of tokens recently verified by internal-apis along with their user IDs
+// so users can keep authenticating for a grace period while internal-apis is down.
+// Tokens rejected by internal-apis are never authenticated this way.
+type TokenFallback struct {
+	grace  time.Duration
+	stop   chan struct{}
+	logger monitor.ModuleLogger
+
+	mu        sync.Mutex
+	lastAlert time.Time
+}
+
+// NewTokenFallback creates a TokenFallback accepting tokens verified within grace period.
+func NewTokenFallback(grace time.Duration) *TokenFallback {
+	return &TokenFallback{
+		grace:  grace,
+		stop:   make(chan struct{}),
+		logger: monitor.NewModuleLogger("auth_fallback"),
+	}
+}
+
+// Remember stores token as just verified by internal-apis for user with a given ID.
+func (f *TokenFallback) Remember(token string, uid int) error {
+	// Avoid rewriting the row on every request for the same token
+	_, err := queries.Raw(
+		`INSERT INTO auth_tokens (token_hash, user_id, verified_at) VALUES ($1, $2, now())
+		ON CONFLICT (token_hash) DO UPDATE SET user_id = EXCLUDED.user_id, verified_at = EXCLUDED.verified_at
+		WHERE auth_tokens.verified_at < now() - interval '1 minute' OR auth_tokens.user_id <> EXCLUDED.user_id`,
+		tokenCacheKey(token), uid,
+	).Exec(boil.GetDB())
+	return err
+}
+
+// Forget removes stored token, it should be called once internal-apis rejects the token.
+func (f *TokenFallback) Forget(token string) error {
+	_, err := models.AuthTokens(models.AuthTokenWhere.TokenHash.EQ(tokenCacheKey(token))).DeleteAll(boil.GetDB())
+	return err
+}
+
+// Lookup returns ID of the user token belongs to if it was verified by internal-apis within grace period.
+func (f *TokenFallback) Lookup(token string) (int, bool, error) {
+	t, err := models.AuthTokens(
+		models.AuthTokenWhere.TokenHash.EQ(tokenCacheKey(token)),
+		models.AuthTokenWhere.VerifiedAt.GT(time.Now().Add(-f.grace)),
+	).OneG()
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+	return t.UserID, true, nil
+}
+
+// Authenticate is called when internal-apis could not be reached while verifying token.
+// remoteErr is returned if the user cannot be authenticated from stored tokens.
+func (f *TokenFallback) Authenticate(q Query, remoteErr error) (*RemoteUser, error) {
+	f.alert(remoteErr)
+	uid, ok, err := f.Lookup(q.Token)
+	log := f.logger.LogF(monitor.F{monitor.RequestIDF: q.RequestID, "remote_error": remoteErr.Error()})
+	if err != nil {
+		AuthFallbacksTotal.WithLabelValues(AuthFallbackError).Inc()
+		log.Errorf("cannot look up stored token: %v", err)
+		return nil, remoteErr
+	}
+	if !ok {
+		AuthFallbacksTotal.WithLabelValues(AuthFallbackUnknownToken).Inc()
+		log.Info("token not verified recently, cannot authenticate without internal-apis")
+		return nil, remoteErr
+	}
+	AuthFallbacksTotal.WithLabelValues(AuthFallbackAuthenticated).Inc()
+	log.WithField("id", uid).Warn("internal-apis unavailable, user authenticated from stored token")
+	return &RemoteUser{ID: uid, Fallback: true}, nil
+}
+
+// alert reports internal-apis outage to Sentry, at most once per fallbackAlertInterval.
+func (f *TokenFallback) alert(remoteErr error) {
+	f.mu.Lock()
+	if time.Since(f.lastAlert) < fallbackAlertInterval {
+		f.mu.Unlock()
+		return
+	}
+	f.lastAlert = time.Now()
+	f.mu.Unlock()
+	monitor.CaptureException(
+		fmt.Errorf("internal-apis unavailable, authenticating users from stored tokens: %v", remoteErr),
+	)
+}
+
+// PurgeExpired removes tokens which haven't been verified within grace period.
+func (f *TokenFallback) PurgeExpired() (int64, error) {
+	return models.AuthTokens(
+		models.AuthTokenWhere.VerifiedAt.LT(time.Now().Add(-f.grace)),
+	).DeleteAll(boil.GetDB())
+}
+
+// Start launches periodic purging of expired tokens.
+func (f *TokenFallback) Start(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				n, err := f.PurgeExpired()
+				if err != nil {
+					f.logger.Log().Errorf("cannot purge expired tokens: %v", err)
+				} else if n > 0 {
+					f.logger.Log().Infof("purged %v expired tokens", n)
+				}
+			case <-f.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops purging expired tokens.
+func (f *TokenFallback) Stop() {
+	close(f.stop)
+}
@@ -0,0 +1,96 @@
+package users
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lbryio/lbrytv/config"
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/models"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/boil"
+)
+
+func TestAuthenticateRemoteUserRejectedToken(t *testing.T) {
+	ts := launchRejectingAPIServer()
+	defer ts.Close()
+	config.Override("InternalAPIHost", ts.URL)
+	defer config.RestoreOverridden()
+
+	AuthFallback = NewTokenFallback(time.Hour)
+	defer func() { AuthFallback = nil }()
+	before := testutil.ToFloat64(AuthFallbacksTotal.WithLabelValues(AuthFallbackUnknownToken))
+
+	// Rejected tokens never reach the fallback
+	u, err := authenticateRemoteUser(Query{Token: "abc"})
+	assert.Nil(t, u)
+	assert.EqualError(t, err, "could not authenticate user")
+	assert.Equal(t, before, testutil.ToFloat64(AuthFallbacksTotal.WithLabelValues(AuthFallbackUnknownToken)))
+}
+
+func TestAuthenticateRemoteUserRevokedToken(t *testing.T) {
+	testFuncSetup()
+
+	ts := launchRejectingAPIServer()
+	defer ts.Close()
+	config.Override("InternalAPIHost", ts.URL)
+	defer config.RestoreOverridden()
+
+	AuthFallback = NewTokenFallback(time.Hour)
+	defer func() { AuthFallback = nil }()
+	u := &models.User{ID: dummyUserID}
+	require.NoError(t, u.InsertG(boil.Infer()))
+	require.NoError(t, AuthFallback.Remember("abc", dummyUserID))
+
+	_, err := authenticateRemoteUser(Query{Token: "abc"})
+	require.Error(t, err)
+
+	// Token revoked in internal-apis is no longer accepted during an outage
+	_, ok, err := AuthFallback.Lookup("abc")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestWalletServiceRetrieveFallback(t *testing.T) {
+	testFuncSetup()
+
+	ts := launchAuthenticatingAPIServer(dummyUserID)
+	config.Override("InternalAPIHost", ts.URL)
+	defer config.RestoreOverridden()
+
+	AuthFallback = NewTokenFallback(time.Hour)
+	defer func() { AuthFallback = nil }()
+
+	svc := NewWalletService()
+	u, err := svc.Retrieve(Query{Token: "abc"})
+	require.NoError(t, err)
+	require.NotNil(t, u)
+
+	count, err := models.AuthTokens(models.AuthTokenWhere.UserID.EQ(dummyUserID)).CountG()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+
+	// internal-apis goes down
+	ts.Close()
+	authenticated := testutil.ToFloat64(AuthFallbacksTotal.WithLabelValues(AuthFallbackAuthenticated))
+
+	u, err = svc.Retrieve(Query{Token: "abc"})
+	require.NoError(t, err)
+	assert.Equal(t, lbrynet.MakeWalletID(dummyUserID), u.WalletID)
+	assert.Equal(t, authenticated+1, testutil.ToFloat64(AuthFallbacksTotal.WithLabelValues(AuthFallbackAuthenticated)))
+
+	_, err = svc.Retrieve(Query{Token: "def"})
+	require.Error(t, err)
+
+	// Tokens verified before the grace period are not accepted
+	AuthFallback = NewTokenFallback(time.Nanosecond)
+	_, err = svc.Retrieve(Query{Token: "abc"})
+	require.Error(t, err)
+
+	n, err := AuthFallback.PurgeExpired()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, n)
+}
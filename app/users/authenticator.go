@@ -3,8 +3,11 @@ package users
 import (
 	"errors"
 	"net/http"
+	"strings"
 
+	"github.com/lbryio/lbrytv/internal/maintenance"
 	"github.com/lbryio/lbrytv/internal/monitor"
+	"github.com/lbryio/lbrytv/models"
 )
 
 const GenericRetrievalErr = "unable to retrieve user"
@@ -13,53 +16,195 @@ var logger = monitor.NewModuleLogger("auth")
 
 type Authenticator struct {
 	retriever Retriever
+	bearer    Retriever
+	apiKeys   ScopedRetriever
+	guests    GuestRetriever
+	wallets   WalletSelector
 }
 
 type AuthenticatedRequest struct {
 	*http.Request
-	WalletID  string
+	WalletID string
+	// Scopes limit SDK methods the request can call, nil if there are no limits
+	Scopes    Scopes
 	AuthError error
 }
 
 type AuthenticatedFunc func(http.ResponseWriter, *AuthenticatedRequest)
 
+// unscoped makes a Retriever into a ScopedRetriever without method limits.
+type unscoped struct {
+	Retriever
+}
+
+func (r unscoped) RetrieveScoped(q Query) (*models.User, Scopes, error) {
+	u, err := r.Retrieve(q)
+	return u, nil, err
+}
+
 // NewAuthenticator provides HTTP handler wrapping methods
 // and should be initialized with an object that allows user retrieval.
+// Bearer tokens are checked by JWT retriever and guest tokens by Guests if they are enabled.
 func NewAuthenticator(retriever Retriever) *Authenticator {
-	return &Authenticator{retriever}
+	a := &Authenticator{retriever: retriever, apiKeys: NewAPIKeyRetriever(), wallets: Wallets}
+	if JWT != nil {
+		a.bearer = JWT
+	}
+	if Guests != nil {
+		a.guests = Guests
+	}
+	return a
 }
 
-// GetWalletID retrieves user token from HTTP headers and subsequently
-// an SDK account ID from Retriever.
-func (a *Authenticator) GetWalletID(r *http.Request) (string, error) {
-	if token, ok := r.Header[TokenHeader]; ok {
-		ip := GetIPAddressForRequest(r)
-		u, err := a.retriever.Retrieve(Query{Token: token[0], MetaRemoteIP: ip})
-		log := logger.LogF(monitor.F{"ip": ip})
-		if err != nil {
-			log.Debugf("failed to authenticate user")
-			return "", err
+// SetBearerRetriever sets retriever for tokens supplied in `Authorization: Bearer` header.
+// Scopes are enforced if it's also a ScopedRetriever.
+func (a *Authenticator) SetBearerRetriever(retriever Retriever) {
+	a.bearer = retriever
+}
+
+// SetAPIKeyRetriever sets retriever for keys supplied in APIKeyHeader.
+func (a *Authenticator) SetAPIKeyRetriever(retriever ScopedRetriever) {
+	a.apiKeys = retriever
+}
+
+// SetWalletSelector sets selector checking wallets requested in WalletHeader.
+func (a *Authenticator) SetWalletSelector(selector WalletSelector) {
+	a.wallets = selector
+}
+
+// SetGuestRetriever sets retriever for guest tokens supplied in GuestTokenHeader or GuestCookie.
+func (a *Authenticator) SetGuestRetriever(retriever GuestRetriever) {
+	a.guests = retriever
+}
+
+// getGuestToken returns guest token from request header or cookie.
+func getGuestToken(r *http.Request) string {
+	if t := r.Header.Get(GuestTokenHeader); t != "" {
+		return t
+	}
+	if c, err := r.Cookie(GuestCookie); err == nil {
+		return c.Value
+	}
+	return ""
+}
+
+// Authenticate retrieves user credentials from HTTP headers and subsequently
+// an SDK wallet ID and scopes it can be used with. internal-apis token takes precedence
+// over API key, which in turn takes precedence over bearer token. Guest token is only used
+// when there are no other credentials, otherwise guest wallet is merged into the wallet of authenticated user.
+// User's default wallet is used unless another one of their wallets is selected in WalletHeader,
+// which is not allowed with scoped credentials.
+func (a *Authenticator) Authenticate(r *http.Request) (string, Scopes, error) {
+	if !a.hasUserCredentials(r) {
+		if t := getGuestToken(r); t != "" && a.guests != nil {
+			return a.authenticateGuest(r, t)
 		}
-		if u == nil {
-			log.Debugf("user is nil")
-			return "", errors.New(GenericRetrievalErr)
+		return "", nil, nil
+	}
+	u, scopes, err := a.AuthenticateUser(r)
+	if err != nil {
+		return "", nil, err
+	}
+	wid := u.WalletID
+	if selected := r.Header.Get(WalletHeader); selected != "" && selected != wid {
+		if scopes != nil {
+			return "", nil, ErrScopedWalletSelection
+		}
+		if err := a.wallets.SelectWallet(u, selected); err != nil {
+			return "", nil, err
+		}
+		wid = selected
+	}
+	return wid, scopes, nil
+}
+
+func (a *Authenticator) hasUserCredentials(r *http.Request) bool {
+	_, ok := r.Header[TokenHeader]
+	return ok ||
+		(r.Header.Get(APIKeyHeader) != "" && a.apiKeys != nil) ||
+		(a.bearer != nil && strings.HasPrefix(r.Header.Get("Authorization"), BearerPrefix))
+}
+
+// AuthenticateUser retrieves user by credentials from HTTP headers, see Authenticate for their precedence.
+// Guest tokens are not accepted by it. Returned user is nil if there are no credentials.
+func (a *Authenticator) AuthenticateUser(r *http.Request) (*models.User, Scopes, error) {
+	var (
+		retriever ScopedRetriever
+		token     string
+	)
+	if t, ok := r.Header[TokenHeader]; ok {
+		retriever, token = unscoped{a.retriever}, t[0]
+	} else if t := r.Header.Get(APIKeyHeader); t != "" && a.apiKeys != nil {
+		retriever, token = a.apiKeys, t
+	} else if h := r.Header.Get("Authorization"); a.bearer != nil && strings.HasPrefix(h, BearerPrefix) {
+		retriever, token = unscoped{a.bearer}, strings.TrimPrefix(h, BearerPrefix)
+		// Bearer tokens of partner apps are limited to specific methods
+		if sr, ok := a.bearer.(ScopedRetriever); ok {
+			retriever = sr
 		}
-		log.Debugf("authenticated user")
-		return u.WalletID, nil
+	} else {
+		return nil, nil, nil
+	}
+
+	ip := GetIPAddressForRequest(r)
+	rid := monitor.RequestID(r.Context())
+	u, scopes, err := retriever.RetrieveScoped(Query{Token: token, MetaRemoteIP: ip, PeerIP: GetPeerIPAddress(r), RequestID: rid})
+	log := logger.LogF(monitor.F{"ip": ip, monitor.RequestIDF: rid})
+	if err != nil {
+		log.Debugf("failed to authenticate user")
+		return nil, nil, err
+	}
+	if u == nil {
+		log.Debugf("user is nil")
+		return nil, nil, errors.New(GenericRetrievalErr)
+	}
+	log.Debugf("authenticated user")
+	// Merge moves funds between wallets, so it's postponed until maintenance is over
+	if gt := getGuestToken(r); gt != "" && a.guests != nil && scopes == nil && !maintenance.Active() {
+		go func() {
+			if err := a.guests.Merge(gt, u); err != nil {
+				log.Errorf("cannot merge guest wallet: %v", err)
+			}
+		}()
+	}
+	return u, scopes, nil
+}
+
+func (a *Authenticator) authenticateGuest(r *http.Request, token string) (string, Scopes, error) {
+	ip := GetIPAddressForRequest(r)
+	rid := monitor.RequestID(r.Context())
+	wid, err := a.guests.RetrieveGuest(Query{Token: token, MetaRemoteIP: ip, RequestID: rid})
+	if err != nil {
+		logger.LogF(monitor.F{"ip": ip, monitor.RequestIDF: rid}).Debugf("failed to authenticate guest")
+		return "", nil, err
+	}
+	return wid, GuestScopes, nil
+}
+
+// GetWalletID is Authenticate for callers which cannot enforce scopes,
+// credentials limited to specific methods are rejected.
+func (a *Authenticator) GetWalletID(r *http.Request) (string, error) {
+	wid, scopes, err := a.Authenticate(r)
+	if err != nil {
+		return "", err
+	}
+	if scopes != nil {
+		return "", errors.New("scoped credentials are not accepted here")
 	}
-	return "", nil
+	return wid, nil
 }
 
 // Wrap result can be supplied to all functions that accept http.HandleFunc,
 // supplied function will be wrapped and called with AuthenticatedRequest instead of http.Request.
 func (a *Authenticator) Wrap(wrapped AuthenticatedFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		WalletID, err := a.GetWalletID(r)
+		WalletID, scopes, err := a.Authenticate(r)
 		ar := &AuthenticatedRequest{Request: r}
 		if err != nil {
 			ar.AuthError = err
 		} else {
 			ar.WalletID = WalletID
+			ar.Scopes = scopes
 		}
 		wrapped(w, ar)
 	}
@@ -6,10 +6,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/lbryio/lbrytv/internal/maintenance"
 	"github.com/lbryio/lbrytv/models"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type DummyRetriever struct {
@@ -64,3 +67,193 @@ func TestAuthenticatorFailure(t *testing.T) {
 	assert.Equal(t, "cannot authenticate", string(body))
 	assert.Equal(t, http.StatusForbidden, response.StatusCode)
 }
+
+func TestAuthenticatorBearer(t *testing.T) {
+	bearer := &TestUserRetriever{WalletID: "bEarer", Token: "jwt"}
+	authenticator := NewAuthenticator(&DummyRetriever{})
+	authenticator.SetBearerRetriever(bearer)
+
+	cases := []struct {
+		headers map[string]string
+		status  int
+		body    string
+	}{
+		{map[string]string{"Authorization": "Bearer jwt"}, http.StatusAccepted, "bEarer"},
+		{map[string]string{"Authorization": "Bearer wrong"}, http.StatusForbidden, GenericRetrievalErr},
+		// internal-apis token takes precedence
+		{map[string]string{"Authorization": "Bearer jwt", TokenHeader: "XyZ"}, http.StatusAccepted, "aBc"},
+		{map[string]string{"Authorization": "Basic jwt"}, http.StatusForbidden, ""},
+	}
+	for _, c := range cases {
+		r, _ := http.NewRequest("GET", "/api/proxy", nil)
+		for k, v := range c.headers {
+			r.Header.Set(k, v)
+		}
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(authenticator.Wrap(func(w http.ResponseWriter, r *AuthenticatedRequest) {
+			if r.AuthError == nil && !r.IsAuthenticated() {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			AuthenticatedHandler(w, r)
+		})).ServeHTTP(rr, r)
+		assert.Equal(t, c.status, rr.Code, c.headers)
+		assert.Equal(t, c.body, rr.Body.String(), c.headers)
+	}
+}
+
+type DummyScopedRetriever struct{}
+
+func (r *DummyScopedRetriever) RetrieveScoped(q Query) (*models.User, Scopes, error) {
+	if q.Token == "k3y" {
+		return &models.User{WalletID: "sCoped"}, Scopes{"publish"}, nil
+	}
+	return nil, nil, errors.New("invalid API key")
+}
+
+func TestAuthenticatorAPIKey(t *testing.T) {
+	authenticator := NewAuthenticator(&DummyRetriever{})
+	authenticator.SetAPIKeyRetriever(&DummyScopedRetriever{})
+
+	var ar *AuthenticatedRequest
+	r, _ := http.NewRequest("POST", "/api/v1/proxy", nil)
+	r.Header.Set(APIKeyHeader, "k3y")
+	http.HandlerFunc(authenticator.Wrap(func(w http.ResponseWriter, r *AuthenticatedRequest) { ar = r })).ServeHTTP(httptest.NewRecorder(), r)
+	require.NotNil(t, ar)
+	assert.Equal(t, "sCoped", ar.WalletID)
+	assert.Equal(t, Scopes{"publish"}, ar.Scopes)
+
+	_, err := authenticator.GetWalletID(r)
+	assert.EqualError(t, err, "scoped credentials are not accepted here")
+
+	// API keys can only be used with the default wallet
+	r.Header.Set(WalletHeader, "sEcond")
+	_, _, err = authenticator.Authenticate(r)
+	assert.Equal(t, ErrScopedWalletSelection, err)
+	r.Header.Set(WalletHeader, "sCoped")
+	wid, _, err := authenticator.Authenticate(r)
+	require.NoError(t, err)
+	assert.Equal(t, "sCoped", wid)
+	r.Header.Del(WalletHeader)
+
+	// internal-apis token credentials are not limited
+	r.Header.Set(TokenHeader, "XyZ")
+	wid, scopes, err := authenticator.Authenticate(r)
+	require.NoError(t, err)
+	assert.Equal(t, "aBc", wid)
+	assert.Nil(t, scopes)
+
+	r, _ = http.NewRequest("POST", "/api/v1/proxy", nil)
+	r.Header.Set(APIKeyHeader, "wrong")
+	_, _, err = authenticator.Authenticate(r)
+	assert.EqualError(t, err, "invalid API key")
+}
+
+type DummyGuestRetriever struct {
+	merged chan int
+}
+
+func (r *DummyGuestRetriever) RetrieveGuest(q Query) (string, error) {
+	if q.Token == "gUest" {
+		return "guest-wallet", nil
+	}
+	return "", ErrInvalidGuestToken
+}
+
+func (r *DummyGuestRetriever) Merge(token string, u *models.User) error {
+	r.merged <- len(u.WalletID)
+	return nil
+}
+
+func TestAuthenticatorGuest(t *testing.T) {
+	guests := &DummyGuestRetriever{merged: make(chan int, 1)}
+	authenticator := NewAuthenticator(&DummyRetriever{})
+	authenticator.SetGuestRetriever(guests)
+
+	r, _ := http.NewRequest("POST", "/api/v1/proxy", nil)
+	r.Header.Set(GuestTokenHeader, "gUest")
+	wid, scopes, err := authenticator.Authenticate(r)
+	require.NoError(t, err)
+	assert.Equal(t, "guest-wallet", wid)
+	assert.Equal(t, GuestScopes, scopes)
+
+	_, err = authenticator.GetWalletID(r)
+	assert.EqualError(t, err, "scoped credentials are not accepted here")
+
+	r, _ = http.NewRequest("POST", "/api/v1/proxy", nil)
+	r.AddCookie(&http.Cookie{Name: GuestCookie, Value: "wrong"})
+	_, _, err = authenticator.Authenticate(r)
+	assert.Equal(t, ErrInvalidGuestToken, err)
+
+	// Guest wallet is merged into the wallet of authenticated user
+	r, _ = http.NewRequest("POST", "/api/v1/proxy", nil)
+	r.Header.Set(GuestTokenHeader, "gUest")
+	r.Header.Set(TokenHeader, "XyZ")
+	wid, scopes, err = authenticator.Authenticate(r)
+	require.NoError(t, err)
+	assert.Equal(t, "aBc", wid)
+	assert.Nil(t, scopes)
+	select {
+	case <-guests.merged:
+	case <-time.After(time.Second):
+		t.Fatal("guest wallet was not merged")
+	}
+}
+
+func TestAuthenticatorGuestMergeMaintenance(t *testing.T) {
+	guests := &DummyGuestRetriever{merged: make(chan int, 1)}
+	authenticator := NewAuthenticator(&DummyRetriever{})
+	authenticator.SetGuestRetriever(guests)
+	maintenance.Set(maintenance.Status{Enabled: true})
+	defer maintenance.Set(maintenance.Status{})
+
+	r, _ := http.NewRequest("POST", "/api/v1/proxy", nil)
+	r.Header.Set(GuestTokenHeader, "gUest")
+	r.Header.Set(TokenHeader, "XyZ")
+	wid, _, err := authenticator.Authenticate(r)
+	require.NoError(t, err)
+	assert.Equal(t, "aBc", wid)
+	select {
+	case <-guests.merged:
+		t.Fatal("guest wallet was merged during maintenance")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+type DummyWalletSelector struct{}
+
+func (s *DummyWalletSelector) SelectWallet(u *models.User, walletID string) error {
+	if walletID == "sEcond" {
+		return nil
+	}
+	return ErrWalletNotFound
+}
+
+func TestAuthenticatorWalletSelection(t *testing.T) {
+	authenticator := NewAuthenticator(&DummyRetriever{})
+	authenticator.SetWalletSelector(&DummyWalletSelector{})
+
+	r, _ := http.NewRequest("POST", "/api/v1/proxy", nil)
+	r.Header.Set(TokenHeader, "XyZ")
+	r.Header.Set(WalletHeader, "sEcond")
+	wid, _, err := authenticator.Authenticate(r)
+	require.NoError(t, err)
+	assert.Equal(t, "sEcond", wid)
+
+	// Default wallet doesn't need checking
+	r.Header.Set(WalletHeader, "aBc")
+	wid, _, err = authenticator.Authenticate(r)
+	require.NoError(t, err)
+	assert.Equal(t, "aBc", wid)
+
+	r.Header.Set(WalletHeader, "someone-elses")
+	_, _, err = authenticator.Authenticate(r)
+	assert.Equal(t, ErrWalletNotFound, err)
+
+	// Wallet can't be selected without credentials
+	r, _ = http.NewRequest("POST", "/api/v1/proxy", nil)
+	r.Header.Set(WalletHeader, "sEcond")
+	wid, _, err = authenticator.Authenticate(r)
+	require.NoError(t, err)
+	assert.Equal(t, "", wid)
+}
@@ -0,0 +1,254 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/internal/monitor"
+	"github.com/lbryio/lbrytv/models"
+
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// ErrUserNotFound is returned when deleting a user who doesn't exist.
+var ErrUserNotFound = errors.New("user not found")
+
+// DeleteUserOpts contains user deletion settings.
+type DeleteUserOpts struct {
+	// ExportDir is where user's wallet accounts, including seeds, are exported to before deletion.
+	// Nothing is exported if it's empty.
+	ExportDir string
+	// WalletsDir is the SDK wallets directory. Wallet files are left in place if it's empty.
+	WalletsDir string
+	// ArchiveDir is where wallet files are moved to, they are deleted if it's empty.
+	ArchiveDir string
+	// UploadDir is the directory published files are uploaded to, see config.GetPublishSourceDir.
+	UploadDir string
+}
+
+// DeletionReport describes what was removed when deleting a user.
+type DeletionReport struct {
+	UserID    int      `json:"user_id"`
+	WalletIDs []string `json:"wallet_ids"`
+	// LegacyAccountID is the removed account of a user who hasn't been migrated to their own wallet yet
+	LegacyAccountID string `json:"legacy_account_id,omitempty"`
+	// ExportFile contains exported wallet accounts
+	ExportFile string `json:"export_file,omitempty"`
+	// WalletFiles are archived or deleted wallet files
+	WalletFiles []string `json:"wallet_files"`
+	// UploadDirs are removed directories with user's uploads
+	UploadDirs []string `json:"upload_dirs"`
+	// IdempotencyKeys is the number of deleted stored responses of user's requests
+	IdempotencyKeys int64 `json:"idempotency_keys"`
+}
+
+// DeleteUser erases user data: wallets and legacy account are removed from the SDK, wallet files archived or deleted,
+// uploaded files and database records, including spendings, API keys and stored tokens, are deleted.
+// Wallets and legacy account are exported first if opts.ExportDir is set, nothing is deleted if export fails.
+// Database records are deleted last so deletion can be retried if any of the previous steps fails.
+// Deletion is recorded in user_deletions without the user ID so it can be audited after the user is gone.
+// Users whose legacy account is being migrated to their wallet are not deleted until the migration is finished.
+func DeleteUser(uid int, opts DeleteUserOpts) (*DeletionReport, error) {
+	log := logger.LogF(monitor.F{"id": uid})
+
+	u, err := models.FindUserG(uid)
+	if err == sql.ErrNoRows {
+		return nil, ErrUserNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	// Escrowed seed and the wallet being migrated into are only known to the migration, deleting the user
+	// in the middle of it would lose them
+	if migrating, err := hasUnfinishedMigration(uid); err != nil {
+		return nil, err
+	} else if migrating {
+		return nil, ErrWalletMigrationInProgress
+	}
+	report := &DeletionReport{UserID: uid, WalletIDs: []string{}, WalletFiles: []string{}, UploadDirs: []string{}}
+
+	wallets, err := models.Wallets(models.WalletWhere.UserID.EQ(uid), qm.OrderBy(models.WalletColumns.ID)).AllG()
+	if err != nil {
+		return nil, err
+	}
+	if u.WalletID != "" {
+		report.WalletIDs = append(report.WalletIDs, u.WalletID)
+	}
+	for _, w := range wallets {
+		if w.WalletID != u.WalletID {
+			report.WalletIDs = append(report.WalletIDs, w.WalletID)
+		}
+	}
+
+	// Users who haven't moved to wallets yet have an account in the default SDK wallet
+	legacyAccount, err := lbrynet.GetAccount(uid)
+	if err == nil {
+		report.LegacyAccountID = legacyAccount.ID
+	} else if !errors.As(err, &lbrynet.AccountNotFound{}) {
+		return nil, fmt.Errorf("cannot look up legacy account: %v", err)
+	}
+
+	if opts.ExportDir != "" {
+		report.ExportFile, err = exportWallets(uid, report.WalletIDs, report.LegacyAccountID, opts.ExportDir)
+		if err != nil {
+			return nil, fmt.Errorf("cannot export wallets: %v", err)
+		}
+		log.WithField("export_file", report.ExportFile).Info("wallets exported")
+	}
+
+	for _, wid := range report.WalletIDs {
+		if err := lbrynet.RemoveWalletByID(wid, uid); err != nil && !errors.As(err, &lbrynet.WalletNotFound{}) {
+			return nil, fmt.Errorf("cannot remove wallet %v: %v", wid, err)
+		}
+		Wallets.forget(wid)
+	}
+	if report.LegacyAccountID != "" {
+		if _, err := lbrynet.RemoveAccount(uid); err != nil && !errors.As(err, &lbrynet.AccountNotFound{}) {
+			return nil, fmt.Errorf("cannot remove legacy account: %v", err)
+		}
+	}
+
+	if opts.WalletsDir != "" {
+		for _, wid := range report.WalletIDs {
+			f, err := disposeWalletFile(filepath.Join(opts.WalletsDir, wid), opts.ArchiveDir)
+			if err != nil {
+				return nil, err
+			}
+			if f != "" {
+				report.WalletFiles = append(report.WalletFiles, f)
+			}
+		}
+	}
+
+	if opts.UploadDir != "" {
+		for _, wid := range report.WalletIDs {
+			dir := filepath.Join(opts.UploadDir, wid)
+			if _, err := os.Stat(dir); os.IsNotExist(err) {
+				continue
+			}
+			if err := os.RemoveAll(dir); err != nil {
+				return nil, err
+			}
+			report.UploadDirs = append(report.UploadDirs, dir)
+		}
+	}
+
+	if len(report.WalletIDs) > 0 {
+		ids := make([]interface{}, len(report.WalletIDs))
+		for i, wid := range report.WalletIDs {
+			ids[i] = wid
+		}
+		report.IdempotencyKeys, err = models.IdempotencyKeys(
+			qm.WhereIn(models.IdempotencyKeyColumns.WalletID+" IN ?", ids...),
+		).DeleteAll(boil.GetDB())
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := deleteUserRecord(u, report); err != nil {
+		return nil, err
+	}
+	if AuthCache != nil {
+		AuthCache.InvalidateUser(uid)
+	}
+
+	log.WithField("wallet_ids", report.WalletIDs).
+		WithField("legacy_account_id", report.LegacyAccountID).
+		WithField("export_file", report.ExportFile).
+		WithField("wallet_files", report.WalletFiles).
+		WithField("upload_dirs", report.UploadDirs).
+		Warn("user deleted")
+	return report, nil
+}
+
+// deleteUserRecord deletes the user along with their wallets, spendings, spending limits, API keys and stored tokens,
+// and records the deletion in the same transaction.
+func deleteUserRecord(u *models.User, report *DeletionReport) error {
+	tx, err := boil.BeginTx(context.Background(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := u.Delete(tx); err != nil {
+		return err
+	}
+	deletion := &models.UserDeletion{
+		Wallets:         len(report.WalletIDs),
+		LegacyAccount:   report.LegacyAccountID != "",
+		Exported:        report.ExportFile != "",
+		WalletFiles:     len(report.WalletFiles),
+		UploadDirs:      len(report.UploadDirs),
+		IdempotencyKeys: int(report.IdempotencyKeys),
+	}
+	if err := deletion.Insert(tx, boil.Infer()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// exportWallets writes accounts of wallets and the legacy account, including their seeds and private keys,
+// to a file only readable by owner.
+func exportWallets(uid int, walletIDs []string, legacyAccountID, dir string) (string, error) {
+	export := map[string]interface{}{}
+	for _, wid := range walletIDs {
+		if err := lbrynet.LoadWalletByID(wid, uid); errors.As(err, &lbrynet.WalletNotFound{}) {
+			// Wallet file is gone, there is nothing to export
+			continue
+		} else if err != nil {
+			return "", fmt.Errorf("cannot load wallet %v: %v", wid, err)
+		}
+		var accounts interface{}
+		err := lbrynet.Call("account_list", map[string]interface{}{"wallet_id": wid, "show_seed": true}, &accounts)
+		if err != nil {
+			return "", err
+		}
+		export[wid] = accounts
+	}
+	content := map[string]interface{}{
+		"user_id":     uid,
+		"exported_at": time.Now().UTC(),
+		"wallets":     export,
+	}
+	if legacyAccountID != "" {
+		var account interface{}
+		err := lbrynet.Call("account_list", map[string]interface{}{"account_id": legacyAccountID, "show_seed": true}, &account)
+		if err != nil {
+			return "", err
+		}
+		content["legacy_account"] = account
+	}
+	data, err := json.MarshalIndent(content, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	f := filepath.Join(dir, fmt.Sprintf("user-%v-%v.json", uid, time.Now().Unix()))
+	return f, ioutil.WriteFile(f, data, 0600)
+}
+
+// disposeWalletFile moves wallet file to archiveDir or deletes it if archiveDir is empty.
+// It returns the archived file path, the deleted file path, or an empty string if there was no file.
+func disposeWalletFile(path, archiveDir string) (string, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return "", nil
+	}
+	if archiveDir == "" {
+		return path, os.Remove(path)
+	}
+	if err := os.MkdirAll(archiveDir, 0700); err != nil {
+		return "", err
+	}
+	dest := filepath.Join(archiveDir, fmt.Sprintf("%v.%v", filepath.Base(path), time.Now().Unix()))
+	return dest, os.Rename(path, dest)
+}
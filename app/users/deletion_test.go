@@ -0,0 +1,165 @@
+package users
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+func TestDeleteUser(t *testing.T) {
+	testFuncSetup()
+
+	u := &models.User{ID: dummyUserID}
+	require.NoError(t, u.InsertG(boil.Infer()))
+	require.NoError(t, NewWalletService().saveWalletID(u, lbrynet.MakeWalletID(dummyUserID)))
+	require.NoError(t, lbrynet.InitializeWalletByID(u.WalletID, u.ID))
+	second, err := Wallets.Create(u, "Personal")
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "user_deletion")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	uploadDir := filepath.Join(dir, "uploads")
+	require.NoError(t, os.MkdirAll(filepath.Join(uploadDir, u.WalletID), 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(uploadDir, u.WalletID, "video.mp4"), []byte("video"), 0600))
+
+	deletions, err := models.UserDeletions().CountG()
+	require.NoError(t, err)
+
+	report, err := DeleteUser(u.ID, DeleteUserOpts{ExportDir: filepath.Join(dir, "exports"), UploadDir: uploadDir})
+	require.NoError(t, err)
+	assert.Equal(t, []string{u.WalletID, second.WalletID}, report.WalletIDs)
+	assert.Equal(t, "", report.LegacyAccountID)
+	assert.Equal(t, []string{filepath.Join(uploadDir, u.WalletID)}, report.UploadDirs)
+	_, err = os.Stat(filepath.Join(uploadDir, u.WalletID))
+	assert.True(t, os.IsNotExist(err))
+
+	data, err := ioutil.ReadFile(report.ExportFile)
+	require.NoError(t, err)
+	var export struct {
+		UserID  int                    `json:"user_id"`
+		Wallets map[string]interface{} `json:"wallets"`
+	}
+	require.NoError(t, json.Unmarshal(data, &export))
+	assert.Equal(t, u.ID, export.UserID)
+	assert.Contains(t, export.Wallets, u.WalletID)
+	assert.Contains(t, export.Wallets, second.WalletID)
+
+	exists, err := models.UserExistsG(u.ID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	n, err := models.Wallets(models.WalletWhere.UserID.EQ(u.ID)).CountG()
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, n)
+	assert.False(t, Wallets.isLoaded(second.WalletID))
+
+	// Deletion is recorded without identifying the user
+	n, err = models.UserDeletions().CountG()
+	require.NoError(t, err)
+	assert.EqualValues(t, deletions+1, n)
+	deletion, err := models.UserDeletions(qm.OrderBy(models.UserDeletionColumns.ID + " DESC")).OneG()
+	require.NoError(t, err)
+	assert.Equal(t, 2, deletion.Wallets)
+	assert.True(t, deletion.Exported)
+	assert.False(t, deletion.LegacyAccount)
+	assert.Equal(t, 1, deletion.UploadDirs)
+
+	_, err = DeleteUser(u.ID, DeleteUserOpts{})
+	assert.Equal(t, ErrUserNotFound, err)
+}
+
+func TestDeleteLegacyUser(t *testing.T) {
+	testFuncSetup()
+
+	u := &models.User{ID: dummyUserID}
+	require.NoError(t, u.InsertG(boil.Infer()))
+	account, err := lbrynet.CreateAccount(u.ID)
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "user_deletion")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	report, err := DeleteUser(u.ID, DeleteUserOpts{ExportDir: dir})
+	require.NoError(t, err)
+	assert.Equal(t, []string{}, report.WalletIDs)
+	assert.Equal(t, account.ID, report.LegacyAccountID)
+
+	data, err := ioutil.ReadFile(report.ExportFile)
+	require.NoError(t, err)
+	var export struct {
+		LegacyAccount map[string]interface{} `json:"legacy_account"`
+	}
+	require.NoError(t, json.Unmarshal(data, &export))
+	assert.Equal(t, account.ID, export.LegacyAccount["id"])
+	assert.NotEmpty(t, export.LegacyAccount["seed"])
+
+	_, err = lbrynet.GetAccount(u.ID)
+	assert.True(t, errors.As(err, &lbrynet.AccountNotFound{}))
+
+	deletion, err := models.UserDeletions(qm.OrderBy(models.UserDeletionColumns.ID + " DESC")).OneG()
+	require.NoError(t, err)
+	assert.True(t, deletion.LegacyAccount)
+	assert.Equal(t, 0, deletion.Wallets)
+}
+
+func TestDeleteUserMigrationInProgress(t *testing.T) {
+	testFuncSetup()
+
+	u := &models.User{ID: dummyUserID}
+	require.NoError(t, u.InsertG(boil.Infer()))
+	state := &models.WalletMigration{
+		UserID: u.ID, Status: MigrationAccountRemoved, AccountID: "abc", WalletID: lbrynet.MakeWalletID(u.ID),
+	}
+	require.NoError(t, state.InsertG(boil.Infer()))
+
+	_, err := DeleteUser(u.ID, DeleteUserOpts{})
+	assert.Equal(t, ErrWalletMigrationInProgress, err)
+	exists, err := models.UserExistsG(u.ID)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	state.Status = MigrationDone
+	_, err = state.UpdateG(boil.Whitelist(models.WalletMigrationColumns.Status))
+	require.NoError(t, err)
+	_, err = DeleteUser(u.ID, DeleteUserOpts{})
+	require.NoError(t, err)
+}
+
+func TestDisposeWalletFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wallets")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	archiveDir := filepath.Join(dir, "archive")
+
+	f, err := disposeWalletFile(filepath.Join(dir, "missing.wallet"), archiveDir)
+	require.NoError(t, err)
+	assert.Equal(t, "", f)
+
+	path := filepath.Join(dir, "lbrytv-id.1.wallet")
+	require.NoError(t, ioutil.WriteFile(path, []byte("{}"), 0600))
+	f, err = disposeWalletFile(path, archiveDir)
+	require.NoError(t, err)
+	assert.Equal(t, archiveDir, filepath.Dir(f))
+	assert.FileExists(t, f)
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("{}"), 0600))
+	f, err = disposeWalletFile(path, "")
+	require.NoError(t, err)
+	assert.Equal(t, path, f)
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
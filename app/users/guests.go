@@ -0,0 +1,404 @@
+package users
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/internal/metrics"
+	"github.com/lbryio/lbrytv/internal/monitor"
+	"github.com/lbryio/lbrytv/models"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+)
+
+// GuestTokenHeader is the name of HTTP header containing guest token, GuestCookie can be used instead.
+const GuestTokenHeader = "X-Lbry-Guest-Token"
+
+// GuestCookie is the name of cookie containing guest token.
+const GuestCookie = "lbrytv_guest"
+
+// Guest lifecycle events as reported in GuestsTotal.
+const (
+	GuestCreated = "created"
+	GuestMerged  = "merged"
+	GuestPurged  = "purged"
+)
+
+// guestMergeFeeReserve is left in guest wallet to pay for the transaction moving its funds, in dewies.
+const guestMergeFeeReserve = 100000
+
+// GuestScopes are SDK methods guest wallets can be used with: enough for following channels,
+// which are stored in wallet preferences, and receiving rewards.
+var GuestScopes = Scopes{
+	"status",
+	"version",
+	"resolve",
+	"claim_search",
+	"comment_list",
+	"transaction_show",
+	"stream_cost_estimate",
+	"rpc.discover",
+
+	"preference_get",
+	"preference_set",
+	"address_unused",
+	"address_list",
+	"address_is_mine",
+	"wallet_balance",
+	"transaction_list",
+	"support_list",
+}
+
+// ErrInvalidGuestToken is returned for malformed, forged, expired and already merged guest tokens.
+var ErrInvalidGuestToken = errors.New("invalid guest token")
+
+// ErrGuestLimitReached is returned when too many guests have been created from the same IP address recently.
+var ErrGuestLimitReached = errors.New("too many guests created from this IP address, try again later")
+
+// Guests issues and authenticates guest wallets, it is nil when guest wallets are disabled.
+var Guests *GuestService
+
+// GuestsTotal counts guest wallets created, merged into user wallets and purged after inactivity.
+var GuestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "users",
+	Name:      "guests_total",
+	Help:      "Number of guest wallets by lifecycle event.",
+}, []string{"event"})
+
+func init() {
+	metrics.Registry.MustRegister(GuestsTotal)
+}
+
+// GuestRetriever is an interface for authenticating guests and merging their wallets into user wallets.
+type GuestRetriever interface {
+	// RetrieveGuest returns wallet ID of the guest q.Token belongs to.
+	RetrieveGuest(q Query) (string, error)
+	// Merge moves guest wallet contents into the wallet of user who has just authenticated.
+	Merge(token string, u *models.User) error
+}
+
+// GuestServiceOpts contains guest wallets settings.
+type GuestServiceOpts struct {
+	// Secret is the key guest tokens are signed with.
+	Secret string
+	// TTL is for how long guest wallets are kept after they were last used.
+	TTL time.Duration
+	// HourlyLimitPerIP is how many guests can be created from a single IP address per hour, 0 means no limit.
+	HourlyLimitPerIP int
+}
+
+// GuestService provisions ephemeral SDK wallets for users who are not logged in, identified
+// by signed guest tokens. Guest wallets are purged after TTL of inactivity and merged
+// into user wallet when the guest later authenticates.
+type GuestService struct {
+	opts   GuestServiceOpts
+	stop   chan struct{}
+	logger monitor.ModuleLogger
+
+	mu     sync.Mutex
+	loaded map[int]bool
+}
+
+// NewGuestService creates a GuestService, opts.Secret is required.
+func NewGuestService(opts GuestServiceOpts) (*GuestService, error) {
+	if opts.Secret == "" {
+		return nil, errors.New("guest token secret is required")
+	}
+	return &GuestService{
+		opts:   opts,
+		stop:   make(chan struct{}),
+		logger: monitor.NewModuleLogger("guests"),
+		loaded: map[int]bool{},
+	}, nil
+}
+
+// Token returns signed token of guest with a given ID.
+func (s *GuestService) Token(gid int) string {
+	id := strconv.Itoa(gid)
+	return id + "." + s.sign(id)
+}
+
+func (s *GuestService) sign(id string) string {
+	mac := hmac.New(sha256.New, []byte(s.opts.Secret))
+	mac.Write([]byte("guest:" + id))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ParseToken verifies token signature and returns guest ID it was issued for.
+func (s *GuestService) ParseToken(token string) (int, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return 0, ErrInvalidGuestToken
+	}
+	if !hmac.Equal([]byte(parts[1]), []byte(s.sign(parts[0]))) {
+		return 0, ErrInvalidGuestToken
+	}
+	gid, err := strconv.Atoi(parts[0])
+	if err != nil || gid <= 0 {
+		return 0, ErrInvalidGuestToken
+	}
+	return gid, nil
+}
+
+// Create registers a new guest with an SDK wallet and returns its token.
+func (s *GuestService) Create(ip string) (string, *models.Guest, error) {
+	if s.opts.HourlyLimitPerIP > 0 {
+		n, err := models.Guests(
+			models.GuestWhere.CreatedIP.EQ(ip),
+			models.GuestWhere.CreatedAt.GT(time.Now().Add(-time.Hour)),
+		).CountG()
+		if err != nil {
+			return "", nil, err
+		}
+		if n >= int64(s.opts.HourlyLimitPerIP) {
+			s.logger.LogF(monitor.F{"ip": ip}).Warn("guest limit reached")
+			return "", nil, ErrGuestLimitReached
+		}
+	}
+
+	g := &models.Guest{CreatedIP: ip}
+	if err := g.InsertG(boil.Infer()); err != nil {
+		return "", nil, err
+	}
+	if _, err := lbrynet.InitializeGuestWallet(g.ID); err != nil {
+		return "", nil, err
+	}
+	s.markLoaded(g.ID, true)
+	GuestsTotal.WithLabelValues(GuestCreated).Inc()
+	s.logger.LogF(monitor.F{"guest_id": g.ID, "ip": ip}).Info("guest created")
+	return s.Token(g.ID), g, nil
+}
+
+// RetrieveGuest checks guest token supplied in q.Token and returns wallet ID of the guest.
+func (s *GuestService) RetrieveGuest(q Query) (string, error) {
+	gid, err := s.ParseToken(q.Token)
+	if err != nil {
+		return "", err
+	}
+	log := s.logger.LogF(monitor.F{"guest_id": gid, "ip": q.MetaRemoteIP, monitor.RequestIDF: q.RequestID})
+
+	g, err := models.FindGuestG(gid)
+	if err == sql.ErrNoRows {
+		log.Info("expired guest token used")
+		return "", ErrInvalidGuestToken
+	} else if err != nil {
+		return "", err
+	}
+	// Merged user can be deleted later, which clears merged_user_id but not merged_at
+	if g.MergedAt.Valid {
+		log.Info("merged guest token used")
+		return "", ErrInvalidGuestToken
+	}
+
+	if lbrynet.Wallets != nil {
+		// Tracker loads the wallet back if it was unloaded after being idle
+		if err := lbrynet.Wallets.Touch(lbrynet.MakeGuestWalletID(gid)); err != nil {
+			log.Errorf("cannot load guest wallet: %v", err)
+			return "", err
+		}
+	} else if !s.isLoaded(gid) {
+		if _, err := lbrynet.InitializeGuestWallet(gid); err != nil {
+			log.Errorf("cannot load guest wallet: %v", err)
+			return "", err
+		}
+		s.markLoaded(gid, true)
+	}
+
+	// Avoid rewriting the row on every request
+	_, err = queries.Raw(
+		`UPDATE guests SET last_used_at = now() WHERE id = $1 AND last_used_at < now() - interval '1 minute'`,
+		gid,
+	).Exec(boil.GetDB())
+	if err != nil {
+		log.Errorf("cannot update guest usage time: %v", err)
+	}
+
+	return lbrynet.MakeGuestWalletID(gid), nil
+}
+
+// Merge copies guest wallet preferences the user doesn't have yet and sends its funds to the user wallet,
+// after which guest token stops working. Guests which are already merged or purged are skipped.
+func (s *GuestService) Merge(token string, u *models.User) error {
+	gid, err := s.ParseToken(token)
+	if err != nil {
+		return err
+	}
+	log := s.logger.LogF(monitor.F{"guest_id": gid, "id": u.ID})
+
+	// Claiming the guest first so concurrent requests don't merge it twice
+	res, err := queries.Raw(
+		`UPDATE guests SET merged_user_id = $1, merged_at = now() WHERE id = $2 AND merged_at IS NULL`,
+		u.ID, gid,
+	).Exec(boil.GetDB())
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return nil
+	}
+
+	if err := s.mergeWallet(gid, u.WalletID, log); err != nil {
+		// Release the guest so merge is retried on the next request
+		_, rErr := queries.Raw(
+			`UPDATE guests SET merged_user_id = NULL, merged_at = NULL WHERE id = $1`, gid,
+		).Exec(boil.GetDB())
+		if rErr != nil {
+			log.Errorf("cannot release guest after failed merge: %v", rErr)
+		}
+		return err
+	}
+
+	if err := lbrynet.RemoveGuestWallet(gid); err != nil {
+		log.Errorf("cannot remove merged guest wallet: %v", err)
+	}
+	s.markLoaded(gid, false)
+	GuestsTotal.WithLabelValues(GuestMerged).Inc()
+	log.Info("guest merged into user")
+	return nil
+}
+
+func (s *GuestService) mergeWallet(gid int, userWalletID string, log *logrus.Entry) error {
+	gwid, err := lbrynet.InitializeGuestWallet(gid)
+	if err != nil {
+		return err
+	}
+
+	var guestPrefs, userPrefs map[string]interface{}
+	if err := lbrynet.Call("preference_get", map[string]interface{}{"wallet_id": gwid}, &guestPrefs); err != nil {
+		return err
+	}
+	if err := lbrynet.Call("preference_get", map[string]interface{}{"wallet_id": userWalletID}, &userPrefs); err != nil {
+		return err
+	}
+	for k, v := range guestPrefs {
+		if _, ok := userPrefs[k]; ok {
+			continue
+		}
+		err := lbrynet.Call("preference_set", map[string]interface{}{"key": k, "value": v, "wallet_id": userWalletID}, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	var balance struct {
+		Available string `json:"available"`
+	}
+	if err := lbrynet.Call("wallet_balance", map[string]interface{}{"wallet_id": gwid}, &balance); err != nil {
+		return err
+	}
+	available, err := lbrynet.ParseLBC(balance.Available)
+	if err != nil {
+		return err
+	}
+	if available <= guestMergeFeeReserve {
+		return nil
+	}
+	var address string
+	if err := lbrynet.Call("address_unused", map[string]interface{}{"wallet_id": userWalletID}, &address); err != nil {
+		return err
+	}
+	amount := lbrynet.FormatLBC(available - guestMergeFeeReserve)
+	err = lbrynet.Call("wallet_send", map[string]interface{}{
+		"amount": amount, "addresses": []string{address}, "wallet_id": gwid,
+	}, nil)
+	if err != nil {
+		return err
+	}
+	log.Infof("sent %v LBC from guest wallet to %v", amount, address)
+	return nil
+}
+
+// PurgeInactive removes guests which haven't been used within TTL and unloads their wallets.
+func (s *GuestService) PurgeInactive() (int, error) {
+	guests, err := models.Guests(models.GuestWhere.LastUsedAt.LT(time.Now().Add(-s.opts.TTL))).AllG()
+	if err != nil {
+		return 0, err
+	}
+	purged := 0
+	for _, g := range guests {
+		// Wallets of merged guests are already removed
+		if !g.MergedAt.Valid {
+			if err := lbrynet.RemoveGuestWallet(g.ID); err != nil {
+				s.logger.LogF(monitor.F{"guest_id": g.ID}).Errorf("cannot remove guest wallet: %v", err)
+				continue
+			}
+		}
+		if _, err := g.DeleteG(); err != nil {
+			return purged, err
+		}
+		s.markLoaded(g.ID, false)
+		GuestsTotal.WithLabelValues(GuestPurged).Inc()
+		purged++
+	}
+	return purged, nil
+}
+
+// Start launches periodic purging of inactive guests.
+func (s *GuestService) Start(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				n, err := s.PurgeInactive()
+				if err != nil {
+					s.logger.Log().Errorf("cannot purge inactive guests: %v", err)
+				} else if n > 0 {
+					s.logger.Log().Infof("purged %v inactive guests", n)
+				}
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops purging inactive guests.
+func (s *GuestService) Stop() {
+	close(s.stop)
+}
+
+// ResetLoaded forgets which guest wallets were loaded into the SDK so they are loaded again on their next use.
+// It should be called when the SDK reports a wallet is not loaded, which happens after it restarts.
+func (s *GuestService) ResetLoaded() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loaded = map[int]bool{}
+}
+
+func (s *GuestService) isLoaded(gid int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.loaded[gid]
+}
+
+func (s *GuestService) markLoaded(gid int, loaded bool) {
+	if lbrynet.Wallets != nil {
+		if loaded {
+			lbrynet.Wallets.MarkLoaded(lbrynet.MakeGuestWalletID(gid))
+		} else {
+			lbrynet.Wallets.Forget(lbrynet.MakeGuestWalletID(gid))
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if loaded {
+		s.loaded[gid] = true
+	} else {
+		delete(s.loaded, gid)
+	}
+}
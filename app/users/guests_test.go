@@ -0,0 +1,99 @@
+package users
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/boil"
+)
+
+func TestGuestToken(t *testing.T) {
+	_, err := NewGuestService(GuestServiceOpts{})
+	assert.Error(t, err)
+
+	s, err := NewGuestService(GuestServiceOpts{Secret: "secret"})
+	require.NoError(t, err)
+	token := s.Token(42)
+	gid, err := s.ParseToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, 42, gid)
+
+	other, err := NewGuestService(GuestServiceOpts{Secret: "other"})
+	require.NoError(t, err)
+	for _, tok := range []string{other.Token(42), "42", "43" + token[2:], "", "0." + s.sign("0"), "x." + s.sign("x")} {
+		_, err := s.ParseToken(tok)
+		assert.Equal(t, ErrInvalidGuestToken, err, tok)
+	}
+}
+
+func TestGuestService(t *testing.T) {
+	testFuncSetup()
+	sdk.Reset()
+
+	s, err := NewGuestService(GuestServiceOpts{Secret: "secret", TTL: time.Hour, HourlyLimitPerIP: 1})
+	require.NoError(t, err)
+
+	token, g, err := s.Create("8.8.8.8")
+	require.NoError(t, err)
+	_, _, err = s.Create("8.8.8.8")
+	assert.Equal(t, ErrGuestLimitReached, err)
+
+	wid, err := s.RetrieveGuest(Query{Token: token})
+	require.NoError(t, err)
+	assert.Equal(t, lbrynet.MakeGuestWalletID(g.ID), wid)
+
+	u := &models.User{ID: dummyUserID, WalletID: lbrynet.MakeWalletID(dummyUserID)}
+	require.NoError(t, u.InsertG(boil.Infer()))
+	sdk.SetResult("preference_get", map[string]interface{}{"subscriptions": []string{"lbry://@channel"}})
+	sdk.SetResult("preference_set", map[string]interface{}{})
+	sdk.SetResult("wallet_balance", map[string]interface{}{"available": "1.0"})
+	sdk.SetResult("address_unused", "bAddress")
+	sdk.SetResult("wallet_send", map[string]interface{}{})
+
+	require.NoError(t, s.Merge(token, u))
+	sends := sdk.Requests("wallet_send")
+	require.Len(t, sends, 1)
+	assert.Equal(t, "0.999", sends[0].Params.(map[string]interface{})["amount"])
+	// User already has the preference so it's not overwritten
+	assert.Len(t, sdk.Requests("preference_set"), 0)
+
+	_, err = s.RetrieveGuest(Query{Token: token})
+	assert.Equal(t, ErrInvalidGuestToken, err)
+	// Merging again is a no-op
+	require.NoError(t, s.Merge(token, u))
+	assert.Len(t, sdk.Requests("wallet_send"), 1)
+
+	// Deleting the user clears merged_user_id, guest must stay merged
+	_, err = u.DeleteG()
+	require.NoError(t, err)
+	require.NoError(t, g.ReloadG())
+	assert.False(t, g.MergedUserID.Valid)
+	_, err = s.RetrieveGuest(Query{Token: token})
+	assert.Equal(t, ErrInvalidGuestToken, err)
+
+	// Merged guest wallet is already gone so it's not removed again, which would fail and keep the guest around
+	sdk.SetError("wallet_remove", -32500, "Couldn't find wallet: "+wid)
+	g.LastUsedAt = time.Now().Add(-2 * time.Hour)
+	_, err = g.UpdateG(boil.Whitelist(models.GuestColumns.LastUsedAt))
+	require.NoError(t, err)
+	removals := len(sdk.Requests("wallet_remove"))
+	n, err := s.PurgeInactive()
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Len(t, sdk.Requests("wallet_remove"), removals)
+}
+
+func TestGuestServiceResetLoaded(t *testing.T) {
+	s, err := NewGuestService(GuestServiceOpts{Secret: "secret"})
+	require.NoError(t, err)
+	s.markLoaded(42, true)
+	require.True(t, s.isLoaded(42))
+
+	s.ResetLoaded()
+	assert.False(t, s.isLoaded(42))
+}
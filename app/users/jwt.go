@@ -0,0 +1,235 @@
+package users
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/monitor"
+	"github.com/lbryio/lbrytv/models"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// BearerPrefix precedes JWT in `Authorization` header.
+const BearerPrefix = "Bearer "
+
+// DefaultUserClaim is the JWT claim containing user ID if another one is not configured.
+const DefaultUserClaim = "sub"
+
+// JWT validates bearer tokens issued to first-party and partner apps, it is nil when JWT auth is disabled.
+var JWT *JWTRetriever
+
+// JWTIssuer contains keys tokens of a single issuer are verified with.
+type JWTIssuer struct {
+	// Issuer is the value of `iss` claim of the tokens.
+	Issuer string
+	// Secrets are HMAC keys tokens signed with HS256, HS384 or HS512 are checked against.
+	Secrets []string
+	// KeysFile is a path to JWKS file containing RSA and ECDSA public keys.
+	KeysFile string
+	// Scopes limit SDK methods users authenticated with tokens of the issuer can call, nil means no limits.
+	// They should be set for partner issuers, which can otherwise act on behalf of any user.
+	Scopes Scopes
+}
+
+// JWTRetrieverOpts contains JWT validation settings.
+type JWTRetrieverOpts struct {
+	// Issuers are accepted token issuers along with their keys.
+	Issuers []JWTIssuer
+	// Audience is the value required to be present in `aud` claim.
+	Audience string
+	// UserClaim is the claim containing user ID, DefaultUserClaim is used if it's empty.
+	UserClaim string
+	// Leeway is the allowed clock skew when checking token expiry.
+	Leeway time.Duration
+}
+
+// JWTRetriever is a ScopedRetriever authenticating users by JWTs supplied in `Authorization: Bearer` header.
+// User ID from the token is mapped to a local user record with a wallet, which are created if needed.
+// Tokens are only verified with keys of the issuer in their `iss` claim, so one issuer can't sign tokens
+// on behalf of another, and are limited to scopes of that issuer.
+type JWTRetriever struct {
+	opts    JWTRetrieverOpts
+	issuers map[string]*issuerKeys
+	service *WalletService
+}
+
+type issuerKeys struct {
+	secrets []string
+	keys    jose.JSONWebKeySet
+	scopes  Scopes
+}
+
+// NewJWTRetriever creates a JWTRetriever, loading public keys from keys files of the issuers.
+func NewJWTRetriever(opts JWTRetrieverOpts) (*JWTRetriever, error) {
+	if len(opts.Issuers) == 0 || opts.Audience == "" {
+		return nil, errors.New("both issuers and audience need to be set")
+	}
+	if opts.UserClaim == "" {
+		opts.UserClaim = DefaultUserClaim
+	}
+	r := &JWTRetriever{
+		opts:    opts,
+		issuers: map[string]*issuerKeys{},
+		service: &WalletService{UserService{logger: monitor.NewModuleLogger("jwt")}},
+	}
+	for _, iss := range opts.Issuers {
+		if iss.Issuer == "" {
+			return nil, errors.New("issuer name is required")
+		}
+		if _, ok := r.issuers[iss.Issuer]; ok {
+			return nil, fmt.Errorf("issuer %q is configured more than once", iss.Issuer)
+		}
+		if len(iss.Secrets) == 0 && iss.KeysFile == "" {
+			return nil, fmt.Errorf("either secrets or keys file need to be set for issuer %q", iss.Issuer)
+		}
+		keys := &issuerKeys{secrets: iss.Secrets, scopes: iss.Scopes}
+		if iss.KeysFile != "" {
+			data, err := ioutil.ReadFile(iss.KeysFile)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(data, &keys.keys); err != nil {
+				return nil, fmt.Errorf("cannot parse keys file of issuer %q: %v", iss.Issuer, err)
+			}
+		}
+		r.issuers[iss.Issuer] = keys
+	}
+	return r, nil
+}
+
+// Retrieve is RetrieveScoped for callers which cannot enforce scopes, tokens of issuers with scopes are rejected.
+func (r *JWTRetriever) Retrieve(q Query) (*models.User, error) {
+	u, scopes, err := r.RetrieveScoped(q)
+	if err != nil {
+		return nil, err
+	}
+	if scopes != nil {
+		return nil, errors.New("tokens of this issuer are limited to specific methods")
+	}
+	return u, nil
+}
+
+// RetrieveScoped validates JWT supplied in q.Token and returns user with ID from its user claim
+// along with scopes of the token issuer.
+func (r *JWTRetriever) RetrieveScoped(q Query) (*models.User, Scopes, error) {
+	log := r.service.logger.LogF(monitor.F{monitor.RequestIDF: q.RequestID})
+	id, keys, err := r.verify(q.Token)
+	if err != nil {
+		return nil, nil, r.service.LogErrorAndReturn(log, "cannot authenticate user with token: %v", err)
+	}
+	u, err := r.service.retrieveLocalUser(id, log.WithField("id", id))
+	if err != nil {
+		return nil, nil, err
+	}
+	return u, keys.scopes, nil
+}
+
+// UserID verifies token signature and claims, returning the user ID it was issued for.
+func (r *JWTRetriever) UserID(token string) (int, error) {
+	id, _, err := r.verify(token)
+	return id, err
+}
+
+// verify checks token signature and claims, returning user ID along with keys of the token issuer.
+func (r *JWTRetriever) verify(token string) (int, *issuerKeys, error) {
+	var (
+		claims jwt.Claims
+		custom map[string]interface{}
+	)
+
+	tok, err := jwt.ParseSigned(token)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(tok.Headers) != 1 {
+		return 0, nil, errors.New("token should have exactly one signature")
+	}
+	// Issuer is needed to pick the keys, claims are not trusted until the signature is verified
+	if err := tok.UnsafeClaimsWithoutVerification(&claims, &custom); err != nil {
+		return 0, nil, err
+	}
+	keys, ok := r.issuers[claims.Issuer]
+	if !ok {
+		return 0, nil, fmt.Errorf("unknown issuer %q", claims.Issuer)
+	}
+	verified := false
+	for _, key := range keys.verificationKeys(tok.Headers[0]) {
+		if err := tok.Claims(key); err == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return 0, nil, errors.New("invalid token signature")
+	}
+
+	if claims.Expiry == nil {
+		return 0, nil, errors.New("token has no expiry")
+	}
+	err = claims.ValidateWithLeeway(jwt.Expected{Audience: jwt.Audience{r.opts.Audience}, Time: time.Now()}, r.opts.Leeway)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	id, err := parseUserClaim(custom[r.opts.UserClaim])
+	if err != nil {
+		return 0, nil, err
+	}
+	return id, keys, nil
+}
+
+// verificationKeys returns keys token signature should be checked against depending on its algorithm.
+// Secrets are never used with asymmetric algorithms and public keys with HMAC.
+func (ik *issuerKeys) verificationKeys(h jose.Header) []interface{} {
+	var keys []interface{}
+	switch jose.SignatureAlgorithm(h.Algorithm) {
+	case jose.HS256, jose.HS384, jose.HS512:
+		for _, s := range ik.secrets {
+			keys = append(keys, []byte(s))
+		}
+	default:
+		candidates := ik.keys.Keys
+		if h.KeyID != "" {
+			candidates = ik.keys.Key(h.KeyID)
+		}
+		for _, k := range candidates {
+			if k.IsPublic() && (k.Algorithm == "" || k.Algorithm == h.Algorithm) {
+				keys = append(keys, k.Key)
+			}
+		}
+	}
+	return keys
+}
+
+func parseUserClaim(v interface{}) (int, error) {
+	var (
+		id  int
+		err error
+	)
+	switch val := v.(type) {
+	case float64:
+		id = int(val)
+		if float64(id) != val {
+			err = errors.New("user ID is not an integer")
+		}
+	case string:
+		id, err = strconv.Atoi(val)
+	case nil:
+		return 0, errors.New("token has no user ID")
+	default:
+		err = fmt.Errorf("unexpected user ID type %T", v)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if id <= 0 {
+		return 0, fmt.Errorf("invalid user ID %v", id)
+	}
+	return id, nil
+}
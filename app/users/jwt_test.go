@@ -0,0 +1,214 @@
+package users
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+type jwtTestKeys struct {
+	rsa      *rsa.PrivateKey
+	ec       *ecdsa.PrivateKey
+	keysFile string
+}
+
+func generateJWTTestKeys(t *testing.T) jwtTestKeys {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	jwks := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: rsaKey.Public(), KeyID: "rsa1", Algorithm: string(jose.RS256), Use: "sig"},
+		{Key: ecKey.Public(), KeyID: "ec1", Algorithm: string(jose.ES256), Use: "sig"},
+	}}
+	data, err := json.Marshal(jwks)
+	require.NoError(t, err)
+	f, err := ioutil.TempFile("", "jwks")
+	require.NoError(t, err)
+	f.Write(data)
+	f.Close()
+	return jwtTestKeys{rsa: rsaKey, ec: ecKey, keysFile: f.Name()}
+}
+
+func signJWT(t *testing.T, alg jose.SignatureAlgorithm, key interface{}, kid string, claims jwt.Claims, custom map[string]interface{}) string {
+	opts := &jose.SignerOptions{}
+	if kid != "" {
+		opts.WithHeader("kid", kid)
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: alg, Key: key}, opts.WithType("JWT"))
+	require.NoError(t, err)
+	token, err := jwt.Signed(signer).Claims(claims).Claims(custom).CompactSerialize()
+	require.NoError(t, err)
+	return token
+}
+
+func validJWTClaims() jwt.Claims {
+	return jwt.Claims{
+		Issuer:   "https://lbry.tv",
+		Audience: jwt.Audience{"lbrytv"},
+		Expiry:   jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+	}
+}
+
+func lbrytvIssuer(secrets ...string) []JWTIssuer {
+	return []JWTIssuer{{Issuer: "https://lbry.tv", Secrets: secrets}}
+}
+
+func TestNewJWTRetriever(t *testing.T) {
+	_, err := NewJWTRetriever(JWTRetrieverOpts{Issuers: lbrytvIssuer("secret")})
+	assert.EqualError(t, err, "both issuers and audience need to be set")
+	_, err = NewJWTRetriever(JWTRetrieverOpts{Issuers: lbrytvIssuer(), Audience: "lbrytv"})
+	assert.EqualError(t, err, `either secrets or keys file need to be set for issuer "https://lbry.tv"`)
+	_, err = NewJWTRetriever(JWTRetrieverOpts{Issuers: []JWTIssuer{{Secrets: []string{"secret"}}}, Audience: "lbrytv"})
+	assert.EqualError(t, err, "issuer name is required")
+	_, err = NewJWTRetriever(JWTRetrieverOpts{Issuers: append(lbrytvIssuer("secret"), lbrytvIssuer("other")...), Audience: "lbrytv"})
+	assert.EqualError(t, err, `issuer "https://lbry.tv" is configured more than once`)
+	_, err = NewJWTRetriever(JWTRetrieverOpts{
+		Issuers: []JWTIssuer{{Issuer: "https://lbry.tv", KeysFile: "/nonexistent"}}, Audience: "lbrytv",
+	})
+	assert.Error(t, err)
+}
+
+func TestJWTRetrieverUserID(t *testing.T) {
+	keys := generateJWTTestKeys(t)
+	defer os.Remove(keys.keysFile)
+
+	r, err := NewJWTRetriever(JWTRetrieverOpts{
+		Issuers: []JWTIssuer{
+			{Issuer: "https://lbry.tv", Secrets: []string{"oldsecret", "secret"}, KeysFile: keys.keysFile},
+			{Issuer: "https://partner.example.com", Secrets: []string{"partnersecret"}, KeysFile: keys.keysFile},
+		},
+		Audience: "lbrytv",
+	})
+	require.NoError(t, err)
+
+	expired := validJWTClaims()
+	expired.Expiry = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+	noExpiry := validJWTClaims()
+	noExpiry.Expiry = nil
+	partner := validJWTClaims()
+	partner.Issuer = "https://partner.example.com"
+	unknownIssuer := validJWTClaims()
+	unknownIssuer.Issuer = "https://evil.com"
+	wrongAudience := validJWTClaims()
+	wrongAudience.Audience = jwt.Audience{"other"}
+	otherRSA, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name  string
+		token string
+		id    int
+		err   string
+	}{
+		{"hmac", signJWT(t, jose.HS256, []byte("secret"), "", validJWTClaims(), map[string]interface{}{"sub": "123"}), 123, ""},
+		{"rsa", signJWT(t, jose.RS256, keys.rsa, "rsa1", validJWTClaims(), map[string]interface{}{"sub": "123"}), 123, ""},
+		{"ecdsa without kid", signJWT(t, jose.ES256, keys.ec, "", partner, map[string]interface{}{"sub": "456"}), 456, ""},
+		{"partner hmac", signJWT(t, jose.HS256, []byte("partnersecret"), "", partner, map[string]interface{}{"sub": "456"}), 456, ""},
+		{"secret of another issuer", signJWT(t, jose.HS256, []byte("secret"), "", partner, map[string]interface{}{"sub": "456"}), 0, "invalid token signature"},
+		{"partner secret for lbry.tv", signJWT(t, jose.HS256, []byte("partnersecret"), "", validJWTClaims(), map[string]interface{}{"sub": "123"}), 0, "invalid token signature"},
+		{"wrong secret", signJWT(t, jose.HS256, []byte("wrong"), "", validJWTClaims(), map[string]interface{}{"sub": "123"}), 0, "invalid token signature"},
+		{"unknown key", signJWT(t, jose.RS256, otherRSA, "rsa1", validJWTClaims(), map[string]interface{}{"sub": "123"}), 0, "invalid token signature"},
+		{"expired", signJWT(t, jose.HS256, []byte("secret"), "", expired, map[string]interface{}{"sub": "123"}), 0, "square/go-jose/jwt: validation failed, token is expired (exp)"},
+		{"no expiry", signJWT(t, jose.HS256, []byte("secret"), "", noExpiry, map[string]interface{}{"sub": "123"}), 0, "token has no expiry"},
+		{"unknown issuer", signJWT(t, jose.HS256, []byte("secret"), "", unknownIssuer, map[string]interface{}{"sub": "123"}), 0, `unknown issuer "https://evil.com"`},
+		{"wrong audience", signJWT(t, jose.HS256, []byte("secret"), "", wrongAudience, map[string]interface{}{"sub": "123"}), 0, "square/go-jose/jwt: validation failed, invalid audience claim (aud)"},
+		{"no user", signJWT(t, jose.HS256, []byte("secret"), "", validJWTClaims(), nil), 0, "token has no user ID"},
+		{"non-string subject", signJWT(t, jose.HS256, []byte("secret"), "", validJWTClaims(), map[string]interface{}{"sub": 123}), 0, "cannot unmarshal number"},
+		{"invalid user", signJWT(t, jose.HS256, []byte("secret"), "", validJWTClaims(), map[string]interface{}{"sub": "abc"}), 0, `strconv.Atoi: parsing "abc": invalid syntax`},
+		{"garbage", "abc.def", 0, "square/go-jose: compact JWS format must have three parts"},
+	}
+	for _, c := range cases {
+		id, err := r.UserID(c.token)
+		if c.err == "" {
+			require.NoError(t, err, c.name)
+			assert.Equal(t, c.id, id, c.name)
+		} else {
+			require.Error(t, err, c.name)
+			assert.Contains(t, err.Error(), c.err, c.name)
+		}
+	}
+}
+
+func TestJWTRetrieverCustomClaim(t *testing.T) {
+	r, err := NewJWTRetriever(JWTRetrieverOpts{Issuers: lbrytvIssuer("secret"), Audience: "lbrytv", UserClaim: "user_id"})
+	require.NoError(t, err)
+
+	id, err := r.UserID(signJWT(t, jose.HS512, []byte("secret"), "", validJWTClaims(), map[string]interface{}{"sub": "1", "user_id": 789}))
+	require.NoError(t, err)
+	assert.Equal(t, 789, id)
+}
+
+func TestJWTRetrieverRetrieve(t *testing.T) {
+	testFuncSetup()
+
+	r, err := NewJWTRetriever(JWTRetrieverOpts{Issuers: lbrytvIssuer("secret"), Audience: "lbrytv"})
+	require.NoError(t, err)
+	token := signJWT(t, jose.HS256, []byte("secret"), "", validJWTClaims(), map[string]interface{}{"sub": dummyUserID})
+
+	u, err := r.Retrieve(Query{Token: token})
+	require.NoError(t, err)
+	assert.Equal(t, dummyUserID, u.ID)
+	assert.Equal(t, lbrynet.MakeWalletID(dummyUserID), u.WalletID)
+
+	_, err = r.Retrieve(Query{Token: token + "x"})
+	assert.Error(t, err)
+}
+
+func TestJWTRetrieverPartnerScopes(t *testing.T) {
+	testFuncSetup()
+
+	jr, err := NewJWTRetriever(JWTRetrieverOpts{
+		Issuers: []JWTIssuer{
+			{Issuer: "https://lbry.tv", Secrets: []string{"secret"}},
+			{Issuer: "https://partner.example.com", Secrets: []string{"partnersecret"}, Scopes: Scopes{"resolve", "claim_search"}},
+		},
+		Audience: "lbrytv",
+	})
+	require.NoError(t, err)
+	partner := validJWTClaims()
+	partner.Issuer = "https://partner.example.com"
+	partnerToken := signJWT(t, jose.HS256, []byte("partnersecret"), "", partner, map[string]interface{}{"sub": dummyUserID})
+	token := signJWT(t, jose.HS256, []byte("secret"), "", validJWTClaims(), map[string]interface{}{"sub": dummyUserID})
+
+	u, scopes, err := jr.RetrieveScoped(Query{Token: partnerToken})
+	require.NoError(t, err)
+	assert.Equal(t, dummyUserID, u.ID)
+	assert.Equal(t, Scopes{"resolve", "claim_search"}, scopes)
+
+	// Partner tokens can't be used where scopes are not enforced
+	_, err = jr.Retrieve(Query{Token: partnerToken})
+	assert.EqualError(t, err, "tokens of this issuer are limited to specific methods")
+
+	authenticator := NewAuthenticator(&DummyRetriever{})
+	authenticator.SetBearerRetriever(jr)
+	r, _ := http.NewRequest("POST", "/api/v1/proxy", nil)
+	r.Header.Set("Authorization", BearerPrefix+partnerToken)
+	wid, scopes, err := authenticator.Authenticate(r)
+	require.NoError(t, err)
+	assert.Equal(t, lbrynet.MakeWalletID(dummyUserID), wid)
+	assert.True(t, scopes.Allows("resolve"))
+	assert.False(t, scopes.Allows("wallet_send"))
+	assert.False(t, scopes.Allows("stream_create"))
+
+	r.Header.Set("Authorization", BearerPrefix+token)
+	_, scopes, err = authenticator.Authenticate(r)
+	require.NoError(t, err)
+	assert.Nil(t, scopes)
+}
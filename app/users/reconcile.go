@@ -0,0 +1,337 @@
+package users
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/internal/monitor"
+	"github.com/lbryio/lbrytv/models"
+
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// Kinds of drift between the database and the SDK found by Reconcile.
+const (
+	// DriftMissingWalletID is a user without wallet ID, which happens to legacy users
+	// and to users whose wallet ID couldn't be saved after the wallet was created.
+	DriftMissingWalletID = "missing_wallet_id"
+	// DriftMissingWalletRecord is a user wallet ID without a record in wallets table.
+	DriftMissingWalletRecord = "missing_wallet_record"
+	// DriftMissingWalletFile is a wallet known to the database which is neither loaded in the SDK nor present on disk.
+	DriftMissingWalletFile = "missing_wallet_file"
+	// DriftOrphanWallet is an lbrytv wallet in the SDK which no user or guest owns.
+	DriftOrphanWallet = "orphan_wallet"
+	// DriftLegacyAccount is an account in the SDK default wallet left over from before users had their own wallets.
+	DriftLegacyAccount = "legacy_account"
+	// DriftOrphanAccount is a legacy account of a user who doesn't exist in the database.
+	DriftOrphanAccount = "orphan_account"
+	// DriftMigrationInProgress is a user whose legacy account migration was interrupted or failed, see WalletMigrator.
+	DriftMigrationInProgress = "migration_in_progress"
+)
+
+// ReconcileOpts contains settings for reconciling users with SDK wallets.
+type ReconcileOpts struct {
+	// WalletsDir is the SDK wallets directory. Wallets which are not loaded are only checked if it's set.
+	WalletsDir string
+	// Fix enables repairs which don't lose any data, other drifts are only reported.
+	Fix bool
+}
+
+// Drift is a single inconsistency between the database and the SDK.
+type Drift struct {
+	Kind      string `json:"kind"`
+	UserID    int    `json:"user_id,omitempty"`
+	WalletID  string `json:"wallet_id,omitempty"`
+	AccountID string `json:"account_id,omitempty"`
+	// Fixed is set when the drift has been repaired, Note explains what was done or why it wasn't.
+	Fixed bool   `json:"fixed"`
+	Note  string `json:"note,omitempty"`
+}
+
+// ReconcileReport summarizes the state of the database and the SDK.
+type ReconcileReport struct {
+	Users          int     `json:"users"`
+	LoadedWallets  int     `json:"loaded_wallets"`
+	WalletFiles    int     `json:"wallet_files"`
+	LegacyAccounts int     `json:"legacy_accounts"`
+	Drifts         []Drift `json:"drifts"`
+}
+
+type reconciler struct {
+	opts   ReconcileOpts
+	report *ReconcileReport
+	logger monitor.ModuleLogger
+
+	users          models.UserSlice
+	owned          map[string]bool
+	migrating      map[int]string
+	loaded         map[string]bool
+	files          map[string]bool
+	legacyAccounts map[int]string
+}
+
+// Reconcile compares users, their wallets and guests to wallets and legacy accounts in the SDK and reports every drift.
+// With opts.Fix set, users without wallet ID get a wallet like they would on their next login,
+// missing wallet records are added and orphan wallets are unloaded from the SDK. Their files are left in place.
+// Users whose wallet migration is unfinished are left for migrate_to_wallets to complete.
+func Reconcile(opts ReconcileOpts) (*ReconcileReport, error) {
+	r := &reconciler{
+		opts:           opts,
+		report:         &ReconcileReport{Drifts: []Drift{}},
+		logger:         monitor.NewModuleLogger("reconcile"),
+		owned:          map[string]bool{},
+		migrating:      map[int]string{},
+		loaded:         map[string]bool{},
+		files:          map[string]bool{},
+		legacyAccounts: map[int]string{},
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	r.checkUsers()
+	r.checkWallets()
+	r.checkAccounts()
+
+	sort.SliceStable(r.report.Drifts, func(i, j int) bool {
+		return r.report.Drifts[i].Kind < r.report.Drifts[j].Kind
+	})
+	return r.report, nil
+}
+
+func (r *reconciler) load() error {
+	var err error
+	r.users, err = models.Users(
+		qm.Select(models.UserColumns.ID, models.UserColumns.WalletID),
+		qm.OrderBy(models.UserColumns.ID),
+	).AllG()
+	if err != nil {
+		return err
+	}
+	r.report.Users = len(r.users)
+
+	wallets, err := models.Wallets(qm.Select(models.WalletColumns.WalletID)).AllG()
+	if err != nil {
+		return err
+	}
+	for _, w := range wallets {
+		r.owned[w.WalletID] = true
+	}
+	migrations, err := models.WalletMigrations(
+		qm.Select(models.WalletMigrationColumns.UserID, models.WalletMigrationColumns.WalletID),
+		models.WalletMigrationWhere.Status.NEQ(MigrationDone),
+	).AllG()
+	if err != nil {
+		return err
+	}
+	for _, m := range migrations {
+		r.migrating[m.UserID] = m.WalletID
+	}
+	// Wallets of merged guests are removed from the SDK
+	guests, err := models.Guests(qm.Select(models.GuestColumns.ID), models.GuestWhere.MergedAt.IsNull()).AllG()
+	if err != nil {
+		return err
+	}
+	for _, g := range guests {
+		r.owned[lbrynet.MakeGuestWalletID(g.ID)] = true
+	}
+
+	sdkWallets, err := lbrynet.Client.WalletList("")
+	if err != nil {
+		return fmt.Errorf("cannot list SDK wallets: %v", err)
+	}
+	for _, w := range *sdkWallets {
+		if lbrynet.IsLbrytvWalletID(w.ID) {
+			r.loaded[w.ID] = true
+		}
+	}
+	r.report.LoadedWallets = len(r.loaded)
+
+	if r.opts.WalletsDir != "" {
+		entries, err := ioutil.ReadDir(r.opts.WalletsDir)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if !e.IsDir() && lbrynet.IsLbrytvWalletID(e.Name()) {
+				r.files[e.Name()] = true
+			}
+		}
+		r.report.WalletFiles = len(r.files)
+	}
+
+	accounts, err := lbrynet.Client.AccountList()
+	if err != nil {
+		return fmt.Errorf("cannot list SDK accounts: %v", err)
+	}
+	for _, a := range accounts.LBCMainnet {
+		if uid, err := lbrynet.ParseAccountName(a.Name); err == nil {
+			r.legacyAccounts[uid] = a.ID
+		}
+	}
+	r.report.LegacyAccounts = len(r.legacyAccounts)
+	return nil
+}
+
+func (r *reconciler) checkUsers() {
+	for _, u := range r.users {
+		if wid, ok := r.migrating[u.ID]; ok {
+			// Wallet is only saved for the user once their account is moved to it
+			r.add(Drift{Kind: DriftMigrationInProgress, UserID: u.ID, WalletID: wid, Note: "run migrate_to_wallets to finish it"})
+			continue
+		}
+		if u.WalletID == "" {
+			r.fixMissingWalletID(u)
+			continue
+		}
+		if !r.owned[u.WalletID] {
+			d := Drift{Kind: DriftMissingWalletRecord, UserID: u.ID, WalletID: u.WalletID}
+			if r.opts.Fix {
+				if err := saveDefaultWallet(u); err != nil {
+					d.Note = fmt.Sprintf("cannot add wallet record: %v", err)
+				} else {
+					d.Fixed, d.Note = true, "wallet record added"
+				}
+			}
+			r.add(d)
+			r.owned[u.WalletID] = true
+		}
+	}
+}
+
+func (r *reconciler) fixMissingWalletID(u *models.User) {
+	d := Drift{Kind: DriftMissingWalletID, UserID: u.ID}
+	if accID, ok := r.legacyAccounts[u.ID]; ok {
+		// Creating a wallet would leave the user with an empty one, the account has to be migrated
+		d.AccountID = accID
+		d.Note = "user has a legacy account, run migrate_to_wallets"
+		r.add(d)
+		return
+	}
+	if !r.opts.Fix {
+		r.add(d)
+		return
+	}
+
+	// Migration could have started since the database was read
+	if migrating, err := hasUnfinishedMigration(u.ID); err != nil {
+		d.Note = fmt.Sprintf("cannot check wallet migration: %v", err)
+		r.add(d)
+		return
+	} else if migrating {
+		d.Note = "wallet migration has started, run migrate_to_wallets to finish it"
+		r.add(d)
+		return
+	}
+
+	s := NewWalletService()
+	wid, err := s.createWallet(u)
+	if err == nil {
+		err = s.saveWalletID(u, wid)
+	}
+	d.WalletID = wid
+	if err != nil {
+		d.Note = fmt.Sprintf("cannot create wallet: %v", err)
+	} else {
+		d.Fixed, d.Note = true, "wallet created"
+		r.owned[wid] = true
+		r.loaded[wid] = true
+	}
+	r.add(d)
+}
+
+func (r *reconciler) checkWallets() {
+	if r.opts.WalletsDir != "" {
+		owned := make([]string, 0, len(r.owned))
+		for wid := range r.owned {
+			owned = append(owned, wid)
+		}
+		sort.Strings(owned)
+		for _, wid := range owned {
+			if !r.loaded[wid] && !r.files[wid] {
+				r.add(Drift{Kind: DriftMissingWalletFile, WalletID: wid, Note: "user won't be able to load the wallet"})
+			}
+		}
+	}
+
+	sdk := map[string]bool{}
+	for wid := range r.loaded {
+		sdk[wid] = true
+	}
+	for wid := range r.files {
+		sdk[wid] = true
+	}
+	migrating := map[string]bool{}
+	for _, wid := range r.migrating {
+		migrating[wid] = true
+	}
+	orphans := []string{}
+	for wid := range sdk {
+		if !r.owned[wid] && !migrating[wid] {
+			orphans = append(orphans, wid)
+		}
+	}
+	sort.Strings(orphans)
+	for _, wid := range orphans {
+		d := Drift{Kind: DriftOrphanWallet, WalletID: wid}
+		if r.opts.Fix && r.loaded[wid] {
+			// Wallet could have been created for a user or guest since the database was read
+			if owned, err := isWalletOwned(wid); err != nil {
+				d.Note = fmt.Sprintf("cannot check wallet ownership: %v", err)
+			} else if owned {
+				d.Note = "wallet has been claimed since reconciliation started, left loaded"
+			} else if err := lbrynet.RemoveWalletByID(wid, 0); err != nil {
+				d.Note = fmt.Sprintf("cannot unload wallet: %v", err)
+			} else {
+				d.Fixed, d.Note = true, "wallet unloaded, its file is left in place"
+			}
+		}
+		r.add(d)
+	}
+}
+
+func (r *reconciler) checkAccounts() {
+	exists := map[int]bool{}
+	for _, u := range r.users {
+		exists[u.ID] = true
+	}
+	uids := make([]int, 0, len(r.legacyAccounts))
+	for uid := range r.legacyAccounts {
+		uids = append(uids, uid)
+	}
+	sort.Ints(uids)
+	for _, uid := range uids {
+		d := Drift{Kind: DriftLegacyAccount, UserID: uid, AccountID: r.legacyAccounts[uid]}
+		if !exists[uid] {
+			d.Kind = DriftOrphanAccount
+		}
+		r.add(d)
+	}
+}
+
+// isWalletOwned checks the database for a user, guest or unfinished migration SDK wallet wid belongs to.
+func isWalletOwned(wid string) (bool, error) {
+	if owned, err := models.Wallets(models.WalletWhere.WalletID.EQ(wid)).ExistsG(); err != nil || owned {
+		return owned, err
+	}
+	if owned, err := models.Users(models.UserWhere.WalletID.EQ(wid)).ExistsG(); err != nil || owned {
+		return owned, err
+	}
+	if gid, err := lbrynet.ParseGuestWalletID(wid); err == nil {
+		return models.Guests(models.GuestWhere.ID.EQ(gid), models.GuestWhere.MergedAt.IsNull()).ExistsG()
+	}
+	return models.WalletMigrations(
+		models.WalletMigrationWhere.WalletID.EQ(wid),
+		models.WalletMigrationWhere.Status.NEQ(MigrationDone),
+	).ExistsG()
+}
+
+func (r *reconciler) add(d Drift) {
+	if d.Fixed {
+		r.logger.LogF(monitor.F{"id": d.UserID, "wallet_id": d.WalletID}).Infof("%v: %v", d.Kind, d.Note)
+	} else if r.opts.Fix && d.Note != "" {
+		r.logger.LogF(monitor.F{"id": d.UserID, "wallet_id": d.WalletID}).Warnf("%v: %v", d.Kind, d.Note)
+	}
+	r.report.Drifts = append(r.report.Drifts, d)
+}
@@ -0,0 +1,133 @@
+package users
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+)
+
+func TestReconcile(t *testing.T) {
+	testFuncSetup()
+	sdk.Reset()
+
+	dir, err := ioutil.TempDir("", "wallets")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Consistent user
+	ok := &models.User{ID: dummyUserID}
+	require.NoError(t, ok.InsertG(boil.Infer()))
+	require.NoError(t, NewWalletService().saveWalletID(ok, lbrynet.MakeWalletID(ok.ID)))
+	require.NoError(t, lbrynet.InitializeWalletByID(ok.WalletID, ok.ID))
+	// User without wallet ID
+	noWallet := &models.User{ID: dummyUserID + 1}
+	require.NoError(t, noWallet.InsertG(boil.Infer()))
+	// Legacy user with an account in the default wallet
+	legacy := &models.User{ID: dummyUserID + 2}
+	require.NoError(t, legacy.InsertG(boil.Infer()))
+	legacyAcc, err := lbrynet.CreateAccount(legacy.ID)
+	require.NoError(t, err)
+	// User without a wallet record
+	noRecord := &models.User{ID: dummyUserID + 3, WalletID: lbrynet.MakeWalletID(dummyUserID + 3)}
+	require.NoError(t, noRecord.InsertG(boil.Infer()))
+	require.NoError(t, lbrynet.InitializeWalletByID(noRecord.WalletID, noRecord.ID))
+	// Wallet of a user who doesn't exist
+	orphan := lbrynet.MakeWalletID(dummyUserID + 10)
+	require.NoError(t, lbrynet.InitializeWalletByID(orphan, dummyUserID+10))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, ok.WalletID), []byte("{}"), 0600))
+
+	report, err := Reconcile(ReconcileOpts{WalletsDir: dir})
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.Users)
+	assert.Equal(t, 1, report.LegacyAccounts)
+	assert.Equal(t, []Drift{
+		{Kind: DriftLegacyAccount, UserID: legacy.ID, AccountID: legacyAcc.ID},
+		{Kind: DriftMissingWalletID, UserID: noWallet.ID},
+		{Kind: DriftMissingWalletID, UserID: legacy.ID, AccountID: legacyAcc.ID, Note: "user has a legacy account, run migrate_to_wallets"},
+		{Kind: DriftMissingWalletRecord, UserID: noRecord.ID, WalletID: noRecord.WalletID},
+		{Kind: DriftOrphanWallet, WalletID: orphan},
+	}, report.Drifts)
+
+	report, err = Reconcile(ReconcileOpts{Fix: true})
+	require.NoError(t, err)
+	for _, d := range report.Drifts {
+		switch d.Kind {
+		case DriftMissingWalletID:
+			assert.Equal(t, d.UserID == noWallet.ID, d.Fixed, d.UserID)
+		case DriftMissingWalletRecord, DriftOrphanWallet:
+			assert.True(t, d.Fixed, d.Kind)
+		default:
+			assert.False(t, d.Fixed, d.Kind)
+		}
+	}
+
+	require.NoError(t, noWallet.ReloadG())
+	assert.Equal(t, lbrynet.MakeWalletID(noWallet.ID), noWallet.WalletID)
+	var n struct {
+		Count int `boil:"count"`
+	}
+	require.NoError(t, queries.Raw(`SELECT count(*) AS count FROM wallets WHERE user_id = $1`, noRecord.ID).Bind(nil, boil.GetDB(), &n))
+	assert.Equal(t, 1, n.Count)
+
+	report, err = Reconcile(ReconcileOpts{})
+	require.NoError(t, err)
+	for _, d := range report.Drifts {
+		assert.Contains(t, []string{DriftLegacyAccount, DriftMissingWalletID}, d.Kind)
+	}
+}
+
+func TestReconcileMigrationInProgress(t *testing.T) {
+	testFuncSetup()
+	sdk.Reset()
+
+	// Account was removed from the default wallet but not yet added to the user's one
+	u := &models.User{ID: dummyUserID}
+	require.NoError(t, u.InsertG(boil.Infer()))
+	state := &models.WalletMigration{
+		UserID: u.ID, Status: MigrationAccountRemoved, AccountID: "abc", WalletID: lbrynet.MakeWalletID(u.ID),
+	}
+	require.NoError(t, state.InsertG(boil.Infer()))
+	require.NoError(t, lbrynet.InitializeWalletByID(state.WalletID, u.ID))
+
+	report, err := Reconcile(ReconcileOpts{Fix: true})
+	require.NoError(t, err)
+	assert.Equal(t, []Drift{
+		{Kind: DriftMigrationInProgress, UserID: u.ID, WalletID: state.WalletID, Note: "run migrate_to_wallets to finish it"},
+	}, report.Drifts)
+
+	require.NoError(t, u.ReloadG())
+	assert.Equal(t, "", u.WalletID)
+	assert.Len(t, sdk.Requests("wallet_remove"), 0)
+}
+
+func TestIsWalletOwned(t *testing.T) {
+	testFuncSetup()
+
+	u := &models.User{ID: dummyUserID}
+	require.NoError(t, u.InsertG(boil.Infer()))
+	wallet := &models.Wallet{UserID: u.ID, WalletID: lbrynet.MakeAdditionalWalletID(u.ID, 1), Name: "Second"}
+	require.NoError(t, wallet.InsertG(boil.Infer()))
+	g := &models.Guest{}
+	require.NoError(t, g.InsertG(boil.Infer()))
+
+	for wid, owned := range map[string]bool{
+		wallet.WalletID:                         true,
+		lbrynet.MakeGuestWalletID(g.ID):         true,
+		lbrynet.MakeGuestWalletID(g.ID + 1):     false,
+		lbrynet.MakeWalletID(u.ID):              false,
+		lbrynet.MakeAdditionalWalletID(u.ID, 2): false,
+	} {
+		ok, err := isWalletOwned(wid)
+		require.NoError(t, err)
+		assert.Equal(t, owned, ok, wid)
+	}
+}
@@ -1,24 +1,99 @@
 package users
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
 	"github.com/lbryio/lbry.go/v2/extras/lbryinc"
 	"github.com/lbryio/lbrytv/config"
+	"github.com/lbryio/lbrytv/internal/monitor"
 )
 
+const remoteTimeout = 5 * time.Second
+
 // RemoteUser encapsulates internal-apis user data
 type RemoteUser struct {
 	ID    int
 	Email string
+	// Fallback is set for users authenticated from stored tokens while internal-apis was unavailable
+	Fallback bool
+	// verified is set when the token has just been checked with internal-apis rather than taken from cache
+	verified bool
 }
 
-func getRemoteUser(token string, remoteIP string) (*RemoteUser, error) {
-	u := &RemoteUser{}
-	c := lbryinc.NewClient(token, &lbryinc.ClientOpts{
-		ServerAddress: config.GetInternalAPIHost(), RemoteIP: remoteIP})
+// RemoteAuthError is returned when internal-apis refuses to authenticate a token (responding with 401 or 403),
+// as opposed to errors communicating with it or internal-apis failing to process the request.
+type RemoteAuthError struct {
+	message string
+}
+
+func (e RemoteAuthError) Error() string {
+	return e.message
+}
+
+// callRemoteUserMe calls internal-apis user/me method. lbryinc.Client is not used here
+// as it doesn't allow setting custom headers, which are needed for forwarding request ID.
+func callRemoteUserMe(q Query) (lbryinc.ResponseData, error) {
+	var ar lbryinc.APIResponse
+
+	form := url.Values{"auth_token": []string{q.Token}}
+	req, err := http.NewRequest(
+		http.MethodPost,
+		fmt.Sprintf("%v/user/me", config.GetInternalAPIHost()),
+		bytes.NewBufferString(form.Encode()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if q.MetaRemoteIP != "" {
+		req.Header.Set("X-Forwarded-For", q.MetaRemoteIP)
+	}
+	if q.RequestID != "" {
+		req.Header.Set(monitor.RequestIDHeader, q.RequestID)
+	}
 
-	r, err := c.UserMe()
+	client := &http.Client{Timeout: remoteTimeout}
+	r, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &ar); err != nil {
+		return nil, err
+	}
+	if !ar.Success {
+		message := "internal-apis responded with an unknown error"
+		if ar.Error != nil {
+			message = *ar.Error
+		}
+		// Only these mean the token itself is bad, internal-apis failing shouldn't get it forgotten
+		if r.StatusCode == http.StatusUnauthorized || r.StatusCode == http.StatusForbidden {
+			return nil, RemoteAuthError{message}
+		}
+		return nil, errors.New(message)
+	}
+	if ar.Data == nil {
+		return nil, errors.New("internal-apis responded with empty data")
+	}
+	return *ar.Data, nil
+}
+
+func getRemoteUser(q Query) (*RemoteUser, error) {
+	u := &RemoteUser{}
+	r, err := callRemoteUserMe(q)
 	if err != nil {
-		// Conn.Logger.LogF(monitor.F{monitor.TokenF: token}).Error("internal-api responded with an error: ", err)
 		// No user found in internal-apis database, give up at this point
 		return nil, err
 	}
@@ -0,0 +1,70 @@
+package users
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lbryio/lbrytv/config"
+	"github.com/lbryio/lbrytv/internal/monitor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRemoteUser(t *testing.T) {
+	var received *http.Request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		received = r
+		w.Write([]byte(`{"success": true, "error": null, "data": {"id": 751365, "primary_email": "user@domain.com"}}`))
+	}))
+	defer ts.Close()
+	config.Override("InternalAPIHost", ts.URL)
+	defer config.RestoreOverridden()
+
+	u, err := getRemoteUser(Query{Token: "abc", MetaRemoteIP: "8.8.8.8", RequestID: "req-1"})
+	require.NoError(t, err)
+	assert.Equal(t, &RemoteUser{ID: 751365, Email: "user@domain.com"}, u)
+
+	assert.Equal(t, "/user/me", received.URL.Path)
+	assert.Equal(t, "abc", received.PostForm.Get("auth_token"))
+	assert.Equal(t, "8.8.8.8", received.Header.Get("X-Forwarded-For"))
+	assert.Equal(t, "req-1", received.Header.Get(monitor.RequestIDHeader))
+}
+
+func TestGetRemoteUserError(t *testing.T) {
+	ts := launchRejectingAPIServer()
+	defer ts.Close()
+	config.Override("InternalAPIHost", ts.URL)
+	defer config.RestoreOverridden()
+
+	u, err := getRemoteUser(Query{Token: "abc"})
+	assert.Nil(t, u)
+	assert.EqualError(t, err, "could not authenticate user")
+	assert.IsType(t, RemoteAuthError{}, err)
+}
+
+func TestGetRemoteUserServerError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"success": false, "error": "database is unavailable", "data": null}`))
+	}))
+	defer ts.Close()
+	config.Override("InternalAPIHost", ts.URL)
+	defer config.RestoreOverridden()
+
+	AuthCache = NewTokenCache(TokenCacheOpts{TTL: time.Minute, NegativeTTL: time.Minute})
+	defer func() { AuthCache = nil }()
+
+	u, err := getCachedRemoteUser(Query{Token: "abc"})
+	assert.Nil(t, u)
+	assert.EqualError(t, err, "database is unavailable")
+	_, rejected := err.(RemoteAuthError)
+	assert.False(t, rejected)
+
+	// Token is not known to be bad so it must not be cached as rejected
+	_, ok, _ := AuthCache.Get("abc")
+	assert.False(t, ok)
+}
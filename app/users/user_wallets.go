@@ -0,0 +1,241 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/internal/monitor"
+	"github.com/lbryio/lbrytv/models"
+
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// WalletHeader is the name of HTTP header containing SDK wallet ID of the user's wallet the request should use.
+// User's default wallet is used if it's not supplied.
+const WalletHeader = "X-Lbry-Wallet"
+
+// DefaultWalletName is the name of wallet created for every user.
+const DefaultWalletName = "Default"
+
+// MaxWalletsPerUser is how many wallets a single user can have, including the default one.
+const MaxWalletsPerUser = 10
+
+// ErrWalletNotFound is returned for wallets which don't exist or belong to another user.
+var ErrWalletNotFound = errors.New("wallet not found")
+
+// ErrScopedWalletSelection is returned when a wallet is selected in WalletHeader along with credentials
+// limited to specific methods, such as API keys, which can only use the default wallet.
+var ErrScopedWalletSelection = errors.New("wallet cannot be selected with scoped credentials")
+
+// Wallets manages additional wallets of users.
+var Wallets = NewUserWallets()
+
+// WalletSelector is an interface for checking that user can use a wallet other than their default one.
+type WalletSelector interface {
+	SelectWallet(u *models.User, walletID string) error
+}
+
+// UserWallets manages wallets users can have in addition to their default wallet.
+// Additional wallets are loaded into the SDK on their first use. When wallet tracker is enabled
+// they are unloaded when idle just like default wallets, see lbrynet.WalletTracker.
+type UserWallets struct {
+	logger monitor.ModuleLogger
+
+	mu     sync.Mutex
+	loaded map[string]bool
+}
+
+// NewUserWallets returns UserWallets instance.
+func NewUserWallets() *UserWallets {
+	return &UserWallets{logger: monitor.NewModuleLogger("user_wallets"), loaded: map[string]bool{}}
+}
+
+// Create makes a new SDK wallet for the user. Wallet count is checked and the record is inserted in one transaction
+// holding a per-user lock, so concurrent calls can't exceed MaxWalletsPerUser together.
+func (w *UserWallets) Create(u *models.User, name string) (*models.Wallet, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("wallet name is required")
+	}
+
+	tx, err := boil.BeginTx(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	if _, err := queries.Raw("SELECT pg_advisory_xact_lock($1)", walletsLockKey(u.ID)).Exec(tx); err != nil {
+		return nil, err
+	}
+	n, err := models.Wallets(models.WalletWhere.UserID.EQ(u.ID)).Count(tx)
+	if err != nil {
+		return nil, err
+	}
+	if n >= MaxWalletsPerUser {
+		return nil, fmt.Errorf("cannot have more than %v wallets", MaxWalletsPerUser)
+	}
+
+	// Wallet record ID is a part of SDK wallet ID so it needs to be known before insertion
+	var seq struct {
+		ID int `boil:"id"`
+	}
+	if err := queries.Raw(`SELECT nextval('wallets_id_seq') AS id`).Bind(nil, tx, &seq); err != nil {
+		return nil, err
+	}
+	wallet := &models.Wallet{
+		ID:       seq.ID,
+		UserID:   u.ID,
+		WalletID: lbrynet.MakeAdditionalWalletID(u.ID, seq.ID),
+		Name:     name,
+	}
+	// Record is inserted first so there's nothing to clean up in the SDK if that fails
+	if err := wallet.Insert(tx, boil.Infer()); err != nil {
+		return nil, err
+	}
+	if err := lbrynet.InitializeWalletByID(wallet.WalletID, u.ID); err != nil {
+		return nil, err
+	}
+	log := w.logger.LogF(monitor.F{"id": u.ID, "wallet_id": wallet.WalletID})
+	if err := tx.Commit(); err != nil {
+		if rErr := lbrynet.RemoveWalletByID(wallet.WalletID, u.ID); rErr != nil {
+			log.Errorf("cannot remove wallet which failed to be recorded: %v", rErr)
+		}
+		return nil, err
+	}
+	w.markLoaded(wallet.WalletID)
+	log.Info("wallet created")
+	return wallet, nil
+}
+
+// List returns all wallets of the user, the default one first.
+func (w *UserWallets) List(u *models.User) (models.WalletSlice, error) {
+	return models.Wallets(
+		models.WalletWhere.UserID.EQ(u.ID),
+		qm.OrderBy(models.WalletColumns.IsDefault+" DESC, "+models.WalletColumns.ID),
+	).AllG()
+}
+
+// Rename changes the name of user's wallet with a given SDK wallet ID.
+func (w *UserWallets) Rename(u *models.User, walletID, name string) (*models.Wallet, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("wallet name is required")
+	}
+	wallet, err := w.find(u, walletID)
+	if err != nil {
+		return nil, err
+	}
+	wallet.Name = name
+	_, err = wallet.UpdateG(boil.Whitelist(models.WalletColumns.Name, models.WalletColumns.UpdatedAt))
+	return wallet, err
+}
+
+// SelectWallet checks that the wallet with a given SDK wallet ID belongs to the user and loads it if needed.
+func (w *UserWallets) SelectWallet(u *models.User, walletID string) error {
+	if walletID == u.WalletID {
+		return nil
+	}
+	if _, err := w.find(u, walletID); err != nil {
+		return err
+	}
+	if lbrynet.Wallets != nil {
+		return lbrynet.Wallets.Touch(walletID)
+	}
+	if w.isLoaded(walletID) {
+		return nil
+	}
+	if err := lbrynet.InitializeWalletByID(walletID, u.ID); err != nil {
+		return err
+	}
+	w.markLoaded(walletID)
+	return nil
+}
+
+func (w *UserWallets) find(u *models.User, walletID string) (*models.Wallet, error) {
+	wallet, err := models.Wallets(
+		models.WalletWhere.UserID.EQ(u.ID),
+		models.WalletWhere.WalletID.EQ(walletID),
+	).OneG()
+	if err == sql.ErrNoRows {
+		return nil, ErrWalletNotFound
+	}
+	return wallet, err
+}
+
+// ResetLoaded forgets which additional wallets were loaded into the SDK so they are loaded again on their next use.
+// It should be called when the SDK reports a wallet is not loaded, which happens after it restarts.
+func (w *UserWallets) ResetLoaded() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.loaded = map[string]bool{}
+}
+
+func (w *UserWallets) isLoaded(walletID string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.loaded[walletID]
+}
+
+func (w *UserWallets) markLoaded(walletID string) {
+	if lbrynet.Wallets != nil {
+		lbrynet.Wallets.MarkLoaded(walletID)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.loaded[walletID] = true
+}
+
+func (w *UserWallets) forget(walletID string) {
+	if lbrynet.Wallets != nil {
+		lbrynet.Wallets.Forget(walletID)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.loaded, walletID)
+}
+
+// ResetLoadedWallets forgets which user and guest wallets were loaded into the SDK, see UserWallets.ResetLoaded.
+func ResetLoadedWallets() {
+	Wallets.ResetLoaded()
+	if Guests != nil {
+		Guests.ResetLoaded()
+	}
+}
+
+// WalletOwnerID returns ID of the user SDK wallet walletID belongs to, either their default or additional one.
+// Default wallets of users not recorded in wallets table yet are recognized by their ID.
+func WalletOwnerID(walletID string) (int, error) {
+	wallet, err := models.Wallets(models.WalletWhere.WalletID.EQ(walletID)).OneG()
+	if err == sql.ErrNoRows {
+		if uid, err := lbrynet.ParseWalletID(walletID); err == nil {
+			return uid, nil
+		}
+		return 0, ErrWalletNotFound
+	} else if err != nil {
+		return 0, err
+	}
+	return wallet.UserID, nil
+}
+
+// walletsLockKey maps user ID to a Postgres advisory lock key taken while creating user's wallets.
+func walletsLockKey(uid int) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(fmt.Sprintf("wallets:%v", uid)))
+	return int64(h.Sum64())
+}
+
+// saveDefaultWallet records user's default wallet in wallets table.
+func saveDefaultWallet(u *models.User) error {
+	_, err := queries.Raw(
+		`INSERT INTO wallets (user_id, wallet_id, name, is_default) VALUES ($1, $2, $3, true) ON CONFLICT (wallet_id) DO NOTHING`,
+		u.ID, u.WalletID, DefaultWalletName,
+	).Exec(boil.GetDB())
+	return err
+}
@@ -0,0 +1,115 @@
+package users
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/boil"
+)
+
+func TestUserWallets(t *testing.T) {
+	testFuncSetup()
+
+	u := &models.User{ID: dummyUserID}
+	require.NoError(t, u.InsertG(boil.Infer()))
+	s := NewWalletService()
+	require.NoError(t, s.saveWalletID(u, lbrynet.MakeWalletID(dummyUserID)))
+	other := &models.User{ID: dummyUserID + 1}
+	require.NoError(t, other.InsertG(boil.Infer()))
+
+	w := NewUserWallets()
+	_, err := w.Create(u, " ")
+	assert.EqualError(t, err, "wallet name is required")
+
+	second, err := w.Create(u, "Channel business")
+	require.NoError(t, err)
+	assert.Equal(t, lbrynet.MakeAdditionalWalletID(u.ID, second.ID), second.WalletID)
+	assert.False(t, second.IsDefault)
+
+	wallets, err := w.List(u)
+	require.NoError(t, err)
+	require.Len(t, wallets, 2)
+	assert.Equal(t, u.WalletID, wallets[0].WalletID)
+	assert.True(t, wallets[0].IsDefault)
+	assert.Equal(t, DefaultWalletName, wallets[0].Name)
+	assert.Equal(t, second.WalletID, wallets[1].WalletID)
+
+	renamed, err := w.Rename(u, second.WalletID, "Personal")
+	require.NoError(t, err)
+	assert.Equal(t, "Personal", renamed.Name)
+	_, err = w.Rename(other, second.WalletID, "Mine now")
+	assert.Equal(t, ErrWalletNotFound, err)
+
+	assert.NoError(t, w.SelectWallet(u, u.WalletID))
+	assert.NoError(t, w.SelectWallet(u, second.WalletID))
+	assert.Equal(t, ErrWalletNotFound, w.SelectWallet(other, second.WalletID))
+	assert.Equal(t, ErrWalletNotFound, w.SelectWallet(u, "lbrytv-id.1.wallet"))
+
+	uid, err := WalletOwnerID(second.WalletID)
+	require.NoError(t, err)
+	assert.Equal(t, u.ID, uid)
+	uid, err = WalletOwnerID(u.WalletID)
+	require.NoError(t, err)
+	assert.Equal(t, u.ID, uid)
+	// Default wallets are recognized before they are recorded
+	uid, err = WalletOwnerID(lbrynet.MakeWalletID(other.ID))
+	require.NoError(t, err)
+	assert.Equal(t, other.ID, uid)
+	_, err = WalletOwnerID(lbrynet.MakeAdditionalWalletID(other.ID, second.ID+1))
+	assert.Equal(t, ErrWalletNotFound, err)
+}
+
+func TestUserWalletsCreateLimit(t *testing.T) {
+	testFuncSetup()
+	sdk.Reset()
+
+	u := &models.User{ID: dummyUserID}
+	require.NoError(t, u.InsertG(boil.Infer()))
+	s := NewWalletService()
+	require.NoError(t, s.saveWalletID(u, lbrynet.MakeWalletID(dummyUserID)))
+	w := NewUserWallets()
+
+	// Wallet is not recorded if the SDK fails to create it
+	sdk.SetError("wallet_create", -32500, "oops")
+	_, err := w.Create(u, "Broken")
+	assert.Error(t, err)
+	sdk.ClearResponse("wallet_create")
+	wallets, err := w.List(u)
+	require.NoError(t, err)
+	assert.Len(t, wallets, 1)
+
+	var (
+		wg      sync.WaitGroup
+		created int32
+	)
+	for i := 0; i < MaxWalletsPerUser+5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := w.Create(u, fmt.Sprintf("Wallet %v", i)); err == nil {
+				atomic.AddInt32(&created, 1)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.EqualValues(t, MaxWalletsPerUser-1, created)
+	n, err := models.Wallets(models.WalletWhere.UserID.EQ(u.ID)).CountG()
+	require.NoError(t, err)
+	assert.EqualValues(t, MaxWalletsPerUser, n)
+}
+
+func TestResetLoadedWallets(t *testing.T) {
+	wid := lbrynet.MakeAdditionalWalletID(dummyUserID, 2)
+	Wallets.markLoaded(wid)
+	require.True(t, Wallets.isLoaded(wid))
+
+	ResetLoadedWallets()
+	assert.False(t, Wallets.isLoaded(wid))
+}
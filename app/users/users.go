@@ -2,6 +2,7 @@ package users
 
 import (
 	"database/sql"
+	"strconv"
 
 	"github.com/lbryio/lbrytv/internal/lbrynet"
 	"github.com/lbryio/lbrytv/internal/monitor"
@@ -38,6 +39,11 @@ type Retriever interface {
 type Query struct {
 	Token        string
 	MetaRemoteIP string
+	// PeerIP is the address of the client connection, see GetPeerIPAddress. Unlike MetaRemoteIP
+	// it is not taken from headers supplied by the client.
+	PeerIP string
+	// RequestID of the client request, it is attached to log entries and forwarded to internal-apis
+	RequestID string
 }
 
 // NewUserService returns UserService instance for retrieving or creating user records and accounts.
@@ -83,16 +89,16 @@ func (s *UserService) createDBUser(id int) (*models.User, error) {
 // Deprecated: WalletService.Retrieve should be used instead
 func (s *UserService) Retrieve(q Query) (*models.User, error) {
 	token := q.Token
-	log := s.logger.LogF(monitor.F{"token": token})
+	log := s.logger.LogF(monitor.F{"token": token, monitor.RequestIDF: q.RequestID})
 	var localUser *models.User
 
-	remoteUser, err := getRemoteUser(token, q.MetaRemoteIP)
+	remoteUser, err := getRemoteUser(q)
 	if err != nil {
 		log.Info("couldn't authenticate user with internal-apis")
 		return nil, errors.Errorf("cannot authenticate user with internal-apis: %v", err)
 	}
 
-	log = s.logger.LogF(monitor.F{"token": token, "id": remoteUser.ID, "email": remoteUser.Email})
+	log = s.logger.LogF(monitor.F{"token": token, "id": remoteUser.ID, "email": remoteUser.Email, monitor.RequestIDF: q.RequestID})
 
 	if remoteUser.Email == "" {
 		log.Info("cannot authenticate internal-api user: email not confirmed")
@@ -119,7 +125,7 @@ func (s *UserService) Retrieve(q Query) (*models.User, error) {
 		log.Warnf("user ID=%v has empty fields in the database, retrieving from the SDK", remoteUser.ID)
 		acc, err := lbrynet.GetAccount(remoteUser.ID)
 		if err != nil {
-			monitor.CaptureException(err, map[string]string{"internal-api-id": string(remoteUser.ID)})
+			monitor.CaptureException(err, map[string]string{"internal-api-id": strconv.Itoa(remoteUser.ID)})
 			log.Errorf("could not retrieve user ID=%v from the SDK: %v", remoteUser.ID, err)
 			return nil, err
 		}
@@ -9,12 +9,15 @@ import (
 
 	"github.com/lbryio/lbrytv/config"
 	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/internal/lbrynettest"
 	"github.com/lbryio/lbrytv/internal/storage"
 )
 
 const dummyUserID = 751365
 const dummyServerURL = "http://127.0.0.1:59988"
 
+var sdk *lbrynettest.Server
+
 func launchDummyAPIServer(response []byte) *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
@@ -23,6 +26,15 @@ func launchDummyAPIServer(response []byte) *httptest.Server {
 	}))
 }
 
+// launchRejectingAPIServer responds the way internal-apis does to invalid tokens.
+func launchRejectingAPIServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"success": false, "error": "could not authenticate user", "data": null}`))
+	}))
+}
+
 func launchAuthenticatingAPIServer(userID int) *httptest.Server {
 	return launchDummyAPIServer([]byte(fmt.Sprintf(`{
 		"success": true,
@@ -59,14 +71,18 @@ func TestMain(m *testing.M) {
 	}
 	dbConn, connCleanup := storage.CreateTestConn(params)
 	dbConn.SetDefaultConnection()
-	defer connCleanup()
-	defer lbrynet.RemoveAccount(dummyUserID)
+	var sdkCleanup func()
+	sdk, sdkCleanup = lbrynettest.UseTestServer(&lbrynet.Client)
 
 	code := m.Run()
 
+	// Deferred calls don't run after os.Exit
+	lbrynet.RemoveAccount(dummyUserID)
+	connCleanup()
+	sdkCleanup()
 	os.Exit(code)
 }
 
 func testFuncSetup() {
-	storage.Conn.Truncate([]string{"users"})
+	storage.Conn.Truncate([]string{"users", "guests"})
 }
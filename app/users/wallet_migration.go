@@ -0,0 +1,368 @@
+package users
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/internal/monitor"
+	"github.com/lbryio/lbrytv/models"
+
+	ljsonrpc "github.com/lbryio/lbry.go/v2/extras/jsonrpc"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// Steps of moving a legacy account to user's own wallet, in order. Migration state records the last completed one.
+const (
+	MigrationPending          = "pending"
+	MigrationWalletCreated    = "wallet_created"
+	MigrationChannelsImported = "channels_imported"
+	MigrationSeedEscrowed     = "seed_escrowed"
+	MigrationAccountRemoved   = "account_removed"
+	MigrationDone             = "done"
+)
+
+const channelListPageSize = 100
+
+// WalletMigratorOpts contains legacy account migration settings.
+type WalletMigratorOpts struct {
+	// EscrowKey is a 32 bytes key for encrypting account seeds, see ParseEscrowKey. Not needed for a dry run.
+	EscrowKey []byte
+	// Workers is how many users are migrated concurrently.
+	Workers int
+	// Limit is how many users are migrated at most, all of them if it's 0.
+	Limit int
+	// DryRun only reports what would be migrated without changing anything.
+	DryRun bool
+}
+
+// WalletMigrationReport summarizes a migration run.
+type WalletMigrationReport struct {
+	Selected int `json:"selected"`
+	Migrated int `json:"migrated"`
+	Failed   int `json:"failed"`
+}
+
+// WalletMigrator moves legacy SDK accounts from the default wallet to wallets of their users.
+// Progress of every user is stored in wallet_migrations table, so a migration that was interrupted
+// or failed is picked up where it stopped by the next run. Account seed is stored encrypted
+// before the account is removed from the SDK, so it can be restored with EscrowedSeed if anything goes wrong.
+type WalletMigrator struct {
+	opts   WalletMigratorOpts
+	aead   cipher.AEAD
+	logger monitor.ModuleLogger
+}
+
+// ParseEscrowKey decodes hex-encoded escrow key.
+func ParseEscrowKey(hexKey string) ([]byte, error) {
+	key, err := hex.DecodeString(hexKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode escrow key: %v", err)
+	}
+	if len(key) != 32 {
+		return nil, errors.New("escrow key must be 32 bytes long")
+	}
+	return key, nil
+}
+
+// NewWalletMigrator returns WalletMigrator instance.
+func NewWalletMigrator(opts WalletMigratorOpts) (*WalletMigrator, error) {
+	if opts.Workers < 1 {
+		opts.Workers = 1
+	}
+	m := &WalletMigrator{opts: opts, logger: monitor.NewModuleLogger("wallet_migration")}
+	if opts.DryRun {
+		return m, nil
+	}
+	aead, err := newEscrowAEAD(opts.EscrowKey)
+	if err != nil {
+		return nil, err
+	}
+	m.aead = aead
+	return m, nil
+}
+
+func newEscrowAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, errors.New("escrow key must be 32 bytes long")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Run migrates users who still have their account in the default wallet and users whose migration
+// was interrupted or failed, even if their wallet ID has been saved already.
+// A failure of a single user is recorded and doesn't stop the others from being migrated.
+func (m *WalletMigrator) Run() (*WalletMigrationReport, error) {
+	mods := []qm.QueryMod{
+		qm.Where(
+			`(wallet_id = '' AND sdk_account_id IS NOT NULL) OR id IN (SELECT user_id FROM wallet_migrations WHERE status <> ?)`,
+			MigrationDone,
+		),
+		qm.OrderBy(models.UserColumns.ID),
+	}
+	if m.opts.Limit > 0 {
+		mods = append(mods, qm.Limit(m.opts.Limit))
+	}
+	users, err := models.Users(mods...).AllG()
+	if err != nil {
+		return nil, err
+	}
+	report := &WalletMigrationReport{Selected: len(users)}
+	m.logger.Log().Infof("%v users to migrate", len(users))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	queue := make(chan *models.User)
+	for i := 0; i < m.opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for u := range queue {
+				err := m.Migrate(u)
+				mu.Lock()
+				if err != nil {
+					report.Failed++
+				} else {
+					report.Migrated++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, u := range users {
+		queue <- u
+	}
+	close(queue)
+	wg.Wait()
+	return report, nil
+}
+
+// Migrate moves legacy account of a single user to their wallet, continuing from the last completed step.
+func (m *WalletMigrator) Migrate(u *models.User) error {
+	log := m.logger.LogF(monitor.F{"id": u.ID, "account_id": u.SDKAccountID.String})
+
+	state, err := models.FindWalletMigrationG(u.ID)
+	if err == sql.ErrNoRows {
+		state = &models.WalletMigration{
+			UserID:    u.ID,
+			Status:    MigrationPending,
+			AccountID: u.SDKAccountID.String,
+			WalletID:  lbrynet.MakeWalletID(u.ID),
+		}
+		if !m.opts.DryRun {
+			if err := state.InsertG(boil.Infer()); err != nil {
+				log.Errorf("cannot save migration state: %v", err)
+				return err
+			}
+		}
+	} else if err != nil {
+		log.Errorf("cannot load migration state: %v", err)
+		return err
+	}
+	log = log.WithField("wallet_id", state.WalletID)
+
+	if m.opts.DryRun {
+		channels, err := m.listChannels(state.AccountID)
+		if err != nil {
+			log.Errorf("cannot list channels: %v", err)
+			return err
+		}
+		log.Infof("would migrate from %v step with %v channels", state.Status, len(channels))
+		return nil
+	}
+
+	state.Attempts++
+	for state.Status != MigrationDone {
+		next, err := m.step(u, state)
+		if err != nil {
+			log.Errorf("migration failed after %v step: %v", state.Status, err)
+			state.LastError = err.Error()
+			if _, err := state.UpdateG(boil.Whitelist(
+				models.WalletMigrationColumns.Attempts,
+				models.WalletMigrationColumns.LastError,
+				models.WalletMigrationColumns.UpdatedAt,
+			)); err != nil {
+				log.Errorf("cannot save migration state: %v", err)
+			}
+			return err
+		}
+		state.Status = next
+		state.LastError = ""
+		if _, err := state.UpdateG(boil.Infer()); err != nil {
+			log.Errorf("cannot save migration state: %v", err)
+			return err
+		}
+		log.Infof("migration step completed: %v", next)
+	}
+	return nil
+}
+
+// step performs the step following the last completed one and returns its name.
+func (m *WalletMigrator) step(u *models.User, state *models.WalletMigration) (string, error) {
+	switch state.Status {
+	case MigrationPending:
+		return MigrationWalletCreated, m.createWallet(u.ID, state.WalletID)
+	case MigrationWalletCreated:
+		return MigrationChannelsImported, m.importChannels(state.AccountID, state.WalletID)
+	case MigrationChannelsImported:
+		sealed, err := m.escrowSeed(u.ID, state.AccountID)
+		if err != nil {
+			return "", err
+		}
+		state.EscrowedSeed.SetValid(sealed)
+		return MigrationSeedEscrowed, nil
+	case MigrationSeedEscrowed:
+		_, err := lbrynet.Client.AccountRemove(state.AccountID)
+		// Account could have been removed by a run that was interrupted before saving its state
+		if err != nil && !strings.Contains(err.Error(), "Couldn't find account") {
+			return "", err
+		}
+		return MigrationAccountRemoved, nil
+	case MigrationAccountRemoved:
+		return MigrationDone, m.restoreAccount(u, state)
+	default:
+		return "", fmt.Errorf("unknown migration step: %v", state.Status)
+	}
+}
+
+// createWallet creates a wallet without any accounts, the legacy one is added to it later.
+func (m *WalletMigrator) createWallet(uid int, wid string) error {
+	_, err := lbrynet.Client.WalletCreate(wid, &ljsonrpc.WalletCreateOpts{CreateAccount: false})
+	if err == nil {
+		return nil
+	}
+	err = lbrynet.NewWalletError(uid, err)
+	if errors.As(err, &lbrynet.WalletExists{}) {
+		return nil
+	} else if errors.As(err, &lbrynet.WalletNeedsLoading{}) {
+		return lbrynet.LoadWalletByID(wid, uid)
+	}
+	return err
+}
+
+func (m *WalletMigrator) listChannels(accountID string) ([]ljsonrpc.Transaction, error) {
+	channels := []ljsonrpc.Transaction{}
+	for page := uint64(1); ; page++ {
+		res, err := lbrynet.Client.ChannelList(&accountID, page, channelListPageSize, nil)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, res.Items...)
+		if page >= res.TotalPages {
+			return channels, nil
+		}
+	}
+}
+
+// importChannels copies channel keys of the legacy account to the wallet. Importing a key twice is harmless.
+func (m *WalletMigrator) importChannels(accountID, wid string) error {
+	channels, err := m.listChannels(accountID)
+	if err != nil {
+		return err
+	}
+	for _, c := range channels {
+		key, err := lbrynet.Client.ChannelExport(c.ClaimID, nil, &accountID)
+		if err != nil {
+			return fmt.Errorf("cannot export channel %v: %v", c.ClaimID, err)
+		}
+		if _, err := lbrynet.Client.ChannelImport(string(*key), &wid); err != nil {
+			return fmt.Errorf("cannot import channel %v: %v", c.ClaimID, err)
+		}
+	}
+	return nil
+}
+
+func (m *WalletMigrator) escrowSeed(uid int, accountID string) ([]byte, error) {
+	var acc ljsonrpc.Account
+	err := lbrynet.Call("account_list", map[string]interface{}{"account_id": accountID, "show_seed": true}, &acc)
+	if err != nil {
+		return nil, err
+	}
+	if acc.Seed == nil || *acc.Seed == "" {
+		return nil, errors.New("account has no seed")
+	}
+	return sealSeed(m.aead, uid, *acc.Seed)
+}
+
+func sealSeed(aead cipher.AEAD, uid int, seed string) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	// User ID is authenticated along with the seed so it can't be restored for another user
+	return aead.Seal(nonce, nonce, []byte(seed), []byte(strconv.Itoa(uid))), nil
+}
+
+func (m *WalletMigrator) restoreAccount(u *models.User, state *models.WalletMigration) error {
+	seed, err := openSeed(m.aead, u.ID, state.EscrowedSeed.Bytes)
+	if err != nil {
+		return err
+	}
+	name := lbrynet.MakeAccountName(u.ID)
+	accounts, err := lbrynet.Client.AccountListForWallet(state.WalletID)
+	if err != nil {
+		return err
+	}
+	added := false
+	for _, a := range accounts.LBCMainnet {
+		if a.Name == name {
+			added = true
+			break
+		}
+	}
+	if !added {
+		singleKey := true
+		if _, err := lbrynet.Client.AccountAdd(name, &seed, nil, nil, &singleKey, &state.WalletID); err != nil {
+			return err
+		}
+	}
+	return NewWalletService().saveWalletID(u, state.WalletID)
+}
+
+func openSeed(aead cipher.AEAD, uid int, sealed []byte) (string, error) {
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("escrowed seed is missing")
+	}
+	seed, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(strconv.Itoa(uid)))
+	if err != nil {
+		return "", fmt.Errorf("cannot decrypt escrowed seed: %v", err)
+	}
+	return string(seed), nil
+}
+
+// hasUnfinishedMigration returns true if user's legacy account is being moved to their wallet.
+// Nothing else should create or save a wallet for the user until the migration is done.
+func hasUnfinishedMigration(uid int) (bool, error) {
+	return models.WalletMigrations(
+		models.WalletMigrationWhere.UserID.EQ(uid),
+		models.WalletMigrationWhere.Status.NEQ(MigrationDone),
+	).ExistsG()
+}
+
+// EscrowedSeed returns decrypted seed of user's legacy account stored during the migration.
+func EscrowedSeed(uid int, key []byte) (string, error) {
+	state, err := models.FindWalletMigrationG(uid)
+	if err == sql.ErrNoRows {
+		return "", errors.New("user has no migration state")
+	} else if err != nil {
+		return "", err
+	}
+	aead, err := newEscrowAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	return openSeed(aead, uid, state.EscrowedSeed.Bytes)
+}
@@ -0,0 +1,175 @@
+package users
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lbryio/lbrytv/config"
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+)
+
+var testEscrowKey = strings.Repeat("ab", 32)
+
+func TestSeedEscrow(t *testing.T) {
+	_, err := ParseEscrowKey("abcd")
+	assert.EqualError(t, err, "escrow key must be 32 bytes long")
+	_, err = ParseEscrowKey("xyz")
+	assert.Error(t, err)
+
+	key, err := ParseEscrowKey(testEscrowKey)
+	require.NoError(t, err)
+	aead, err := newEscrowAEAD(key)
+	require.NoError(t, err)
+
+	sealed, err := sealSeed(aead, 1, "seed words")
+	require.NoError(t, err)
+	assert.NotContains(t, string(sealed), "seed words")
+
+	seed, err := openSeed(aead, 1, sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "seed words", seed)
+
+	_, err = openSeed(aead, 2, sealed)
+	assert.Error(t, err)
+	_, err = openSeed(aead, 1, nil)
+	assert.EqualError(t, err, "escrowed seed is missing")
+}
+
+func TestWalletMigrator(t *testing.T) {
+	testFuncSetup()
+	sdk.Reset()
+	defer lbrynet.RemoveAccount(dummyUserID)
+
+	acc, err := lbrynet.CreateAccount(dummyUserID)
+	require.NoError(t, err)
+	u := &models.User{ID: dummyUserID, SDKAccountID: null.StringFrom(acc.ID)}
+	require.NoError(t, u.InsertG(boil.Infer()))
+	// Account which is not in the SDK can't be escrowed
+	failed := &models.User{ID: dummyUserID + 1, SDKAccountID: null.StringFrom("beefbeef")}
+	require.NoError(t, failed.InsertG(boil.Infer()))
+
+	key, err := ParseEscrowKey(testEscrowKey)
+	require.NoError(t, err)
+
+	m, err := NewWalletMigrator(WalletMigratorOpts{DryRun: true})
+	require.NoError(t, err)
+	report, err := m.Run()
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Selected)
+	n, err := models.WalletMigrations().CountG()
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, n)
+
+	m, err = NewWalletMigrator(WalletMigratorOpts{EscrowKey: key, Workers: 2})
+	require.NoError(t, err)
+	report, err = m.Run()
+	require.NoError(t, err)
+	assert.Equal(t, &WalletMigrationReport{Selected: 2, Migrated: 1, Failed: 1}, report)
+
+	require.NoError(t, u.ReloadG())
+	assert.Equal(t, lbrynet.MakeWalletID(u.ID), u.WalletID)
+	state, err := models.FindWalletMigrationG(u.ID)
+	require.NoError(t, err)
+	assert.Equal(t, MigrationDone, state.Status)
+	seed, err := EscrowedSeed(u.ID, key)
+	require.NoError(t, err)
+	assert.NotEmpty(t, seed)
+	accounts, err := lbrynet.Client.AccountListForWallet(u.WalletID)
+	require.NoError(t, err)
+	require.Len(t, accounts.LBCMainnet, 1)
+	assert.Equal(t, lbrynet.MakeAccountName(u.ID), accounts.LBCMainnet[0].Name)
+
+	state, err = models.FindWalletMigrationG(failed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, MigrationChannelsImported, state.Status)
+	assert.Equal(t, 1, state.Attempts)
+	assert.NotEmpty(t, state.LastError)
+
+	// Finished migrations are not picked up again, failed ones are retried
+	report, err = m.Run()
+	require.NoError(t, err)
+	assert.Equal(t, &WalletMigrationReport{Selected: 1, Migrated: 0, Failed: 1}, report)
+	state, err = models.FindWalletMigrationG(failed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, state.Attempts)
+}
+
+func TestWalletMigratorResumesFromAccountRemoved(t *testing.T) {
+	testFuncSetup()
+	sdk.Reset()
+
+	ts := launchAuthenticatingAPIServer(dummyUserID)
+	defer ts.Close()
+	config.Override("InternalAPIHost", ts.URL)
+	defer config.RestoreOverridden()
+
+	key, err := ParseEscrowKey(testEscrowKey)
+	require.NoError(t, err)
+	aead, err := newEscrowAEAD(key)
+	require.NoError(t, err)
+	m, err := NewWalletMigrator(WalletMigratorOpts{EscrowKey: key})
+	require.NoError(t, err)
+
+	// Previous run was interrupted after removing the account from the default wallet
+	acc, err := lbrynet.CreateAccount(dummyUserID)
+	require.NoError(t, err)
+	u := &models.User{ID: dummyUserID, SDKAccountID: null.StringFrom(acc.ID)}
+	require.NoError(t, u.InsertG(boil.Infer()))
+	sealed, err := sealSeed(aead, u.ID, *acc.Seed)
+	require.NoError(t, err)
+	state := &models.WalletMigration{
+		UserID:       u.ID,
+		Status:       MigrationAccountRemoved,
+		AccountID:    acc.ID,
+		WalletID:     lbrynet.MakeWalletID(u.ID),
+		EscrowedSeed: null.BytesFrom(sealed),
+	}
+	require.NoError(t, state.InsertG(boil.Infer()))
+	require.NoError(t, m.createWallet(u.ID, state.WalletID))
+	_, err = lbrynet.RemoveAccount(u.ID)
+	require.NoError(t, err)
+
+	// Another run was interrupted after saving wallet ID but before marking the migration done
+	saved := &models.User{ID: dummyUserID + 1, WalletID: lbrynet.MakeWalletID(dummyUserID + 1)}
+	require.NoError(t, saved.InsertG(boil.Infer()))
+	savedSealed, err := sealSeed(aead, saved.ID, "saved seed")
+	require.NoError(t, err)
+	savedState := &models.WalletMigration{
+		UserID:       saved.ID,
+		Status:       MigrationAccountRemoved,
+		AccountID:    "beefbeef",
+		WalletID:     saved.WalletID,
+		EscrowedSeed: null.BytesFrom(savedSealed),
+	}
+	require.NoError(t, savedState.InsertG(boil.Infer()))
+	require.NoError(t, m.createWallet(saved.ID, saved.WalletID))
+
+	// User can't get an empty wallet while their account is out of the SDK
+	_, err = NewWalletService().Retrieve(Query{Token: "abc"})
+	assert.Equal(t, ErrWalletMigrationInProgress, err)
+	require.NoError(t, u.ReloadG())
+	assert.Equal(t, "", u.WalletID)
+
+	report, err := m.Run()
+	require.NoError(t, err)
+	assert.Equal(t, &WalletMigrationReport{Selected: 2, Migrated: 2, Failed: 0}, report)
+
+	for _, user := range []*models.User{u, saved} {
+		state, err := models.FindWalletMigrationG(user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, MigrationDone, state.Status)
+		accounts, err := lbrynet.Client.AccountListForWallet(state.WalletID)
+		require.NoError(t, err)
+		require.Len(t, accounts.LBCMainnet, 1)
+		assert.Equal(t, lbrynet.MakeAccountName(user.ID), accounts.LBCMainnet[0].Name)
+	}
+	retrieved, err := NewWalletService().Retrieve(Query{Token: "abc"})
+	require.NoError(t, err)
+	assert.Equal(t, lbrynet.MakeWalletID(u.ID), retrieved.WalletID)
+}
@@ -13,6 +13,9 @@ import (
 	"github.com/volatiletech/sqlboiler/boil"
 )
 
+// ErrWalletMigrationInProgress is returned for legacy users whose account is being moved to their wallet.
+var ErrWalletMigrationInProgress = errors.New("wallet migration is in progress, try again later")
+
 type WalletService struct {
 	UserService
 }
@@ -24,29 +27,54 @@ func NewWalletService() *WalletService {
 }
 
 func (s *WalletService) Retrieve(q Query) (*models.User, error) {
-	var (
-		localUser *models.User
-		wid       string
-	)
 	token := q.Token
 
-	log := s.logger.LogF(monitor.F{"token": token})
+	log := s.logger.LogF(monitor.F{"token": token, monitor.RequestIDF: q.RequestID})
 
-	remoteUser, err := getRemoteUser(token, q.MetaRemoteIP)
+	remoteUser, err := authenticateRemoteUser(q)
 	if err != nil {
 		return nil, s.LogErrorAndReturn(log, "cannot authenticate user with internal-apis: %v", err)
 	}
 
 	// Update log entry with extra context data
-	log = s.logger.LogF(monitor.F{"token": token, "id": remoteUser.ID, "email": remoteUser.Email})
-	if remoteUser.Email == "" {
+	log = s.logger.LogF(monitor.F{"token": token, "id": remoteUser.ID, "email": remoteUser.Email, monitor.RequestIDF: q.RequestID})
+	if remoteUser.Email == "" && !remoteUser.Fallback {
 		return nil, s.LogErrorAndReturn(log, "cannot authenticate user with internal-api, email not confirmed")
 	}
 
-	localUser, errStorage := s.getDBUser(remoteUser.ID)
+	localUser, err := s.retrieveLocalUser(remoteUser.ID, log)
+	if err != nil {
+		return nil, err
+	}
+
+	if Activity != nil {
+		Activity.Record(localUser.ID, remoteUser.Email, q.MetaRemoteIP)
+	}
+
+	// Only users with confirmed emails get here, so the token can be used for authenticating them
+	// while internal-apis is unavailable
+	if remoteUser.verified && AuthFallback != nil {
+		if err := AuthFallback.Remember(token, localUser.ID); err != nil {
+			log.Errorf("cannot store verified token: %v", err)
+		}
+	}
+
+	return localUser, nil
+}
+
+// retrieveLocalUser returns local user record for an authenticated user with a given ID,
+// creating the record and SDK wallet if they don't exist yet.
+func (s *WalletService) retrieveLocalUser(id int, log *logrus.Entry) (*models.User, error) {
+	var (
+		localUser *models.User
+		wid       string
+		err       error
+	)
+
+	localUser, errStorage := s.getDBUser(id)
 	if errStorage == sql.ErrNoRows {
 		log.Infof("user was not found in the database, creating")
-		localUser, err = s.createDBUser(remoteUser.ID)
+		localUser, err = s.createDBUser(id)
 		if err != nil {
 			return nil, err
 		}
@@ -55,7 +83,7 @@ func (s *WalletService) Retrieve(q Query) (*models.User, error) {
 		if err != nil {
 			return nil, err
 		}
-		log = s.logger.LogF(monitor.F{"token": token, "id": remoteUser.ID, "email": remoteUser.Email, "wallet_id": wid})
+		log = log.WithField("wallet_id", wid)
 	} else if errStorage != nil {
 		return nil, errStorage
 	}
@@ -63,6 +91,13 @@ func (s *WalletService) Retrieve(q Query) (*models.User, error) {
 	// This scenario may happen for legacy users who haven't moved to wallets yet
 	if localUser.WalletID == "" {
 		log.Warn("user doesn't have wallet ID set")
+		// Wallet ID is saved by the migration once the account is in the wallet
+		if migrating, err := hasUnfinishedMigration(localUser.ID); err != nil {
+			return nil, err
+		} else if migrating {
+			log.Warn("user wallet migration is in progress")
+			return nil, ErrWalletMigrationInProgress
+		}
 		wid, err = s.createWallet(localUser)
 		if err != nil {
 			return nil, err
@@ -73,18 +108,35 @@ func (s *WalletService) Retrieve(q Query) (*models.User, error) {
 		}
 	}
 
+	// Wallet could have been unloaded from the SDK after being idle, it needs to be loaded before it's used
+	if wid == "" && lbrynet.Wallets != nil {
+		if err := lbrynet.Wallets.Touch(localUser.WalletID); err != nil {
+			return nil, s.LogErrorAndReturn(log, "cannot load wallet: %v", err)
+		}
+	}
+
 	return localUser, nil
 }
 
 func (s *WalletService) createWallet(u *models.User) (string, error) {
-	return lbrynet.InitializeWallet(u.ID)
+	wid, err := lbrynet.InitializeWallet(u.ID)
+	if err != nil {
+		return "", err
+	}
+	if lbrynet.Wallets != nil {
+		lbrynet.Wallets.MarkLoaded(wid)
+	}
+	return wid, nil
 }
 
 func (s *WalletService) saveWalletID(u *models.User, wid string) error {
 	s.logger.LogF(monitor.F{"id": u.ID, "wallet_id": wid}).Info("saving wallet ID to user record")
 	u.WalletID = wid
-	_, err := u.UpdateG(boil.Infer())
-	return err
+	_, err := u.UpdateG(boil.Whitelist(models.UserColumns.WalletID, models.UserColumns.UpdatedAt))
+	if err != nil {
+		return err
+	}
+	return saveDefaultWallet(u)
 }
 
 // LogErrorAndReturn logs error with rich context and returns an error object
@@ -98,7 +150,9 @@ func (s *WalletService) LogErrorAndReturn(log *logrus.Entry, message string, a .
 // by a header provided by http client.
 func GetWalletIDFromRequest(r *http.Request, retriever Retriever) (string, error) {
 	if token, ok := r.Header[TokenHeader]; ok {
-		u, err := retriever.Retrieve(Query{token[0], GetIPAddressForRequest(r)})
+		u, err := retriever.Retrieve(Query{
+			Token: token[0], MetaRemoteIP: GetIPAddressForRequest(r), RequestID: monitor.RequestID(r.Context()),
+		})
 		if err != nil {
 			return "", err
 		}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lbryio/lbrytv/app/users"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	apiKeyName       string
+	apiKeyScopes     []string
+	apiKeyAllowedIPs []string
+	apiKeyExpiresIn  time.Duration
+)
+
+func init() {
+	apiKeyCreateCmd.Flags().StringVar(&apiKeyName, "name", "", "who the key is issued to")
+	apiKeyCreateCmd.Flags().StringSliceVar(&apiKeyScopes, "scopes", nil, "SDK methods the key can call, e.g. publish,stream_update,claim_list")
+	apiKeyCreateCmd.Flags().StringSliceVar(&apiKeyAllowedIPs, "allow-ip", nil, "IP addresses or CIDR ranges the key can be used from (any if not set)")
+	apiKeyCreateCmd.Flags().DurationVar(&apiKeyExpiresIn, "expires-in", 0, "time until the key expires (never if not set)")
+	apiKeyCreateCmd.MarkFlagRequired("name")
+	apiKeyCreateCmd.MarkFlagRequired("scopes")
+
+	apiKeyCmd.AddCommand(apiKeyCreateCmd, apiKeyListCmd, apiKeyRevokeCmd)
+	rootCmd.AddCommand(apiKeyCmd)
+}
+
+func parseIDArg(arg, name string) int {
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		fmt.Printf("invalid %v: %v\n", name, arg)
+		os.Exit(1)
+	}
+	return id
+}
+
+var apiKeyCmd = &cobra.Command{
+	Use:   "api_key",
+	Short: "Manage API keys for server-to-server integrations",
+}
+
+var apiKeyCreateCmd = &cobra.Command{
+	Use:   "create <user_id>",
+	Short: "Issue an API key giving access to user's wallet",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		opts := users.APIKeyOpts{
+			UserID:     parseIDArg(args[0], "user ID"),
+			Name:       apiKeyName,
+			Scopes:     apiKeyScopes,
+			AllowedIPs: apiKeyAllowedIPs,
+		}
+		if apiKeyExpiresIn != 0 {
+			opts.Expires = time.Now().Add(apiKeyExpiresIn)
+		}
+		key, k, err := users.CreateAPIKey(opts)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Printf("API key %v issued to %v for user %v\n", k.ID, k.Name, k.UserID)
+		fmt.Printf("key (it will not be shown again): %v\n", key)
+	},
+}
+
+var apiKeyListCmd = &cobra.Command{
+	Use:   "list [user_id]",
+	Short: "List API keys, of a single user if user ID is supplied",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var uid int
+		if len(args) > 0 {
+			uid = parseIDArg(args[0], "user ID")
+		}
+		keys, err := users.ListAPIKeys(uid)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		for _, k := range keys {
+			status := "active"
+			if k.RevokedAt.Valid {
+				status = "revoked"
+			} else if k.ExpiresAt.Valid && k.ExpiresAt.Time.Before(time.Now()) {
+				status = "expired"
+			}
+			fmt.Printf(
+				"%v\tuser %v\t%v\t%v\tscopes: %v\tallowed IPs: %v\n",
+				k.ID, k.UserID, k.Name, status, strings.Join(k.Scopes, ","), strings.Join(k.AllowedIps, ","),
+			)
+		}
+	},
+}
+
+var apiKeyRevokeCmd = &cobra.Command{
+	Use:   "revoke <key_id>",
+	Short: "Revoke an API key",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id := parseIDArg(args[0], "API key ID")
+		if err := users.RevokeAPIKey(id); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Printf("API key %v revoked\n", id)
+	},
+}
@@ -1,114 +1,80 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
+	"os"
 
-	ljsonrpc "github.com/lbryio/lbry.go/v2/extras/jsonrpc"
-	"github.com/lbryio/lbrytv/internal/lbrynet"
-	"github.com/lbryio/lbrytv/models"
+	"github.com/lbryio/lbrytv/app/users"
+	"github.com/lbryio/lbrytv/config"
 
 	"github.com/spf13/cobra"
-	"github.com/volatiletech/sqlboiler/boil"
+)
+
+var (
+	migrateDryRun  bool
+	migrateLimit   int
+	migrateWorkers int
 )
 
 func init() {
+	migrateToWallets.Flags().BoolVar(&migrateDryRun, "dry-run", false, "only report what would be migrated")
+	migrateToWallets.Flags().IntVar(&migrateLimit, "limit", 0, "how many users to migrate at most (all if not set)")
+	migrateToWallets.Flags().IntVar(&migrateWorkers, "workers", 4, "how many users to migrate concurrently")
+
+	migrateToWallets.AddCommand(migrateToWalletsSeed)
 	rootCmd.AddCommand(migrateToWallets)
 }
 
-func prettyPrint(i interface{}) {
-	s, _ := json.MarshalIndent(i, "", "\t")
-	fmt.Println(string(s))
+func escrowKey() []byte {
+	key, err := users.ParseEscrowKey(config.GetWalletMigrationEscrowKey())
+	if err != nil {
+		fmt.Printf("WalletMigrationEscrowKey: %v\n", err)
+		os.Exit(1)
+	}
+	return key
 }
 
-func ptrToBool(b bool) *bool { return &b }
-
 var migrateToWallets = &cobra.Command{
 	Use:    "migrate_to_wallets",
-	Short:  "Migrate existing accounts to wallets",
+	Short:  "Migrate existing accounts to wallets, resuming interrupted and failed migrations",
 	Hidden: true,
+	Args:   cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		realRun := false
-		if len(args) > 0 && args[0] == "doit" {
-			realRun = true
+		opts := users.WalletMigratorOpts{Workers: migrateWorkers, Limit: migrateLimit, DryRun: migrateDryRun}
+		if !migrateDryRun {
+			opts.EscrowKey = escrowKey()
 		}
-		c := lbrynet.Client
-
-		users, err := models.Users(models.UserWhere.WalletID.EQ("")).AllG()
+		m, err := users.NewWalletMigrator(opts)
 		if err != nil {
-			panic(err)
+			fmt.Println(err)
+			os.Exit(1)
 		}
-		fmt.Printf("%v users without wallets\n", len(users))
-
-		for _, u := range users {
-			wid := lbrynet.MakeWalletID(u.ID)
-
-			if realRun {
-				_, err = c.WalletCreate(wid, &ljsonrpc.WalletCreateOpts{CreateAccount: false})
-				if err != nil {
-					panic(err)
-				}
-			}
-
-			u.WalletID = wid
-
-			if realRun {
-				_, err = u.UpdateG(boil.Infer())
-				if err != nil {
-					panic(err)
-				}
-			}
-
-			fmt.Printf("initialized wallet for user %v (wid=%v)\n", u.ID, wid)
-
-			accID := u.SDKAccountID.String
-
-			channels, err := c.ChannelList(&accID, 1, 999, nil)
-			if channels.TotalPages > 1 {
-				panic(fmt.Sprintf("expected one page, found %v for account %v", channels.TotalPages, accID))
-			}
-
-			fmt.Printf("got %v channels for acc id=%v, uid=%v\n", len(channels.Items), accID, u.ID)
-
-			for _, channel := range channels.Items {
-				key, err := c.ChannelExport(channel.ClaimID, nil, nil)
-				if err != nil {
-					panic(err)
-				}
-				keyS := string(*key)
-
-				if realRun {
-					_, err = c.ChannelImport(keyS, &wid)
-					if err != nil {
-						panic(err)
-					}
-				}
-				fmt.Printf("exported channel %v to wallet %v\n", keyS, wid)
-			}
-
-			var acc *ljsonrpc.Account
-			if realRun {
-				acc, err = c.AccountRemove(accID)
-				if err != nil {
-					panic(err)
-				}
-				fmt.Printf("removed account %v for user %v (seed=%v)\n", accID, u.ID, acc.Seed)
-			}
-
-			newAccName := lbrynet.MakeAccountName(u.ID)
-
-			var newAcc *ljsonrpc.Account
-			if realRun {
-				newAcc, err = c.AccountAdd(newAccName, acc.Seed, nil, nil, ptrToBool(true), &wid)
-				if err != nil {
-					prettyPrint(acc)
-					panic(err)
-				}
-				fmt.Printf("DONE migrating account %v to wallet %v (acc id=%v)\n", accID, wid, newAcc.ID)
-			} else {
-				fmt.Printf("TESTED migrating account %v to wallet %v\n", accID, wid)
-			}
+		report, err := m.Run()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if migrateDryRun {
+			fmt.Printf("%v users would be migrated\n", report.Selected)
+			return
+		}
+		fmt.Printf("%v users selected, %v migrated, %v failed\n", report.Selected, report.Migrated, report.Failed)
+		if report.Failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
 
+var migrateToWalletsSeed = &cobra.Command{
+	Use:   "seed <user_id>",
+	Short: "Print escrowed seed of user's legacy account",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		seed, err := users.EscrowedSeed(parseIDArg(args[0], "user ID"), escrowKey())
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
 		}
+		fmt.Println(seed)
 	},
 }
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/lbryio/lbrytv/app/users"
+	"github.com/lbryio/lbrytv/config"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	reconcileFix        bool
+	reconcileWalletsDir string
+)
+
+func init() {
+	reconcileCmd.Flags().BoolVar(&reconcileFix, "fix", false, "apply repairs which don't lose any data")
+	reconcileCmd.Flags().StringVar(&reconcileWalletsDir, "wallets-dir", "", "SDK wallets directory for checking wallets which are not loaded (LbrynetWalletsDir if not set)")
+	rootCmd.AddCommand(reconcileCmd)
+}
+
+var reconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Find inconsistencies between users in the database and SDK wallets and accounts",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		opts := users.ReconcileOpts{WalletsDir: config.GetLbrynetWalletsDir(), Fix: reconcileFix}
+		if reconcileWalletsDir != "" {
+			opts.WalletsDir = reconcileWalletsDir
+		}
+		report, err := users.Reconcile(opts)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Printf(
+			"%v users, %v loaded wallets, %v wallet files, %v legacy accounts\n",
+			report.Users, report.LoadedWallets, report.WalletFiles, report.LegacyAccounts,
+		)
+		unfixed := 0
+		for _, d := range report.Drifts {
+			status := "found"
+			if d.Fixed {
+				status = "fixed"
+			} else {
+				unfixed++
+			}
+			fmt.Printf("%v\t%v\tuser %v\twallet %v\taccount %v\t%v\n", status, d.Kind, d.UserID, d.WalletID, d.AccountID, d.Note)
+		}
+		fmt.Printf("%v drifts found, %v left unfixed\n", len(report.Drifts), unfixed)
+		if unfixed > 0 {
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/lbryio/lbrytv/app/proxy"
+	"github.com/lbryio/lbrytv/config"
+
+	"github.com/spf13/cobra"
+	"github.com/ybbus/jsonrpc"
+)
+
+var (
+	replaySDK      string
+	replayWalletID string
+	replayIgnore   []string
+	replayVerbose  bool
+	replayWrites   bool
+)
+
+func init() {
+	replayCmd.Flags().StringVar(&replaySDK, "sdk", "", "SDK address to replay queries against (defaults to Lbrynet config value)")
+	replayCmd.Flags().StringVar(&replayWalletID, "wallet-id", "", "wallet ID to use for wallet-specific queries (those are skipped if not set)")
+	replayCmd.Flags().StringSliceVar(&replayIgnore, "ignore", proxy.VolatileFields, "response fields to ignore when comparing")
+	replayCmd.Flags().BoolVar(&replayWrites, "allow-writes", false, "replay queries sending funds or otherwise changing the wallet (those are skipped if not set)")
+	replayCmd.Flags().BoolVarP(&replayVerbose, "verbose", "v", false, "print matching queries too")
+	rootCmd.AddCommand(replayCmd)
+}
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <recording.jsonl>",
+	Short: "Replay recorded proxy queries against an SDK and compare responses",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		f, err := os.Open(args[0])
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer f.Close()
+
+		sdk := replaySDK
+		if sdk == "" {
+			sdk = config.GetLbrynet()
+		}
+		svc := proxy.NewService(sdk)
+
+		var total, matched, mismatched, skipped int
+		err = proxy.ReadRecords(f, func(rec proxy.Record) error {
+			total++
+			// Replaying writes against a real wallet would send funds and publish content again
+			if !replayWrites && proxy.IsWriteMethod(rec.Method) {
+				skipped++
+				if replayVerbose {
+					fmt.Printf("SKIP %v: writes are not allowed\n", rec.Method)
+				}
+				return nil
+			}
+			if replayWalletID == "" && proxy.MethodRequiresWallet(rec.Method) {
+				skipped++
+				if replayVerbose {
+					fmt.Printf("SKIP %v: no wallet ID supplied\n", rec.Method)
+				}
+				return nil
+			}
+			raw, err := json.Marshal(jsonrpc.NewRequest(rec.Method, rec.Params))
+			if err != nil {
+				return err
+			}
+
+			c := svc.NewCaller()
+			if replayWalletID != "" {
+				c.SetWalletID(replayWalletID)
+			}
+			replayed := &jsonrpc.RPCResponse{}
+			d := json.NewDecoder(bytes.NewReader(c.Call(raw)))
+			d.UseNumber()
+			if err := d.Decode(replayed); err != nil {
+				return fmt.Errorf("cannot parse response for %v: %v", rec.Method, err)
+			}
+			// Secrets are masked in recordings
+			replayed = proxy.SanitizeResponse(replayed)
+
+			// Request IDs are assigned anew on replay so only result and error are compared
+			var expected jsonrpc.RPCResponse
+			if rec.Response != nil {
+				expected = *rec.Response
+			}
+			diffs, err := proxy.DiffResponses(
+				map[string]interface{}{"result": expected.Result, "error": expected.Error},
+				map[string]interface{}{"result": replayed.Result, "error": replayed.Error},
+				replayIgnore,
+			)
+			if err != nil {
+				return err
+			}
+			if len(diffs) == 0 {
+				matched++
+				if replayVerbose {
+					fmt.Printf("OK   %v\n", rec.Method)
+				}
+				return nil
+			}
+			mismatched++
+			params, _ := json.Marshal(rec.Params)
+			fmt.Printf("DIFF %v %s\n", rec.Method, params)
+			for _, d := range diffs {
+				fmt.Printf("     %v\n", d)
+			}
+			return nil
+		})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("%v queries replayed: %v matched, %v differed, %v skipped\n", total, matched, mismatched, skipped)
+		if mismatched > 0 {
+			os.Exit(1)
+		}
+	},
+}
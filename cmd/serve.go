@@ -4,10 +4,15 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/lbryio/lbrytv/app/proxy"
+	"github.com/lbryio/lbrytv/app/users"
 	"github.com/lbryio/lbrytv/config"
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/internal/maintenance"
 	"github.com/lbryio/lbrytv/internal/metrics_server"
+	"github.com/lbryio/lbrytv/internal/storage"
 	"github.com/lbryio/lbrytv/server"
 
 	"github.com/spf13/cobra"
@@ -17,16 +22,127 @@ var rootCmd = &cobra.Command{
 	Use:   "lbrytv",
 	Short: "lbrytv is a backend API server for lbry.tv frontend",
 	Run: func(cmd *cobra.Command, args []string) {
+		proxyService := proxy.NewService(config.GetLbrynet())
+		if rc := config.GetRecorder(); rc.Path != "" {
+			r, err := proxy.NewRecorder(proxy.RecorderOpts{Path: rc.Path, MaxSize: rc.MaxSize, SampleRate: rc.SampleRate})
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer r.Close()
+			proxyService.SetRecorder(r)
+		}
+		if sc := config.GetShadow(); sc.Endpoint != "" {
+			proxyService.SetShadow(proxy.NewShadow(proxy.ShadowOpts{
+				Endpoint:    sc.Endpoint,
+				Percentage:  sc.Percentage,
+				Concurrency: sc.Concurrency,
+				Timeout:     sc.Timeout,
+			}))
+		}
+		if lc := config.GetWalletLock(); lc.Enabled {
+			proxyService.SetWalletLocker(proxy.NewWalletLocker(proxy.WalletLockerOpts{
+				QueueSize: lc.QueueSize,
+				Timeout:   lc.Timeout,
+				DB:        storage.Conn.DB.DB,
+				MaxHeld:   lc.MaxHeld,
+			}))
+		}
+		if retention := config.GetIdempotencyKeyRetention(); retention != 0 {
+			idempotency := proxy.NewIdempotency(retention)
+			idempotency.Start(time.Hour)
+			defer idempotency.Stop()
+			proxyService.SetIdempotency(idempotency)
+		}
+		// Limits are always checked as users can have their own limits even if there are no default ones
+		proxyService.SetSpendingLimits(proxy.NewSpendingLimits(spendingLimitsOpts()))
+		if ac := config.GetAuthCache(); ac.TTL != 0 {
+			users.AuthCache = users.NewTokenCache(users.TokenCacheOpts{
+				TTL:         ac.TTL,
+				NegativeTTL: ac.NegativeTTL,
+				MaxSize:     ac.MaxSize,
+			})
+		}
+		if grace := config.GetAuthFallbackGracePeriod(); grace != 0 {
+			users.AuthFallback = users.NewTokenFallback(grace)
+			users.AuthFallback.Start(time.Hour)
+			defer users.AuthFallback.Stop()
+		}
+		if err := users.SetTrustedProxies(config.GetTrustedProxies()); err != nil {
+			log.Fatal(err)
+		}
+		if jc := config.GetJWT(); len(jc.Issuers) > 0 {
+			var err error
+			issuers := make([]users.JWTIssuer, len(jc.Issuers))
+			for i, iss := range jc.Issuers {
+				issuers[i] = users.JWTIssuer{Issuer: iss.Issuer, Secrets: iss.Secrets, KeysFile: iss.KeysFile}
+				if len(iss.Scopes) > 0 {
+					issuers[i].Scopes = iss.Scopes
+				}
+			}
+			users.JWT, err = users.NewJWTRetriever(users.JWTRetrieverOpts{
+				Issuers:   issuers,
+				Audience:  jc.Audience,
+				UserClaim: jc.UserClaim,
+				Leeway:    jc.Leeway,
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+		if uac := config.GetUserActivity(); uac.Interval != 0 {
+			users.Activity = users.NewActivityRecorder(users.ActivityRecorderOpts{
+				Interval:  uac.Interval,
+				QueueSize: uac.QueueSize,
+			})
+			users.Activity.Start()
+			defer users.Activity.Stop()
+		}
+		if gc := config.GetGuests(); gc.Secret != "" {
+			var err error
+			users.Guests, err = users.NewGuestService(users.GuestServiceOpts{
+				Secret:           gc.Secret,
+				TTL:              gc.TTL,
+				HourlyLimitPerIP: gc.HourlyLimitPerIP,
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+			users.Guests.Start(time.Hour)
+			defer users.Guests.Stop()
+		}
+		if wc := config.GetWalletTracker(); wc.IdleTimeout != 0 || wc.MaxLoaded != 0 {
+			lbrynet.Wallets = lbrynet.NewWalletTracker(lbrynet.WalletTrackerOpts{
+				IdleTimeout:   wc.IdleTimeout,
+				MaxLoaded:     wc.MaxLoaded,
+				CheckInterval: wc.CheckInterval,
+			})
+			if err := lbrynet.Wallets.Seed(); err != nil {
+				log.Printf("cannot get the list of loaded wallets: %v", err)
+			}
+			lbrynet.Wallets.Start()
+			defer lbrynet.Wallets.Stop()
+		}
+
+		mc := config.GetMaintenance()
+		applyMaintenanceConfig(mc)
+		config.OnReload(func() {
+			// Only apply config settings when they change so state set via admin API is not overwritten
+			if nmc := config.GetMaintenance(); nmc != mc {
+				mc = nmc
+				applyMaintenanceConfig(mc)
+			}
+		})
+
 		s := server.NewServer(server.ServerOpts{
 			Address:      config.GetAddress(),
-			ProxyService: proxy.NewService(config.GetLbrynet()),
+			ProxyService: proxyService,
 		})
 		err := s.Start()
 		if err != nil {
 			log.Fatal(err)
 		}
 
-		ms := metrics_server.NewServer(config.MetricsAddress(), config.MetricsPath(), s.ProxyService)
+		ms := metrics_server.NewServer(config.MetricsAddress(), config.MetricsPath())
 		ms.Serve()
 
 		// ServeUntilShutdown is blocking, should be last
@@ -40,3 +156,28 @@ func Execute() {
 		os.Exit(1)
 	}
 }
+
+func applyMaintenanceConfig(mc config.MaintenanceConfig) {
+	st := maintenance.Status{Enabled: mc.Enabled, Message: mc.Message}
+	if !mc.ETA.IsZero() {
+		st.ETA = &mc.ETA
+	}
+	maintenance.Set(st)
+}
+
+func spendingLimitsOpts() proxy.SpendingLimitsOpts {
+	var opts proxy.SpendingLimitsOpts
+	var err error
+	slc := config.GetSpendingLimits()
+	if slc.Daily != "" {
+		if opts.Daily, err = lbrynet.ParseLBC(slc.Daily); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if slc.PerTransaction != "" {
+		if opts.PerTransaction, err = lbrynet.ParseLBC(slc.PerTransaction); err != nil {
+			log.Fatal(err)
+		}
+	}
+	return opts
+}
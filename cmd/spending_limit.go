@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/lbryio/lbrytv/app/proxy"
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+
+	"github.com/spf13/cobra"
+	"github.com/volatiletech/null"
+)
+
+var (
+	spendingLimitDaily          string
+	spendingLimitPerTransaction string
+	spendingLimitRemove         bool
+)
+
+func init() {
+	spendingLimitCmd.Flags().StringVar(&spendingLimitDaily, "daily", "", "LBC the user can send out during 24 hours (default limit applies if not set, 0 means no limit)")
+	spendingLimitCmd.Flags().StringVar(&spendingLimitPerTransaction, "per-transaction", "", "LBC the user can send out in one transaction (default limit applies if not set, 0 means no limit)")
+	spendingLimitCmd.Flags().BoolVar(&spendingLimitRemove, "remove", false, "remove user-specific limits so default ones apply")
+	rootCmd.AddCommand(spendingLimitCmd)
+}
+
+func parseLimitFlag(value string) null.Int64 {
+	if value == "" {
+		return null.Int64{}
+	}
+	deweys, err := lbrynet.ParseLBC(value)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	return null.Int64From(deweys)
+}
+
+func formatLimit(limit null.Int64) string {
+	if !limit.Valid {
+		return "default"
+	}
+	if limit.Int64 == 0 {
+		return "unlimited"
+	}
+	return lbrynet.FormatLBC(limit.Int64) + " LBC"
+}
+
+var spendingLimitCmd = &cobra.Command{
+	Use:   "spending_limit <user_id>",
+	Short: "Override spending limits for a user",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		uid, err := strconv.Atoi(args[0])
+		if err != nil {
+			fmt.Printf("invalid user ID: %v\n", args[0])
+			os.Exit(1)
+		}
+
+		if spendingLimitRemove {
+			if err := proxy.RemoveUserSpendingLimit(uid); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			fmt.Printf("user %v: default limits restored\n", uid)
+			return
+		}
+
+		l, err := proxy.SetUserSpendingLimit(uid, parseLimitFlag(spendingLimitDaily), parseLimitFlag(spendingLimitPerTransaction))
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Printf("user %v: daily limit %v, per-transaction limit %v\n", uid, formatLimit(l.DailyLimit), formatLimit(l.TransactionLimit))
+	},
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/lbryio/lbrytv/app/users"
+	"github.com/lbryio/lbrytv/config"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	userExportTo string
+	userNoExport bool
+)
+
+func init() {
+	userDeleteCmd.Flags().StringVar(&userExportTo, "export-to", "", "directory to export wallets to before deletion (UserExportDir if not set)")
+	userDeleteCmd.Flags().BoolVar(&userNoExport, "no-export", false, "don't export wallets before deletion")
+
+	userCmd.AddCommand(userDeleteCmd)
+	rootCmd.AddCommand(userCmd)
+}
+
+var userCmd = &cobra.Command{
+	Use:   "user",
+	Short: "Manage lbrytv users",
+}
+
+var userDeleteCmd = &cobra.Command{
+	Use:   "delete <user_id>",
+	Short: "Erase user's wallets, uploaded files and database records",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		uid := parseIDArg(args[0], "user ID")
+		cfg := config.GetUserDeletion()
+		opts := users.DeleteUserOpts{
+			ExportDir:  cfg.ExportDir,
+			WalletsDir: cfg.WalletsDir,
+			ArchiveDir: cfg.ArchiveDir,
+			UploadDir:  config.GetPublishSourceDir(),
+		}
+		if userExportTo != "" {
+			opts.ExportDir = userExportTo
+		}
+		if userNoExport {
+			opts.ExportDir = ""
+		}
+		report, err := users.DeleteUser(uid, opts)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Printf("user %v deleted\n", report.UserID)
+		if report.ExportFile != "" {
+			fmt.Printf("wallets exported to %v\n", report.ExportFile)
+		}
+		for _, wid := range report.WalletIDs {
+			fmt.Printf("wallet removed: %v\n", wid)
+		}
+		for _, f := range report.WalletFiles {
+			fmt.Printf("wallet file archived or deleted: %v\n", f)
+		}
+		for _, d := range report.UploadDirs {
+			fmt.Printf("uploads removed: %v\n", d)
+		}
+		fmt.Printf("stored responses deleted: %v\n", report.IdempotencyKeys)
+	},
+}
@@ -4,7 +4,9 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
 )
 
@@ -20,6 +22,93 @@ type DBConfig struct {
 	Options    string
 }
 
+// RecorderConfig contains settings for recording proxied SDK traffic.
+type RecorderConfig struct {
+	Path       string
+	MaxSize    int64
+	SampleRate float64
+}
+
+// ShadowConfig contains settings for mirroring proxied traffic to a canary SDK.
+type ShadowConfig struct {
+	Endpoint    string
+	Percentage  float64
+	Concurrency int
+	Timeout     time.Duration
+}
+
+// WalletTrackerConfig contains settings for unloading idle wallets from the SDK.
+type WalletTrackerConfig struct {
+	IdleTimeout   time.Duration
+	MaxLoaded     int
+	CheckInterval time.Duration
+}
+
+// SpendingLimitsConfig contains default limits on LBC sent out of user wallets, in LBC.
+type SpendingLimitsConfig struct {
+	Daily          string
+	PerTransaction string
+}
+
+// AuthCacheConfig contains settings for caching internal-apis authentication results.
+type AuthCacheConfig struct {
+	TTL         time.Duration
+	NegativeTTL time.Duration
+	MaxSize     int
+}
+
+// JWTIssuerConfig contains keys tokens of a single JWT issuer are verified with
+// and SDK methods they are limited to. Tokens can be used with all methods if Scopes are empty.
+type JWTIssuerConfig struct {
+	Issuer   string
+	Secrets  []string
+	KeysFile string
+	Scopes   []string
+}
+
+// JWTConfig contains settings for authenticating users by JWTs issued to first-party and partner apps.
+type JWTConfig struct {
+	Issuers   []JWTIssuerConfig
+	Audience  string
+	UserClaim string
+	Leeway    time.Duration
+}
+
+// UserActivityConfig contains settings for recording when and from where users were last seen.
+type UserActivityConfig struct {
+	Interval  time.Duration
+	QueueSize int
+}
+
+// GuestsConfig contains settings for guest wallets of users who are not logged in.
+type GuestsConfig struct {
+	Secret           string
+	TTL              time.Duration
+	HourlyLimitPerIP int
+}
+
+// UserDeletionConfig contains settings for erasing user data.
+type UserDeletionConfig struct {
+	ExportDir  string
+	WalletsDir string
+	ArchiveDir string
+}
+
+// MaintenanceConfig contains maintenance mode settings.
+type MaintenanceConfig struct {
+	Enabled bool
+	Message string
+	ETA     time.Time
+}
+
+// WalletLockConfig contains settings for serializing wallet-mutating calls.
+type WalletLockConfig struct {
+	Enabled   bool
+	QueueSize int
+	Timeout   time.Duration
+	MaxHeld   int
+}
+
 var once sync.Once
 var Config *ConfigWrapper
 
@@ -63,6 +152,39 @@ func (c *ConfigWrapper) Init() {
 	c.Viper.SetDefault("AccountsEnabled", false)
 	c.Viper.BindEnv("AccountsEnabled")
 
+	c.Viper.SetDefault("RecorderMaxSize", 100*1024*1024)
+	c.Viper.SetDefault("RecorderSampleRate", 1.0)
+
+	c.Viper.SetDefault("ShadowPercentage", 1.0)
+	c.Viper.SetDefault("ShadowConcurrency", 10)
+	c.Viper.SetDefault("ShadowTimeout", 30*time.Second)
+
+	c.Viper.SetDefault("WalletIdleTimeout", time.Hour)
+	c.Viper.SetDefault("WalletMaxLoaded", 0)
+	c.Viper.SetDefault("WalletCheckInterval", time.Minute)
+
+	c.Viper.SetDefault("WalletLockEnabled", true)
+	c.Viper.SetDefault("WalletLockQueueSize", 10)
+	c.Viper.SetDefault("WalletLockTimeout", 30*time.Second)
+	c.Viper.SetDefault("WalletLockMaxHeld", 20)
+
+	c.Viper.SetDefault("IdempotencyKeyRetention", 24*time.Hour)
+
+	c.Viper.SetDefault("AuthCacheTTL", 5*time.Minute)
+	c.Viper.SetDefault("AuthCacheNegativeTTL", 30*time.Second)
+	c.Viper.SetDefault("AuthCacheMaxSize", 100000)
+
+	c.Viper.SetDefault("AuthFallbackGracePeriod", 24*time.Hour)
+
+	c.Viper.SetDefault("JWTUserClaim", "sub")
+	c.Viper.SetDefault("JWTLeeway", time.Minute)
+
+	c.Viper.SetDefault("UserActivityInterval", time.Minute)
+	c.Viper.SetDefault("UserActivityQueueSize", 1000)
+
+	c.Viper.SetDefault("GuestTTL", 30*24*time.Hour)
+	c.Viper.SetDefault("GuestHourlyLimitPerIP", 10)
+
 	c.Viper.SetConfigName("lbrytv") // name of config file (without extension)
 
 	c.Viper.AddConfigPath(os.Getenv("LBRYTV_CONFIG_DIR"))
@@ -156,6 +278,150 @@ func GetDatabase() DBConfig {
 	return config
 }
 
+// GetRecorder returns proxied traffic recorder config. Recording is disabled if Path is empty.
+func GetRecorder() RecorderConfig {
+	return RecorderConfig{
+		Path:       Config.Viper.GetString("RecorderPath"),
+		MaxSize:    Config.Viper.GetInt64("RecorderMaxSize"),
+		SampleRate: Config.Viper.GetFloat64("RecorderSampleRate"),
+	}
+}
+
+// GetShadow returns canary SDK traffic mirroring config. Mirroring is disabled if Endpoint is empty.
+func GetShadow() ShadowConfig {
+	return ShadowConfig{
+		Endpoint:    Config.Viper.GetString("ShadowEndpoint"),
+		Percentage:  Config.Viper.GetFloat64("ShadowPercentage"),
+		Concurrency: Config.Viper.GetInt("ShadowConcurrency"),
+		Timeout:     Config.Viper.GetDuration("ShadowTimeout"),
+	}
+}
+
+// GetWalletTracker returns wallet unloading config. Unloading is disabled if both IdleTimeout and MaxLoaded are 0.
+func GetWalletTracker() WalletTrackerConfig {
+	return WalletTrackerConfig{
+		IdleTimeout:   Config.Viper.GetDuration("WalletIdleTimeout"),
+		MaxLoaded:     Config.Viper.GetInt("WalletMaxLoaded"),
+		CheckInterval: Config.Viper.GetDuration("WalletCheckInterval"),
+	}
+}
+
+// GetIdempotencyKeyRetention returns for how long responses to calls made with idempotency keys are stored.
+// Idempotency keys are ignored if it's 0.
+func GetIdempotencyKeyRetention() time.Duration {
+	return Config.Viper.GetDuration("IdempotencyKeyRetention")
+}
+
+// GetSpendingLimits returns default spending limits, empty values mean there is no default limit.
+// Limits set for specific users are checked regardless.
+func GetSpendingLimits() SpendingLimitsConfig {
+	return SpendingLimitsConfig{
+		Daily:          Config.Viper.GetString("SpendingLimitDaily"),
+		PerTransaction: Config.Viper.GetString("SpendingLimitPerTransaction"),
+	}
+}
+
+// GetAuthCache returns internal-apis authentication cache config. Caching is disabled if TTL is 0.
+func GetAuthCache() AuthCacheConfig {
+	return AuthCacheConfig{
+		TTL:         Config.Viper.GetDuration("AuthCacheTTL"),
+		NegativeTTL: Config.Viper.GetDuration("AuthCacheNegativeTTL"),
+		MaxSize:     Config.Viper.GetInt("AuthCacheMaxSize"),
+	}
+}
+
+// GetAuthFallbackGracePeriod returns for how long tokens verified by internal-apis keep authenticating users
+// while internal-apis is unavailable. Fallback is disabled if it's 0.
+func GetAuthFallbackGracePeriod() time.Duration {
+	return Config.Viper.GetDuration("AuthFallbackGracePeriod")
+}
+
+// GetTrustedProxies returns IP addresses or CIDR ranges of proxies in front of lbrytv. Client addresses
+// they add to X-Forwarded-For are used when checking API key allowlists.
+func GetTrustedProxies() []string {
+	return Config.Viper.GetStringSlice("TrustedProxies")
+}
+
+// GetJWT returns JWT authentication config. JWT authentication is disabled if there are no issuers.
+func GetJWT() JWTConfig {
+	var issuers []JWTIssuerConfig
+	Config.Viper.UnmarshalKey("JWTIssuers", &issuers)
+	return JWTConfig{
+		Issuers:   issuers,
+		Audience:  Config.Viper.GetString("JWTAudience"),
+		UserClaim: Config.Viper.GetString("JWTUserClaim"),
+		Leeway:    Config.Viper.GetDuration("JWTLeeway"),
+	}
+}
+
+// GetUserActivity returns user activity recording config. Recording is disabled if Interval is 0.
+func GetUserActivity() UserActivityConfig {
+	return UserActivityConfig{
+		Interval:  Config.Viper.GetDuration("UserActivityInterval"),
+		QueueSize: Config.Viper.GetInt("UserActivityQueueSize"),
+	}
+}
+
+// GetGuests returns guest wallets config. Guest wallets are disabled if Secret is empty.
+func GetGuests() GuestsConfig {
+	return GuestsConfig{
+		Secret:           Config.Viper.GetString("GuestSecret"),
+		TTL:              Config.Viper.GetDuration("GuestTTL"),
+		HourlyLimitPerIP: Config.Viper.GetInt("GuestHourlyLimitPerIP"),
+	}
+}
+
+// GetUserDeletion returns user data erasure config. Wallet files are left in place if WalletsDir is empty
+// and deleted instead of being archived if ArchiveDir is empty.
+func GetUserDeletion() UserDeletionConfig {
+	return UserDeletionConfig{
+		ExportDir:  Config.Viper.GetString("UserExportDir"),
+		WalletsDir: GetLbrynetWalletsDir(),
+		ArchiveDir: Config.Viper.GetString("WalletArchiveDir"),
+	}
+}
+
+// GetLbrynetWalletsDir returns the SDK wallets directory if lbrytv has access to it.
+func GetLbrynetWalletsDir() string {
+	return Config.Viper.GetString("LbrynetWalletsDir")
+}
+
+// GetWalletMigrationEscrowKey returns hex-encoded AES-256 key for encrypting seeds of legacy accounts
+// while they're moved to user wallets.
+func GetWalletMigrationEscrowKey() string {
+	return Config.Viper.GetString("WalletMigrationEscrowKey")
+}
+
+// GetMaintenance returns maintenance mode settings. ETA is zero if it's not set.
+func GetMaintenance() MaintenanceConfig {
+	return MaintenanceConfig{
+		Enabled: Config.Viper.GetBool("MaintenanceEnabled"),
+		Message: Config.Viper.GetString("MaintenanceMessage"),
+		ETA:     Config.Viper.GetTime("MaintenanceETA"),
+	}
+}
+
+// GetAdminToken returns token required for accessing admin API, which is disabled if the token is empty.
+func GetAdminToken() string {
+	return Config.Viper.GetString("AdminToken")
+}
+
+// OnReload calls f every time the config file is changed.
+func OnReload(f func()) {
+	Config.Viper.OnConfigChange(func(fsnotify.Event) { f() })
+	Config.Viper.WatchConfig()
+}
+
+// GetWalletLock returns settings for serializing wallet-mutating SDK calls per wallet.
+func GetWalletLock() WalletLockConfig {
+	return WalletLockConfig{
+		Enabled:   Config.Viper.GetBool("WalletLockEnabled"),
+		QueueSize: Config.Viper.GetInt("WalletLockQueueSize"),
+		Timeout:   Config.Viper.GetDuration("WalletLockTimeout"),
+		MaxHeld:   Config.Viper.GetInt("WalletLockMaxHeld"),
+	}
+}
+
 // GetSentryDSN returns sentry.io service DSN
 func GetSentryDSN() string {
 	return Config.Viper.GetString("SentryDSN")
@@ -1,9 +1,12 @@
 package config
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestOverride(t *testing.T) {
@@ -23,3 +26,25 @@ func TestIsProduction(t *testing.T) {
 	assert.False(t, IsProduction())
 	defer RestoreOverridden()
 }
+
+func TestGetJWT(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	require.NoError(t, v.ReadConfig(strings.NewReader(`
+JWTIssuers:
+  - issuer: https://lbry.tv
+    secrets:
+      - secret
+  - issuer: https://partner.example.com
+    keysfile: /etc/lbrytv/partner-jwks.json
+    scopes:
+      - resolve
+`)))
+	Override("JWTIssuers", v.Get("JWTIssuers"))
+	defer RestoreOverridden()
+
+	assert.Equal(t, []JWTIssuerConfig{
+		{Issuer: "https://lbry.tv", Secrets: []string{"secret"}},
+		{Issuer: "https://partner.example.com", KeysFile: "/etc/lbrytv/partner-jwks.json", Scopes: []string{"resolve"}},
+	}, GetJWT().Issuers)
+}
@@ -2,11 +2,14 @@ module github.com/lbryio/lbrytv
 
 require (
 	github.com/aws/aws-sdk-go v1.23.19 // indirect
-	github.com/btcsuite/btcd v0.0.0-20190824003749-130ea5bddde3 // indirect
+	github.com/btcsuite/btcd v0.0.0-20190824003749-130ea5bddde3
+	github.com/ericlagergren/decimal v0.0.0-20190204014639-71cf34b7c2b5 // indirect
+	github.com/fsnotify/fsnotify v1.4.7
 	github.com/getsentry/sentry-go v0.3.0
 	github.com/gobuffalo/packr v1.30.1 // indirect
 	github.com/gobuffalo/packr/v2 v2.5.1
 	github.com/gofrs/uuid v3.2.0+incompatible // indirect
+	github.com/golang/protobuf v1.3.2
 	github.com/google/go-cmp v0.3.1 // indirect
 	github.com/google/martian v2.1.0+incompatible
 	github.com/gorilla/mux v1.7.3
@@ -16,9 +19,9 @@ require (
 	github.com/kat-co/vala v0.0.0-20170210184112-42e1d8b61f12
 	github.com/lbryio/lbry.go v1.1.2
 	github.com/lbryio/lbry.go/v2 v2.3.1
+	github.com/lbryio/lbryschema.go v0.0.0-20190602173230-6d2f69a36f46
 	github.com/lbryio/reflector.go v1.1.0
-
-	github.com/lbryio/types v0.0.0-20190715201353-fe180635eafe // indirect
+	github.com/lbryio/types v0.0.0-20190715201353-fe180635eafe
 	github.com/lib/pq v1.1.1
 	github.com/magiconair/properties v1.8.1 // indirect
 	github.com/nlopes/slack v0.6.0 // indirect
@@ -28,7 +31,7 @@ require (
 	github.com/pingcap/errors v0.11.4 // indirect
 	github.com/pkg/errors v0.8.1
 	github.com/prometheus/client_golang v1.1.0
-	github.com/prometheus/client_model v0.0.0-20190812154241-14fe0d1b01d4 // indirect
+	github.com/prometheus/client_model v0.0.0-20190812154241-14fe0d1b01d4
 	github.com/prometheus/procfs v0.0.4 // indirect
 	github.com/rogpeppe/go-internal v1.3.2 // indirect
 	github.com/rubenv/sql-migrate v0.0.0-20190618074426-f4d34eae5a5c
@@ -40,6 +43,7 @@ require (
 	github.com/spf13/viper v1.4.0
 	github.com/stretchr/testify v1.4.0
 	github.com/uber-go/atomic v1.4.0 // indirect
+	github.com/vmihailenco/msgpack v4.0.4+incompatible
 	github.com/volatiletech/inflect v0.0.0-20170731032912-e7201282ae8d // indirect
 	github.com/volatiletech/null v8.0.0+incompatible
 	github.com/volatiletech/sqlboiler v3.4.0+incompatible
@@ -52,6 +56,7 @@ require (
 	gopkg.in/DATA-DOG/go-sqlmock.v1 v1.3.0 // indirect
 	gopkg.in/check.v1 v1.0.0-20190902080502-41f04d3bba15 // indirect
 	gopkg.in/gorp.v1 v1.7.2 // indirect
+	gopkg.in/square/go-jose.v2 v2.5.1
 )
 
 go 1.13
@@ -0,0 +1,36 @@
+package lbrynet
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DeweysPerLBC is the number of the smallest LBC units in one LBC.
+const DeweysPerLBC = 100000000
+
+var reLBCAmount = regexp.MustCompile(`^(\d+)(?:\.(\d{1,8}))?$`)
+
+// ParseLBC converts LBC amount like "1.5", as accepted and returned by the SDK, into deweys.
+func ParseLBC(amount string) (int64, error) {
+	m := reLBCAmount.FindStringSubmatch(amount)
+	if m == nil {
+		return 0, fmt.Errorf("invalid LBC amount: %v", amount)
+	}
+	whole, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil || whole > (1<<63-1)/DeweysPerLBC {
+		return 0, fmt.Errorf("invalid LBC amount: %v", amount)
+	}
+	var fraction int64
+	if m[2] != "" {
+		fraction, _ = strconv.ParseInt(m[2]+strings.Repeat("0", 8-len(m[2])), 10, 64)
+	}
+	return whole*DeweysPerLBC + fraction, nil
+}
+
+// FormatLBC converts deweys into LBC amount string.
+func FormatLBC(deweys int64) string {
+	s := fmt.Sprintf("%v.%08d", deweys/DeweysPerLBC, deweys%DeweysPerLBC)
+	return strings.TrimRight(strings.TrimRight(s, "0"), ".")
+}
@@ -0,0 +1,31 @@
+package lbrynet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLBC(t *testing.T) {
+	cases := []struct {
+		amount string
+		deweys int64
+	}{
+		{"1", 100000000},
+		{"1.5", 150000000},
+		{"0.00000001", 1},
+		{"12.345", 1234500000},
+	}
+	for _, c := range cases {
+		deweys, err := ParseLBC(c.amount)
+		require.NoError(t, err, c.amount)
+		assert.Equal(t, c.deweys, deweys, c.amount)
+		assert.Equal(t, c.amount, FormatLBC(deweys))
+	}
+
+	for _, amount := range []string{"", "-1", "1.000000001", "1e5", "abc", "99999999999999999999"} {
+		_, err := ParseLBC(amount)
+		assert.Error(t, err, amount)
+	}
+}
@@ -0,0 +1,38 @@
+package lbrynet
+
+import (
+	"fmt"
+)
+
+const guestWalletNameTemplate string = "lbrytv-guest.%v.wallet"
+
+// MakeGuestWalletID formats guest ID to use as an SDK wallet ID.
+// Guest wallet IDs never clash with user wallet IDs made by MakeWalletID.
+func MakeGuestWalletID(gid int) string {
+	return fmt.Sprintf(guestWalletNameTemplate, gid)
+}
+
+// InitializeGuestWallet creates a guest wallet or loads it if it exists already,
+// so it can be immediately used in subsequent commands.
+// UID of returned wallet errors contains guest ID.
+func InitializeGuestWallet(gid int) (string, error) {
+	wid := MakeGuestWalletID(gid)
+	if err := InitializeWalletByID(wid, gid); err != nil {
+		return "", err
+	}
+	return wid, nil
+}
+
+// RemoveGuestWallet unloads guest wallet from the SDK. Wallets which are not loaded are not considered an error.
+func RemoveGuestWallet(gid int) error {
+	return RemoveWalletByID(MakeGuestWalletID(gid), gid)
+}
+
+// ParseGuestWalletID returns guest ID from an SDK wallet ID made by MakeGuestWalletID.
+func ParseGuestWalletID(wid string) (int, error) {
+	var gid int
+	if _, err := fmt.Sscanf(wid, guestWalletNameTemplate, &gid); err != nil {
+		return 0, fmt.Errorf("cannot parse guest wallet ID %v: %v", wid, err)
+	}
+	return gid, nil
+}
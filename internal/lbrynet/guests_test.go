@@ -0,0 +1,45 @@
+package lbrynet
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitializeGuestWallet(t *testing.T) {
+	gid := rand.Int()
+
+	wid, err := InitializeGuestWallet(gid)
+	require.Nil(t, err)
+	assert.Equal(t, MakeGuestWalletID(gid), wid)
+	assert.NotEqual(t, MakeWalletID(gid), wid)
+
+	// Already loaded
+	wid, err = InitializeGuestWallet(gid)
+	require.Nil(t, err)
+	assert.Equal(t, MakeGuestWalletID(gid), wid)
+
+	require.Nil(t, RemoveGuestWallet(gid))
+	require.Nil(t, RemoveGuestWallet(gid))
+
+	// Needs loading
+	wid, err = InitializeGuestWallet(gid)
+	require.Nil(t, err)
+	assert.Equal(t, MakeGuestWalletID(gid), wid)
+
+	var wallets []map[string]interface{}
+	require.Nil(t, Call("wallet_list", map[string]interface{}{"wallet_id": wid}, &wallets))
+	require.Len(t, wallets, 1)
+	assert.Equal(t, wid, wallets[0]["id"])
+}
+
+func TestParseGuestWalletID(t *testing.T) {
+	gid, err := ParseGuestWalletID(MakeGuestWalletID(42))
+	require.NoError(t, err)
+	assert.Equal(t, 42, gid)
+
+	_, err = ParseGuestWalletID(MakeWalletID(42))
+	assert.Error(t, err)
+}
@@ -32,6 +32,24 @@ func MakeWalletID(uid int) string {
 	return fmt.Sprintf(walletNameTemplate, uid)
 }
 
+// ParseWalletID returns user ID from an SDK wallet ID made by MakeWalletID.
+func ParseWalletID(wid string) (int, error) {
+	var uid int
+	if _, err := fmt.Sscanf(wid, walletNameTemplate, &uid); err != nil {
+		return 0, fmt.Errorf("cannot parse wallet ID %v: %v", wid, err)
+	}
+	return uid, nil
+}
+
+// ParseAccountName returns user ID from a legacy SDK account name made by MakeAccountName.
+func ParseAccountName(name string) (int, error) {
+	var uid int
+	if _, err := fmt.Sscanf(name, accountNameTemplate, &uid); err != nil {
+		return 0, fmt.Errorf("cannot parse account name %v: %v", name, err)
+	}
+	return uid, nil
+}
+
 // GetAccount finds account in account_list by UID
 func GetAccount(uid int) (*ljsonrpc.Account, error) {
 	requiredAccountName := MakeAccountName(uid)
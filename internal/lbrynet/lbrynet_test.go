@@ -9,6 +9,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/lbryio/lbrytv/internal/lbrynettest"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,7 +26,9 @@ func generateTestUID() int {
 
 func TestMain(m *testing.M) {
 	rand.Seed(time.Now().UnixNano())
+	_, sdkCleanup := lbrynettest.UseTestServer(&Client)
 	code := m.Run()
+	sdkCleanup()
 	os.Exit(code)
 }
 
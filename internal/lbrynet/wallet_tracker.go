@@ -0,0 +1,272 @@
+package lbrynet
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/metrics"
+	"github.com/lbryio/lbrytv/internal/monitor"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Reasons for unloading wallets as reported in WalletUnloadsTotal.
+const (
+	UnloadReasonIdle     = "idle"
+	UnloadReasonCapacity = "capacity"
+)
+
+// Wallets tracks wallets loaded in the SDK, it is nil when wallet unloading is disabled.
+var Wallets *WalletTracker
+
+var (
+	// LoadedWallets is the number of wallets currently loaded in the SDK by the tracker.
+	LoadedWallets = prometheus.NewGauge(prometheus.GaugeOpts{
+		Subsystem: "lbrynet",
+		Name:      "wallets_loaded",
+		Help:      "Number of wallets currently loaded in the SDK.",
+	})
+	// WalletReloadDurations observes time it takes to load a previously unloaded wallet back into the SDK.
+	WalletReloadDurations = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Subsystem: "lbrynet",
+		Name:      "wallet_reload_seconds",
+		Help:      "Time to load an unloaded wallet back into the SDK.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	})
+	// WalletUnloadsTotal counts wallets unloaded from the SDK by reason.
+	WalletUnloadsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: "lbrynet",
+		Name:      "wallet_unloads_total",
+		Help:      "Number of wallets unloaded from the SDK by reason.",
+	}, []string{"reason"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(LoadedWallets, WalletReloadDurations, WalletUnloadsTotal)
+}
+
+// WalletTrackerOpts contains wallet unloading settings.
+type WalletTrackerOpts struct {
+	// IdleTimeout is for how long a wallet can stay unused before being unloaded, 0 disables idle unloading.
+	IdleTimeout time.Duration
+	// MaxLoaded is the maximum number of loaded wallets, least recently used wallets are unloaded
+	// when it is exceeded. 0 means no limit.
+	MaxLoaded int
+	// CheckInterval is how often idle wallets are looked for.
+	CheckInterval time.Duration
+}
+
+type trackedWallet struct {
+	id       string
+	lastUsed time.Time
+}
+
+// WalletTracker keeps track of wallet usage and unloads idle or least recently used wallets from the SDK.
+// Unloaded wallets are loaded back on their next use. Wallets are tracked by SDK wallet ID,
+// so default, additional and guest wallets are all handled the same way.
+type WalletTracker struct {
+	opts WalletTrackerOpts
+
+	mu    sync.Mutex
+	lru   *list.List
+	items map[string]*list.Element
+	// unloading contains wallets being removed from the SDK, channels are closed once removal is done
+	unloading map[string]chan struct{}
+
+	stop   chan struct{}
+	logger monitor.ModuleLogger
+}
+
+// NewWalletTracker creates a wallet tracker, Start needs to be called to enable idle wallets unloading.
+func NewWalletTracker(opts WalletTrackerOpts) *WalletTracker {
+	if opts.CheckInterval == 0 {
+		opts.CheckInterval = time.Minute
+	}
+	return &WalletTracker{
+		opts:      opts,
+		lru:       list.New(),
+		items:     map[string]*list.Element{},
+		unloading: map[string]chan struct{}{},
+		stop:      make(chan struct{}),
+		logger:    monitor.NewModuleLogger("wallet_tracker"),
+	}
+}
+
+// Seed starts tracking wallets which are already loaded in the SDK, for example after lbrytv restart.
+func (t *WalletTracker) Seed() error {
+	wallets, err := Client.WalletList("")
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	for _, w := range *wallets {
+		if !IsLbrytvWalletID(w.ID) {
+			continue
+		}
+		t.add(w.ID)
+	}
+	t.mu.Unlock()
+	t.logger.Log().Infof("tracking %v loaded wallets", t.Count())
+	t.evictOverCapacity()
+	return nil
+}
+
+// Start launches a goroutine periodically unloading idle wallets.
+func (t *WalletTracker) Start() {
+	if t.opts.IdleTimeout == 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(t.opts.CheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				t.UnloadIdle()
+			case <-t.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops idle wallets unloading.
+func (t *WalletTracker) Stop() {
+	close(t.stop)
+}
+
+// Count returns the number of wallets loaded in the SDK.
+func (t *WalletTracker) Count() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.lru.Len()
+}
+
+// MarkLoaded records wallet as loaded and used without making any SDK calls,
+// it should be called after a wallet is created or loaded outside of the tracker.
+func (t *WalletTracker) MarkLoaded(wid string) {
+	t.mu.Lock()
+	t.add(wid)
+	t.mu.Unlock()
+	t.evictOverCapacity()
+}
+
+// Touch records wallet use, loading it back into the SDK if it had been unloaded.
+// Touch should be called before any SDK call involving the wallet.
+func (t *WalletTracker) Touch(wid string) error {
+	t.mu.Lock()
+	if el, ok := t.items[wid]; ok {
+		el.Value.(*trackedWallet).lastUsed = time.Now()
+		t.lru.MoveToFront(el)
+		t.mu.Unlock()
+		return nil
+	}
+	pending := t.unloading[wid]
+	t.mu.Unlock()
+
+	// Wallet could be in the middle of being removed, loading it concurrently could leave it unloaded
+	if pending != nil {
+		<-pending
+	}
+
+	start := time.Now()
+	if err := LoadWalletByID(wid, walletOwnerID(wid)); err != nil {
+		return err
+	}
+	WalletReloadDurations.Observe(time.Since(start).Seconds())
+	t.logger.LogF(monitor.F{"wallet_id": wid, "duration": time.Since(start).Seconds()}).Info("wallet reloaded")
+
+	t.MarkLoaded(wid)
+	return nil
+}
+
+// Forget stops tracking the wallet without unloading it, it should be called after the wallet
+// has been removed from the SDK outside of the tracker.
+func (t *WalletTracker) Forget(wid string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if el, ok := t.items[wid]; ok {
+		t.lru.Remove(el)
+		delete(t.items, wid)
+		LoadedWallets.Set(float64(t.lru.Len()))
+	}
+}
+
+// UnloadIdle unloads wallets which haven't been used for longer than IdleTimeout.
+func (t *WalletTracker) UnloadIdle() {
+	var idle []string
+	threshold := time.Now().Add(-t.opts.IdleTimeout)
+
+	t.mu.Lock()
+	for el := t.lru.Back(); el != nil; el = el.Prev() {
+		w := el.Value.(*trackedWallet)
+		if w.lastUsed.After(threshold) {
+			break
+		}
+		idle = append(idle, w.id)
+	}
+	for _, wid := range idle {
+		t.remove(wid)
+	}
+	t.mu.Unlock()
+
+	for _, wid := range idle {
+		t.unload(wid, UnloadReasonIdle)
+	}
+}
+
+func (t *WalletTracker) evictOverCapacity() {
+	if t.opts.MaxLoaded == 0 {
+		return
+	}
+	var evicted []string
+
+	t.mu.Lock()
+	for t.lru.Len() > t.opts.MaxLoaded {
+		wid := t.lru.Back().Value.(*trackedWallet).id
+		t.remove(wid)
+		evicted = append(evicted, wid)
+	}
+	t.mu.Unlock()
+
+	for _, wid := range evicted {
+		t.unload(wid, UnloadReasonCapacity)
+	}
+}
+
+// add should be called with mu locked.
+func (t *WalletTracker) add(wid string) {
+	if el, ok := t.items[wid]; ok {
+		el.Value.(*trackedWallet).lastUsed = time.Now()
+		t.lru.MoveToFront(el)
+		return
+	}
+	t.items[wid] = t.lru.PushFront(&trackedWallet{id: wid, lastUsed: time.Now()})
+	LoadedWallets.Set(float64(t.lru.Len()))
+}
+
+// remove stops tracking the wallet and marks it as being unloaded, it should be called with mu locked.
+func (t *WalletTracker) remove(wid string) {
+	t.lru.Remove(t.items[wid])
+	delete(t.items, wid)
+	t.unloading[wid] = make(chan struct{})
+	LoadedWallets.Set(float64(t.lru.Len()))
+}
+
+func (t *WalletTracker) unload(wid string, reason string) {
+	log := t.logger.LogF(monitor.F{"wallet_id": wid, "reason": reason})
+	err := RemoveWalletByID(wid, walletOwnerID(wid))
+	if err != nil {
+		// Wallet is considered unloaded anyway, it will be loaded again on the next use
+		log.Errorf("error unloading wallet: %v", err)
+	} else {
+		log.Info("wallet unloaded")
+	}
+	WalletUnloadsTotal.WithLabelValues(reason).Inc()
+
+	t.mu.Lock()
+	close(t.unloading[wid])
+	delete(t.unloading, wid)
+	t.mu.Unlock()
+}
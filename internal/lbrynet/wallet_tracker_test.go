@@ -0,0 +1,157 @@
+package lbrynet
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func isWalletLoaded(t *testing.T, wid string) bool {
+	wallets, err := Client.WalletList("")
+	require.NoError(t, err)
+	for _, w := range *wallets {
+		if w.ID == wid {
+			return true
+		}
+	}
+	return false
+}
+
+func TestWalletTrackerUnloadIdle(t *testing.T) {
+	tr := NewWalletTracker(WalletTrackerOpts{IdleTimeout: 50 * time.Millisecond})
+	idleBefore := testutil.ToFloat64(WalletUnloadsTotal.WithLabelValues(UnloadReasonIdle))
+
+	idle, active := MakeWalletID(rand.Int()), MakeWalletID(rand.Int())
+	for _, wid := range []string{idle, active} {
+		_, err := InitializeWallet(walletOwnerID(wid))
+		require.NoError(t, err)
+		tr.MarkLoaded(wid)
+	}
+	time.Sleep(60 * time.Millisecond)
+	require.NoError(t, tr.Touch(active))
+
+	tr.UnloadIdle()
+	assert.False(t, isWalletLoaded(t, idle))
+	assert.True(t, isWalletLoaded(t, active))
+	assert.Equal(t, 1, tr.Count())
+	assert.Equal(t, 1.0, testutil.ToFloat64(LoadedWallets))
+	assert.Equal(t, idleBefore+1, testutil.ToFloat64(WalletUnloadsTotal.WithLabelValues(UnloadReasonIdle)))
+
+	// Unloaded wallet gets loaded back on its next use
+	require.NoError(t, tr.Touch(idle))
+	assert.True(t, isWalletLoaded(t, idle))
+	assert.Equal(t, 2, tr.Count())
+}
+
+func TestWalletTrackerMaxLoaded(t *testing.T) {
+	tr := NewWalletTracker(WalletTrackerOpts{MaxLoaded: 2})
+	capacityBefore := testutil.ToFloat64(WalletUnloadsTotal.WithLabelValues(UnloadReasonCapacity))
+
+	uids := []int{rand.Int(), rand.Int(), rand.Int()}
+	for _, uid := range uids[:2] {
+		_, err := InitializeWallet(uid)
+		require.NoError(t, err)
+		tr.MarkLoaded(MakeWalletID(uid))
+	}
+	// First wallet becomes the most recently used one
+	require.NoError(t, tr.Touch(MakeWalletID(uids[0])))
+
+	_, err := InitializeWallet(uids[2])
+	require.NoError(t, err)
+	tr.MarkLoaded(MakeWalletID(uids[2]))
+
+	assert.Equal(t, 2, tr.Count())
+	assert.True(t, isWalletLoaded(t, MakeWalletID(uids[0])))
+	assert.False(t, isWalletLoaded(t, MakeWalletID(uids[1])))
+	assert.True(t, isWalletLoaded(t, MakeWalletID(uids[2])))
+	assert.Equal(t, capacityBefore+1, testutil.ToFloat64(WalletUnloadsTotal.WithLabelValues(UnloadReasonCapacity)))
+}
+
+func TestWalletTrackerTouchReloads(t *testing.T) {
+	tr := NewWalletTracker(WalletTrackerOpts{})
+	uid := rand.Int()
+
+	_, err := InitializeWallet(uid)
+	require.NoError(t, err)
+	_, err = WalletRemove(uid)
+	require.NoError(t, err)
+
+	require.NoError(t, tr.Touch(MakeWalletID(uid)))
+	assert.True(t, isWalletLoaded(t, MakeWalletID(uid)))
+
+	// Wallet loaded outside of the tracker is not an error
+	tr = NewWalletTracker(WalletTrackerOpts{})
+	require.NoError(t, tr.Touch(MakeWalletID(uid)))
+	assert.Equal(t, 1, tr.Count())
+}
+
+func TestWalletTrackerTouchNonexistent(t *testing.T) {
+	tr := NewWalletTracker(WalletTrackerOpts{})
+	err := tr.Touch(MakeWalletID(rand.Int()))
+	assert.Error(t, err)
+	assert.Equal(t, 0, tr.Count())
+}
+
+func TestWalletTrackerForget(t *testing.T) {
+	tr := NewWalletTracker(WalletTrackerOpts{})
+	wid := MakeWalletID(rand.Int())
+	tr.MarkLoaded(wid)
+	require.Equal(t, 1, tr.Count())
+
+	tr.Forget(wid)
+	tr.Forget(wid)
+	assert.Equal(t, 0, tr.Count())
+}
+
+func TestWalletTrackerSeed(t *testing.T) {
+	uid := rand.Int()
+	_, err := InitializeWallet(uid)
+	require.NoError(t, err)
+	gwid, err := InitializeGuestWallet(rand.Int())
+	require.NoError(t, err)
+
+	tr := NewWalletTracker(WalletTrackerOpts{})
+	require.NoError(t, tr.Seed())
+	assert.GreaterOrEqual(t, tr.Count(), 2)
+
+	tr.mu.Lock()
+	_, userTracked := tr.items[MakeWalletID(uid)]
+	_, guestTracked := tr.items[gwid]
+	tr.mu.Unlock()
+	assert.True(t, userTracked)
+	assert.True(t, guestTracked)
+}
+
+func TestWalletTrackerUnloadsGuestAndAdditionalWallets(t *testing.T) {
+	tr := NewWalletTracker(WalletTrackerOpts{IdleTimeout: 50 * time.Millisecond})
+
+	uid := rand.Int()
+	awid := MakeAdditionalWalletID(uid, rand.Int())
+	require.NoError(t, InitializeWalletByID(awid, uid))
+	gwid, err := InitializeGuestWallet(rand.Int())
+	require.NoError(t, err)
+	tr.MarkLoaded(awid)
+	tr.MarkLoaded(gwid)
+
+	time.Sleep(60 * time.Millisecond)
+	tr.UnloadIdle()
+	assert.False(t, isWalletLoaded(t, awid))
+	assert.False(t, isWalletLoaded(t, gwid))
+	assert.Equal(t, 0, tr.Count())
+
+	require.NoError(t, tr.Touch(awid))
+	require.NoError(t, tr.Touch(gwid))
+	assert.True(t, isWalletLoaded(t, awid))
+	assert.True(t, isWalletLoaded(t, gwid))
+}
+
+func TestWalletOwnerID(t *testing.T) {
+	assert.Equal(t, 123, walletOwnerID(MakeWalletID(123)))
+	assert.Equal(t, 123, walletOwnerID(MakeAdditionalWalletID(123, 45)))
+	assert.Equal(t, 67, walletOwnerID(MakeGuestWalletID(67)))
+	assert.Equal(t, 0, walletOwnerID("default_wallet"))
+}
@@ -0,0 +1,110 @@
+package lbrynet
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lbryio/lbrytv/config"
+	"github.com/lbryio/lbrytv/internal/monitor"
+
+	"github.com/ybbus/jsonrpc"
+)
+
+const additionalWalletNameTemplate string = "lbrytv-id.%v.%v.wallet"
+
+// MakeAdditionalWalletID formats user ID and wallet record ID to use as an SDK wallet ID
+// for wallets user creates in addition to the one made by MakeWalletID.
+// ParseWalletID doesn't accept IDs made by it.
+func MakeAdditionalWalletID(uid, id int) string {
+	return fmt.Sprintf(additionalWalletNameTemplate, uid, id)
+}
+
+// IsLbrytvWalletID returns true for SDK wallet IDs made by MakeWalletID, MakeAdditionalWalletID or MakeGuestWalletID.
+func IsLbrytvWalletID(wid string) bool {
+	return strings.HasPrefix(wid, "lbrytv-") && strings.HasSuffix(wid, ".wallet")
+}
+
+// walletOwnerID returns user or guest ID from wallet IDs made by MakeWalletID, MakeAdditionalWalletID
+// or MakeGuestWalletID. It's only meant for wallet errors and returns 0 for other wallet IDs.
+func walletOwnerID(wid string) int {
+	var id int
+	for _, prefix := range []string{"lbrytv-id.%d", "lbrytv-guest.%d"} {
+		if _, err := fmt.Sscanf(wid, prefix, &id); err == nil {
+			return id
+		}
+	}
+	return 0
+}
+
+// InitializeWalletByID creates a wallet with a given SDK wallet ID or loads it if it exists already,
+// so it can be immediately used in subsequent commands. UID of returned wallet errors is set to id.
+func InitializeWalletByID(wid string, id int) error {
+	log := logger.LogF(monitor.F{"wallet_id": wid})
+	_, err := Client.WalletCreate(wid, &defaultWalletOpts)
+	if err == nil {
+		log.Info("wallet created")
+		return nil
+	}
+	err = NewWalletError(id, err)
+	if errors.As(err, &WalletExists{}) {
+		return nil
+	} else if !errors.As(err, &WalletNeedsLoading{}) {
+		return err
+	}
+
+	_, err = Client.WalletAdd(wid)
+	if err != nil {
+		err = NewWalletError(id, err)
+		if errors.As(err, &WalletAlreadyLoaded{}) {
+			return nil
+		}
+		return err
+	}
+	log.Info("wallet loaded")
+	return nil
+}
+
+// LoadWalletByID loads an existing wallet with a given SDK wallet ID, wallets which are already loaded
+// are not considered an error. Unlike InitializeWalletByID it never creates a wallet.
+func LoadWalletByID(wid string, id int) error {
+	_, err := Client.WalletAdd(wid)
+	if err != nil {
+		err = NewWalletError(id, err)
+		if errors.As(err, &WalletAlreadyLoaded{}) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// RemoveWalletByID unloads wallet with a given SDK wallet ID. Wallets which are not loaded are not considered an error.
+func RemoveWalletByID(wid string, id int) error {
+	_, err := Client.WalletRemove(wid)
+	if err != nil {
+		err = NewWalletError(id, err)
+		if errors.As(err, &WalletNotLoaded{}) {
+			return nil
+		}
+		return err
+	}
+	logger.LogF(monitor.F{"wallet_id": wid}).Info("wallet removed")
+	return nil
+}
+
+// Call makes an arbitrary SDK call, decoding its result into result.
+// It's meant for methods Client doesn't support with wallet_id.
+func Call(method string, params map[string]interface{}, result interface{}) error {
+	res, err := jsonrpc.NewClient(config.GetLbrynet()).Call(method, params)
+	if err != nil {
+		return err
+	}
+	if res.Error != nil {
+		return fmt.Errorf("error in daemon: %v", res.Error.Message)
+	}
+	if result == nil {
+		return nil
+	}
+	return res.GetObject(result)
+}
@@ -0,0 +1,40 @@
+package lbrynet
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadWalletByID(t *testing.T) {
+	uid, id := rand.Int(), rand.Int()
+	wid := MakeAdditionalWalletID(uid, id)
+
+	assert.Error(t, LoadWalletByID(wid, uid))
+
+	require.Nil(t, InitializeWalletByID(wid, uid))
+	require.Nil(t, LoadWalletByID(wid, uid))
+	require.Nil(t, RemoveWalletByID(wid, uid))
+	require.Nil(t, LoadWalletByID(wid, uid))
+
+	_, err := ParseWalletID(wid)
+	assert.Error(t, err)
+}
+
+func TestIsLbrytvWalletID(t *testing.T) {
+	assert.True(t, IsLbrytvWalletID(MakeWalletID(1)))
+	assert.True(t, IsLbrytvWalletID(MakeAdditionalWalletID(1, 2)))
+	assert.True(t, IsLbrytvWalletID(MakeGuestWalletID(1)))
+	assert.False(t, IsLbrytvWalletID("default_wallet"))
+}
+
+func TestParseAccountName(t *testing.T) {
+	uid, err := ParseAccountName(MakeAccountName(751365))
+	require.NoError(t, err)
+	assert.Equal(t, 751365, uid)
+
+	_, err = ParseAccountName("Account")
+	assert.Error(t, err)
+}
@@ -0,0 +1,91 @@
+package lbrynettest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// params wraps JSON-RPC request params, treating null values the same way as missing ones like SDK does.
+type params struct {
+	method string
+	values map[string]interface{}
+}
+
+func (p params) get(key string) (interface{}, bool) {
+	v, ok := p.values[key]
+	if !ok || v == nil {
+		return nil, false
+	}
+	return v, true
+}
+
+func (p params) string(key string) (string, bool) {
+	v, ok := p.get(key)
+	if !ok {
+		return "", false
+	}
+	switch t := v.(type) {
+	case string:
+		return t, true
+	case json.Number:
+		return t.String(), true
+	default:
+		return fmt.Sprintf("%v", t), true
+	}
+}
+
+// required returns a param value or an error worded the same way Python reports missing arguments.
+func (p params) required(key string) (string, error) {
+	v, ok := p.string(key)
+	if !ok {
+		return "", newError("%v() missing 1 required positional argument: '%v'", p.method, key)
+	}
+	return v, nil
+}
+
+func (p params) bool(key string) bool {
+	v, ok := p.get(key)
+	if !ok {
+		return false
+	}
+	switch t := v.(type) {
+	case bool:
+		return t
+	case string:
+		b, _ := strconv.ParseBool(t)
+		return b
+	}
+	return false
+}
+
+func (p params) int(key string, defaultValue int) int {
+	v, ok := p.string(key)
+	if !ok {
+		return defaultValue
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return defaultValue
+	}
+	return i
+}
+
+// strings returns a list param, accepting a single string in place of a list.
+func (p params) strings(key string) []string {
+	v, ok := p.get(key)
+	if !ok {
+		return nil
+	}
+	switch t := v.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		list := make([]string, 0, len(t))
+		for _, item := range t {
+			list = append(list, fmt.Sprintf("%v", item))
+		}
+		return list
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package lbrynettest
+
+import (
+	"regexp"
+	"strings"
+)
+
+var reClaimID = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// urlPart is a single segment of LBRY URL, either a channel or a stream one.
+type urlPart struct {
+	name     string
+	claimID  string
+	complete bool
+}
+
+func parseURLPart(segment string) urlPart {
+	p := urlPart{name: segment}
+	if i := strings.IndexAny(segment, "#:"); i >= 0 {
+		p.name, p.claimID = segment[:i], segment[i+1:]
+	}
+	p.complete = reClaimID.MatchString(p.claimID)
+	return p
+}
+
+func (p urlPart) matches(c *claim) bool {
+	return !c.abandoned && c.name == p.name && strings.HasPrefix(c.claimID, p.claimID)
+}
+
+// AddClaim puts a stream claim on the fake blockchain so it can be resolved and found with claim_search.
+// If channelName is not empty, the claim is signed by a channel with that name, which is created if needed.
+// Claim ID of the new claim is returned.
+func (s *Server) AddClaim(name, channelName, title string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var channel *claim
+	if channelName != "" {
+		channel = s.lookup(parseURLPart(channelName), nil)
+		if channel == nil {
+			channel = s.syntheticClaim(parseURLPart(channelName))
+		}
+	}
+	c := s.syntheticClaim(urlPart{name: name})
+	c.channel = channel
+	if title != "" {
+		c.value["title"] = title
+	}
+	return c.claimID
+}
+
+// resolve finds a claim for the URL. Since the fake server doesn't have real blockchain data,
+// URLs with complete claim IDs resolve to claims made up on the fly, so production URLs can be used in tests.
+func (s *Server) resolve(url string) *claim {
+	url = strings.TrimPrefix(url, "lbry://")
+	if url == "" {
+		return nil
+	}
+
+	var channelPart *urlPart
+	streamPart := parseURLPart(url)
+	if i := strings.Index(url, "/"); i >= 0 {
+		cp := parseURLPart(url[:i])
+		channelPart = &cp
+		streamPart = parseURLPart(url[i+1:])
+	}
+
+	var channel *claim
+	if channelPart != nil {
+		channel = s.lookup(*channelPart, nil)
+		if channel == nil {
+			if !channelPart.complete {
+				return nil
+			}
+			channel = s.syntheticClaim(*channelPart)
+		}
+	}
+
+	if c := s.lookup(streamPart, channel); c != nil {
+		return c
+	}
+	if !streamPart.complete {
+		return nil
+	}
+	c := s.syntheticClaim(streamPart)
+	c.channel = channel
+	return c
+}
+
+// lookup finds the earliest claim matching the URL part, signed by the channel if it's supplied.
+func (s *Server) lookup(p urlPart, channel *claim) *claim {
+	for _, c := range s.claims {
+		if p.matches(c) && (channel == nil || c.channel == channel) {
+			return c
+		}
+	}
+	return nil
+}
+
+// syntheticClaim creates a claim for a URL part, with the claim ID from the URL if it's complete.
+func (s *Server) syntheticClaim(p urlPart) *claim {
+	var c *claim
+	if strings.HasPrefix(p.name, "@") {
+		c = s.newClaim(p.name, "1.0", valueTypeChannel, map[string]interface{}{
+			"public_key":    s.publicKey(),
+			"public_key_id": s.base58("b", 34),
+			"title":         p.name[1:],
+		})
+	} else {
+		c = s.newClaim(p.name, "1.0", valueTypeStream, map[string]interface{}{
+			"source": map[string]interface{}{
+				"hash":       s.hash("file", 96),
+				"media_type": "video/mp4",
+				"name":       p.name + ".mp4",
+				"sd_hash":    s.hash("sd", 96),
+				"size":       "1048576",
+			},
+			"stream_type": "video",
+			"title":       p.name,
+		})
+	}
+	if p.complete {
+		c.claimID = p.claimID
+	}
+	return c
+}
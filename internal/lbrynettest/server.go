@@ -0,0 +1,219 @@
+// Package lbrynettest provides an in-process fake of lbrynet (LBRY SDK) JSON-RPC API for tests.
+//
+// The fake keeps wallets, accounts and claims in memory and implements the subset of SDK methods
+// lbrytv relies on, returning errors worded the same way the SDK does so error handling code
+// can be exercised too:
+//
+//	sdk := lbrynettest.NewServer()
+//	defer sdk.Close()
+//	svc := proxy.NewService(sdk.URL)
+package lbrynettest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/ybbus/jsonrpc"
+)
+
+// DefaultWalletID is the wallet SDK uses for calls that don't specify wallet_id.
+const DefaultWalletID = "default_wallet"
+
+// WalletsDir is reported as the SDK wallets location in error messages.
+const WalletsDir = "/storage/lbrynet/wallets"
+
+const (
+	errCodeGeneric       = -32500
+	errCodeInvalidMethod = -32601
+)
+
+type handler func(s *Server, p params) (interface{}, error)
+
+var handlers = map[string]handler{
+	"status":  handleStatus,
+	"version": handleVersion,
+
+	"wallet_create": handleWalletCreate,
+	"wallet_add":    handleWalletAdd,
+	"wallet_remove": handleWalletRemove,
+	"wallet_list":   handleWalletList,
+
+	"account_list":    handleAccountList,
+	"account_create":  handleAccountCreate,
+	"account_add":     handleAccountAdd,
+	"account_remove":  handleAccountRemove,
+	"account_balance": handleAccountBalance,
+	"address_unused":  handleAddressUnused,
+
+	"resolve":      handleResolve,
+	"claim_search": handleClaimSearch,
+	"claim_list":   handleClaimList,
+
+	"publish":        handleStreamCreate,
+	"stream_create":  handleStreamCreate,
+	"stream_list":    handleStreamList,
+	"stream_abandon": handleStreamAbandon,
+
+	"channel_create":  handleChannelCreate,
+	"channel_list":    handleChannelList,
+	"channel_abandon": handleChannelAbandon,
+	"channel_export":  handleChannelExport,
+	"channel_import":  handleChannelImport,
+}
+
+// Error is an SDK-level error, returned to the client in JSON-RPC response error field.
+type Error struct {
+	Code    int
+	Message string
+}
+
+func (e Error) Error() string {
+	return e.Message
+}
+
+func newError(format string, args ...interface{}) Error {
+	return Error{Code: errCodeGeneric, Message: fmt.Sprintf(format, args...)}
+}
+
+// Server is a fake SDK JSON-RPC server. Its URL field contains an address to supply to SDK clients.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	seq       int
+	wallets   map[string]*wallet
+	claims    []*claim
+	overrides map[string]*jsonrpc.RPCResponse
+	requests  []*jsonrpc.RPCRequest
+	// headers are HTTP headers of requests, in the same order
+	headers []http.Header
+}
+
+// NewServer starts a fake SDK server with an empty default wallet loaded.
+// Close should be called on the returned server when it's no longer needed.
+func NewServer() *Server {
+	s := &Server{}
+	s.reset()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Reset drops all wallets, claims, response overrides and recorded requests.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reset()
+}
+
+func (s *Server) reset() {
+	s.seq = 0
+	s.wallets = map[string]*wallet{}
+	s.claims = nil
+	s.overrides = map[string]*jsonrpc.RPCResponse{}
+	s.requests = nil
+	s.headers = nil
+
+	w := s.newWallet(DefaultWalletID)
+	w.loaded = true
+	w.addAccount(s.newAccount("Account", true, true))
+}
+
+// SetResult makes the server respond to all subsequent calls of the method with the supplied result.
+func (s *Server) SetResult(method string, result interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.overrides[method] = &jsonrpc.RPCResponse{JSONRPC: "2.0", Result: result}
+}
+
+// SetError makes the server respond to all subsequent calls of the method with the supplied error.
+func (s *Server) SetError(method string, code int, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.overrides[method] = &jsonrpc.RPCResponse{JSONRPC: "2.0", Error: &jsonrpc.RPCError{Code: code, Message: message}}
+}
+
+// ClearResponse removes a response override set by SetResult or SetError.
+func (s *Server) ClearResponse(method string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.overrides, method)
+}
+
+// Requests returns requests received by the server for the method, all requests are returned if method is empty.
+func (s *Server) Requests(method string) []*jsonrpc.RPCRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var requests []*jsonrpc.RPCRequest
+	for _, r := range s.requests {
+		if method == "" || r.Method == method {
+			requests = append(requests, r)
+		}
+	}
+	return requests
+}
+
+// RequestHeaders returns HTTP headers of requests received by the server for the method,
+// headers of all requests are returned if method is empty.
+func (s *Server) RequestHeaders(method string) []http.Header {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var headers []http.Header
+	for i, r := range s.requests {
+		if method == "" || r.Method == method {
+			headers = append(headers, s.headers[i])
+		}
+	}
+	return headers
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var request jsonrpc.RPCRequest
+
+	d := json.NewDecoder(r.Body)
+	d.UseNumber()
+	if err := d.Decode(&request); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	response := s.process(&request, r.Header)
+	response.ID = request.ID
+	response.JSONRPC = "2.0"
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (s *Server) process(request *jsonrpc.RPCRequest, header http.Header) *jsonrpc.RPCResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, request)
+	s.headers = append(s.headers, header)
+	if override, ok := s.overrides[request.Method]; ok {
+		response := *override
+		return &response
+	}
+
+	h, ok := handlers[request.Method]
+	if !ok {
+		return &jsonrpc.RPCResponse{Error: &jsonrpc.RPCError{
+			Code:    errCodeInvalidMethod,
+			Message: fmt.Sprintf("Invalid method requested: %v.", request.Method),
+		}}
+	}
+
+	p, _ := request.Params.(map[string]interface{})
+	result, err := h(s, params{method: request.Method, values: p})
+	if err != nil {
+		e, ok := err.(Error)
+		if !ok {
+			e = Error{Code: errCodeGeneric, Message: err.Error()}
+		}
+		return &jsonrpc.RPCResponse{Error: &jsonrpc.RPCError{Code: e.Code, Message: e.Message}}
+	}
+	return &jsonrpc.RPCResponse{Result: result}
+}
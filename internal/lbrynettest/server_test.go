@@ -0,0 +1,232 @@
+package lbrynettest_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/lbryio/lbrytv/internal/lbrynet"
+	"github.com/lbryio/lbrytv/internal/lbrynettest"
+
+	ljsonrpc "github.com/lbryio/lbry.go/v2/extras/jsonrpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ybbus/jsonrpc"
+)
+
+func TestWalletLifecycle(t *testing.T) {
+	sdk := lbrynettest.NewServer()
+	defer sdk.Close()
+	c := ljsonrpc.NewClient(sdk.URL)
+	wid := lbrynet.MakeWalletID(1)
+
+	w, err := c.WalletCreate(wid, &ljsonrpc.WalletCreateOpts{CreateAccount: true, SingleKey: true})
+	require.NoError(t, err)
+	assert.Equal(t, wid, w.ID)
+
+	_, err = c.WalletCreate(wid, nil)
+	assert.True(t, errors.As(lbrynet.NewWalletError(1, err), &lbrynet.WalletExists{}))
+
+	accounts, err := c.AccountListForWallet(wid)
+	require.NoError(t, err)
+	require.Len(t, accounts.LBCMainnet, 1)
+	assert.Equal(t, "single-address", accounts.LBCMainnet[0].AddressGenerator.Name)
+	assert.True(t, accounts.LBCMainnet[0].IsDefault)
+
+	_, err = c.WalletRemove(wid)
+	require.NoError(t, err)
+
+	_, err = c.AccountListForWallet(wid)
+	assert.True(t, errors.As(lbrynet.NewWalletError(1, err), &lbrynet.WalletNotLoaded{}))
+	_, err = c.WalletRemove(wid)
+	assert.True(t, errors.As(lbrynet.NewWalletError(1, err), &lbrynet.WalletNotLoaded{}))
+	_, err = c.WalletCreate(wid, nil)
+	assert.True(t, errors.As(lbrynet.NewWalletError(1, err), &lbrynet.WalletNeedsLoading{}))
+
+	_, err = c.WalletAdd(wid)
+	require.NoError(t, err)
+	_, err = c.WalletAdd(wid)
+	assert.True(t, errors.As(lbrynet.NewWalletError(1, err), &lbrynet.WalletAlreadyLoaded{}))
+	_, err = c.WalletAdd(lbrynet.MakeWalletID(2))
+	assert.True(t, errors.As(lbrynet.NewWalletError(2, err), &lbrynet.WalletNotFound{}))
+
+	balance, err := c.AccountBalance(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "0", balance.Available.String())
+}
+
+func TestAccounts(t *testing.T) {
+	sdk := lbrynettest.NewServer()
+	defer sdk.Close()
+	c := ljsonrpc.NewClient(sdk.URL)
+
+	acc, err := c.AccountCreate(lbrynet.MakeAccountName(1), true)
+	require.NoError(t, err)
+	require.NotNil(t, acc.Seed)
+
+	accounts, err := c.AccountList()
+	require.NoError(t, err)
+	assert.Len(t, accounts.LBCMainnet, 2)
+
+	single, err := c.SingleAccountList(acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, lbrynet.MakeAccountName(1), single.Name)
+
+	removed, err := c.AccountRemove(acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, *acc.Seed, *removed.Seed)
+
+	_, err = c.AccountRemove(acc.ID)
+	assert.EqualError(t, err, "Error in daemon: Couldn't find account: "+acc.ID+".")
+
+	added, err := c.AccountAdd("restored", acc.Seed, nil, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, *acc.Seed, *added.Seed)
+}
+
+func TestResolve(t *testing.T) {
+	sdk := lbrynettest.NewServer()
+	defer sdk.Close()
+	c := ljsonrpc.NewClient(sdk.URL)
+	url := "what#6769855a9aa43b67086f9ff3c1a5bacb5698a27a"
+
+	r, err := c.Resolve(url)
+	require.NoError(t, err)
+	claim := (*r)[url]
+	assert.Equal(t, "6769855a9aa43b67086f9ff3c1a5bacb5698a27a", claim.ClaimID)
+	assert.Equal(t, "lbry://what#6", claim.CanonicalURL)
+	require.NotNil(t, claim.Value.GetStream())
+	assert.Equal(t, "video/mp4", claim.Value.GetStream().GetSource().GetMediaType())
+
+	// Same claim is returned for a partial claim ID now that it's known
+	r, err = c.Resolve("lbry://what#676")
+	require.NoError(t, err)
+	assert.Equal(t, claim.ClaimID, (*r)["lbry://what#676"].ClaimID)
+
+	r, err = c.Resolve("nonexistent")
+	require.NoError(t, err)
+	assert.Empty(t, (*r)["nonexistent"].ClaimID)
+
+	claimID := sdk.AddClaim("some-video", "@channel", "Some video")
+	r, err = c.Resolve("@channel/some-video")
+	require.NoError(t, err)
+	resolved := (*r)["@channel/some-video"]
+	assert.Equal(t, claimID, resolved.ClaimID)
+	require.NotNil(t, resolved.SigningChannel)
+	assert.Equal(t, "@channel", resolved.SigningChannel.Name)
+	assert.Equal(t, "Some video", resolved.Value.GetTitle())
+
+	name := "some-video"
+	search, err := c.ClaimSearch(&name, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, search.Claims, 1)
+	assert.Equal(t, claimID, search.Claims[0].ClaimID)
+}
+
+func TestPublishAndChannels(t *testing.T) {
+	sdk := lbrynettest.NewServer()
+	defer sdk.Close()
+	c := ljsonrpc.NewClient(sdk.URL)
+	wid := lbrynet.MakeWalletID(1)
+	_, err := c.WalletCreate(wid, &ljsonrpc.WalletCreateOpts{CreateAccount: true, SingleKey: true})
+	require.NoError(t, err)
+
+	rpc := jsonrpc.NewClient(sdk.URL)
+	r, err := rpc.Call("channel_create", map[string]interface{}{"name": "nochannel", "bid": "0.1"})
+	require.NoError(t, err)
+	assert.Equal(t, "Channel names must start with '@' symbol.", r.Error.Message)
+
+	r, err = rpc.Call("channel_create", map[string]interface{}{"name": "@mine", "bid": "0.1", "wallet_id": wid})
+	require.NoError(t, err)
+	require.Nil(t, r.Error)
+	var channelTx ljsonrpc.TransactionSummary
+	require.NoError(t, ljsonrpc.Decode(r.Result, &channelTx))
+	channelID := channelTx.Outputs[0].ClaimID
+
+	r, err = rpc.Call("stream_create", map[string]interface{}{
+		"name": "test", "bid": "0.10000000", "file_path": "/tmp/video.mp4", "title": "test",
+		"channel_id": channelID, "wallet_id": wid,
+	})
+	require.NoError(t, err)
+	require.Nil(t, r.Error)
+	var streamTx ljsonrpc.TransactionSummary
+	require.NoError(t, ljsonrpc.Decode(r.Result, &streamTx))
+	assert.Equal(t, "0.1", streamTx.Outputs[0].Amount)
+	assert.Equal(t, "test", streamTx.Outputs[0].Name)
+
+	r, err = rpc.Call("stream_create", map[string]interface{}{
+		"name": "test", "bid": "0.1", "file_path": "/tmp/video.mp4", "wallet_id": wid,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, r.Error)
+	assert.Contains(t, r.Error.Message, "You already have a stream claim published under the name 'test'")
+
+	resolved, err := c.Resolve("@mine/test")
+	require.NoError(t, err)
+	assert.Equal(t, streamTx.Outputs[0].ClaimID, (*resolved)["@mine/test"].ClaimID)
+
+	channels, err := c.ChannelList(nil, 1, 20, &wid)
+	require.NoError(t, err)
+	require.Len(t, channels.Items, 1)
+	assert.Equal(t, "@mine", channels.Items[0].Name)
+
+	_, err = c.ChannelExport(channelID, nil, nil)
+	assert.EqualError(t, err, "Error in daemon: Couldn't find channel with channel_id '"+channelID+"'.")
+	r, err = rpc.Call("channel_export", map[string]interface{}{"channel_id": channelID, "wallet_id": wid})
+	require.NoError(t, err)
+	require.Nil(t, r.Error)
+
+	otherWid := lbrynet.MakeWalletID(2)
+	_, err = c.WalletCreate(otherWid, &ljsonrpc.WalletCreateOpts{CreateAccount: true})
+	require.NoError(t, err)
+	exported := r.Result.(string)
+	_, err = c.ChannelImport(exported, &otherWid)
+	require.NoError(t, err)
+	channels, err = c.ChannelList(nil, 1, 20, &otherWid)
+	require.NoError(t, err)
+	require.Len(t, channels.Items, 1)
+}
+
+func TestOverridesAndRequests(t *testing.T) {
+	sdk := lbrynettest.NewServer()
+	defer sdk.Close()
+	rpc := jsonrpc.NewClient(sdk.URL)
+
+	r, err := rpc.Call("crazy_method")
+	require.NoError(t, err)
+	assert.Equal(t, "Invalid method requested: crazy_method.", r.Error.Message)
+
+	r, err = rpc.Call("resolve", map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Contains(t, r.Error.Message, "missing 1 required positional argument: 'urls'")
+
+	sdk.SetResult("resolve", map[string]interface{}{"what": json.Number("1")})
+	r, err = rpc.Call("resolve", map[string]interface{}{"urls": "what"})
+	require.NoError(t, err)
+	assert.Nil(t, r.Error)
+	assert.EqualValues(t, map[string]interface{}{"what": json.Number("1")}, r.Result)
+
+	sdk.SetError("resolve", -32500, "oops")
+	r, err = rpc.Call("resolve", map[string]interface{}{"urls": "what"})
+	require.NoError(t, err)
+	assert.Equal(t, "oops", r.Error.Message)
+
+	sdk.ClearResponse("resolve")
+	r, err = rpc.Call("resolve", map[string]interface{}{"urls": "what"})
+	require.NoError(t, err)
+	assert.Nil(t, r.Error)
+
+	assert.Len(t, sdk.Requests("resolve"), 4)
+	assert.Len(t, sdk.Requests(""), 5)
+
+	headered := jsonrpc.NewClientWithOpts(sdk.URL, &jsonrpc.RPCClientOpts{CustomHeaders: map[string]string{"X-Request-Id": "abc"}})
+	_, err = headered.Call("status")
+	require.NoError(t, err)
+	headers := sdk.RequestHeaders("status")
+	require.Len(t, headers, 1)
+	assert.Equal(t, "abc", headers[0].Get("X-Request-Id"))
+	assert.Len(t, sdk.RequestHeaders(""), 6)
+
+	sdk.Reset()
+	assert.Empty(t, sdk.Requests(""))
+}
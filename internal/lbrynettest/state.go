@@ -0,0 +1,310 @@
+package lbrynettest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	schema "github.com/lbryio/lbryschema.go/claim"
+	pb "github.com/lbryio/types/v2/go"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/golang/protobuf/proto"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// blockHeight is reported as the current blockchain height, claims created by the fake server
+// get it as their height and are considered confirmed.
+const blockHeight = 700000
+
+var seedWords = []string{
+	"abandon", "bright", "cargo", "dolphin", "engine", "fossil", "garden", "harbor",
+	"island", "jungle", "kitten", "lumber", "marble", "nectar", "orbit", "pepper",
+}
+
+type wallet struct {
+	id       string
+	loaded   bool
+	accounts []*account
+	// claims contains IDs of claims (and channels) this wallet is able to sign or abandon
+	claims map[string]bool
+}
+
+type account struct {
+	id         string
+	name       string
+	publicKey  string
+	seed       string
+	isDefault  bool
+	singleKey  bool
+	modifiedOn int64
+}
+
+type claim struct {
+	claimID   string
+	name      string
+	txid      string
+	address   string
+	amount    string
+	valueType string
+	value     map[string]interface{}
+	channel   *claim
+	height    int
+	timestamp int64
+	abandoned bool
+}
+
+// hash generates a deterministic pseudo-random hex string unique within the server session.
+func (s *Server) hash(kind string, length int) string {
+	s.seq++
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%v:%v", kind, s.seq)))
+	h := hex.EncodeToString(sum[:])
+	for len(h) < length {
+		h += h
+	}
+	return h[:length]
+}
+
+// base58 generates a deterministic pseudo-random base58 string unique within the server session.
+func (s *Server) base58(prefix string, length int) string {
+	n := new(big.Int)
+	n.SetString(s.hash(prefix, 64), 16)
+	encoded := prefix
+	radix := big.NewInt(int64(len(base58Alphabet)))
+	mod := new(big.Int)
+	for len(encoded) < length {
+		n.DivMod(n, radix, mod)
+		encoded += string(base58Alphabet[mod.Int64()])
+		if n.Sign() == 0 {
+			n.SetString(s.hash(prefix, 64), 16)
+		}
+	}
+	return encoded
+}
+
+// publicKey generates a channel public key, DER-encoded and hex-serialized like SDK does it.
+func (s *Server) publicKey() string {
+	seed, _ := hex.DecodeString(s.hash("key", 64))
+	_, pub := btcec.PrivKeyFromBytes(btcec.S256(), seed)
+	der, err := schema.PublicKeyToDER(pub)
+	if err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(der)
+}
+
+func (s *Server) newWallet(id string) *wallet {
+	w := &wallet{id: id, claims: map[string]bool{}}
+	s.wallets[id] = w
+	return w
+}
+
+func (s *Server) newAccount(name string, singleKey, isDefault bool) *account {
+	words := make([]string, 12)
+	for i, c := range s.hash("seed", 12) {
+		idx, _ := strconv.ParseInt(string(c), 16, 64)
+		words[i] = seedWords[idx]
+	}
+	return &account{
+		id:         s.base58("b", 34),
+		name:       name,
+		publicKey:  s.base58("xpub", 111),
+		seed:       strings.Join(words, " "),
+		isDefault:  isDefault,
+		singleKey:  singleKey,
+		modifiedOn: time.Now().Unix(),
+	}
+}
+
+func (s *Server) newClaim(name, amount, valueType string, value map[string]interface{}) *claim {
+	c := &claim{
+		claimID:   s.hash("claim", 40),
+		name:      name,
+		txid:      s.hash("tx", 64),
+		address:   s.base58("b", 34),
+		amount:    amount,
+		valueType: valueType,
+		value:     value,
+		height:    blockHeight,
+		timestamp: time.Now().Unix(),
+	}
+	s.claims = append(s.claims, c)
+	return c
+}
+
+func (w *wallet) addAccount(a *account) {
+	w.accounts = append(w.accounts, a)
+}
+
+func (w *wallet) defaultAccount() *account {
+	for _, a := range w.accounts {
+		if a.isDefault {
+			return a
+		}
+	}
+	if len(w.accounts) > 0 {
+		return w.accounts[0]
+	}
+	return nil
+}
+
+func (w *wallet) findAccount(id string) *account {
+	for _, a := range w.accounts {
+		if a.id == id {
+			return a
+		}
+	}
+	return nil
+}
+
+func (a *account) toMap(withSeed bool) map[string]interface{} {
+	generator := map[string]interface{}{"name": "single-address"}
+	if !a.singleKey {
+		generator = map[string]interface{}{
+			"name":      "deterministic-chain",
+			"change":    map[string]interface{}{"gap": 6, "maximum_uses_per_address": 1},
+			"receiving": map[string]interface{}{"gap": 20, "maximum_uses_per_address": 1},
+		}
+	}
+	m := map[string]interface{}{
+		"address_generator": generator,
+		"certificates":      0,
+		"coins":             0.0,
+		"encrypted":         false,
+		"id":                a.id,
+		"is_default":        a.isDefault,
+		"ledger":            "lbc_mainnet",
+		"modified_on":       a.modifiedOn,
+		"name":              a.name,
+		"public_key":        a.publicKey,
+		"satoshis":          0,
+	}
+	if withSeed {
+		m["seed"] = a.seed
+	}
+	return m
+}
+
+func (c *claim) permanentURL() string {
+	return fmt.Sprintf("lbry://%v#%v", c.name, c.claimID)
+}
+
+func (c *claim) shortURL() string {
+	return fmt.Sprintf("lbry://%v#%v", c.name, c.claimID[:1])
+}
+
+func (c *claim) canonicalURL() string {
+	if c.channel != nil {
+		return fmt.Sprintf("lbry://%v#%v/%v#%v", c.channel.name, c.channel.claimID[:1], c.name, c.claimID[:1])
+	}
+	return c.shortURL()
+}
+
+// toMap serializes the claim the way SDK outputs it in resolve, claim_search and *_list methods.
+func (c *claim) toMap(includeProtobuf bool) map[string]interface{} {
+	m := map[string]interface{}{
+		"address":       c.address,
+		"amount":        c.amount,
+		"canonical_url": c.canonicalURL(),
+		"claim_id":      c.claimID,
+		"claim_op":      "create",
+		"confirmations": 1,
+		"height":        c.height,
+		"meta": map[string]interface{}{
+			"activation_height":  c.height,
+			"creation_height":    c.height,
+			"creation_timestamp": c.timestamp,
+			"effective_amount":   c.amount,
+			"expiration_height":  c.height + 2102400,
+			"is_controlling":     true,
+			"support_amount":     "0.0",
+			"trending_global":    0.0,
+			"trending_group":     0,
+			"trending_local":     0.0,
+			"trending_mixed":     0.0,
+		},
+		"name":            c.name,
+		"normalized_name": strings.ToLower(c.name),
+		"nout":            0,
+		"permanent_url":   c.permanentURL(),
+		"short_url":       c.shortURL(),
+		"timestamp":       c.timestamp,
+		"txid":            c.txid,
+		"type":            "claim",
+		"value":           c.value,
+		"value_type":      c.valueType,
+	}
+	if c.channel != nil {
+		m["is_channel_signature_valid"] = true
+		m["signing_channel"] = c.channel.toMap(includeProtobuf)
+	}
+	if includeProtobuf {
+		m["protobuf"] = c.protobuf()
+	}
+	return m
+}
+
+// toOutput serializes the claim as a transaction output the way SDK returns it from *_create methods.
+func (c *claim) toOutput() map[string]interface{} {
+	m := c.toMap(false)
+	m["confirmations"] = -2
+	m["height"] = -2
+	m["is_change"] = false
+	m["is_mine"] = true
+	m["meta"] = map[string]interface{}{}
+	delete(m, "canonical_url")
+	delete(m, "short_url")
+	return m
+}
+
+// protobuf returns claim value serialized in the format SDK stores it on the blockchain.
+func (c *claim) protobuf() string {
+	v := &pb.Claim{}
+	v.Title, _ = c.value["title"].(string)
+	v.Description, _ = c.value["description"].(string)
+	if tags, ok := c.value["tags"].([]string); ok {
+		v.Tags = tags
+	}
+
+	if c.valueType == "channel" {
+		pk, _ := hex.DecodeString(c.value["public_key"].(string))
+		v.Type = &pb.Claim_Channel{Channel: &pb.Channel{PublicKey: pk}}
+	} else {
+		source := c.value["source"].(map[string]interface{})
+		sdHash, _ := hex.DecodeString(source["sd_hash"].(string))
+		fileHash, _ := hex.DecodeString(source["hash"].(string))
+		size, _ := strconv.ParseUint(source["size"].(string), 10, 64)
+		v.Type = &pb.Claim_Stream{Stream: &pb.Stream{Source: &pb.Source{
+			Hash:      fileHash,
+			Name:      source["name"].(string),
+			Size:      size,
+			MediaType: source["media_type"].(string),
+			SdHash:    sdHash,
+		}}}
+	}
+
+	serialized, err := proto.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	// Leading zero byte signifies unsigned claim
+	return "00" + hex.EncodeToString(serialized)
+}
+
+// formatAmount converts LBC amount as supplied by the client into the SDK format ("1.0", "0.01").
+func formatAmount(amount string) (string, error) {
+	f, err := strconv.ParseFloat(amount, 64)
+	if err != nil || f <= 0 {
+		return "", newError("Invalid amount: %v", amount)
+	}
+	formatted := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.Contains(formatted, ".") {
+		formatted += ".0"
+	}
+	return formatted, nil
+}
@@ -0,0 +1,25 @@
+package lbrynettest
+
+import (
+	"github.com/lbryio/lbrytv/config"
+
+	ljsonrpc "github.com/lbryio/lbry.go/v2/extras/jsonrpc"
+)
+
+// UseTestServer launches a fake SDK and points both client and Lbrynet config setting to it,
+// so tests can run without a live SDK instance. Supply a pointer to lbrynet.Client as client.
+// Call the returned function to shut the fake down and restore the previous settings.
+func UseTestServer(client **ljsonrpc.Client) (*Server, func()) {
+	sdk := NewServer()
+	originalClient, originalAddress := *client, config.GetLbrynet()
+
+	*client = ljsonrpc.NewClient(sdk.URL)
+	// Not using config.Override as tests calling config.RestoreOverridden would switch it back to the live SDK
+	config.Config.Viper.Set("Lbrynet", sdk.URL)
+
+	return sdk, func() {
+		*client = originalClient
+		config.Config.Viper.Set("Lbrynet", originalAddress)
+		sdk.Close()
+	}
+}
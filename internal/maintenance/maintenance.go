@@ -0,0 +1,58 @@
+// Package maintenance holds the maintenance mode state. While maintenance mode is on,
+// calls changing wallets and uploads are rejected, while reads keep being served.
+package maintenance
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lbryio/lbrytv/internal/monitor"
+)
+
+// DefaultMessage is shown to users when maintenance mode is enabled without a message.
+const DefaultMessage = "lbry.tv is undergoing maintenance, publishing and wallet operations are temporarily unavailable"
+
+// Status describes maintenance mode state.
+type Status struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message,omitempty"`
+	// ETA is when maintenance is expected to be over, if known
+	ETA *time.Time `json:"eta,omitempty"`
+}
+
+var (
+	mu      sync.RWMutex
+	current Status
+	logger  = monitor.NewModuleLogger("maintenance")
+)
+
+// Set changes maintenance mode state of this lbrytv instance.
+func Set(s Status) {
+	if s.Enabled && s.Message == "" {
+		s.Message = DefaultMessage
+	}
+	if !s.Enabled {
+		s = Status{}
+	}
+
+	mu.Lock()
+	changed := s.Enabled != current.Enabled
+	current = s
+	mu.Unlock()
+
+	if changed {
+		logger.LogF(monitor.F{"enabled": s.Enabled, "message": s.Message, "eta": s.ETA}).Info("maintenance mode changed")
+	}
+}
+
+// Get returns current maintenance mode state.
+func Get() Status {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Active returns true if maintenance mode is on.
+func Active() bool {
+	return Get().Enabled
+}
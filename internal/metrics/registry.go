@@ -0,0 +1,8 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Registry is a dedicated prometheus registry for lbrytv metrics.
+// Modules register their collectors in it on init and metrics server exposes it
+// along with the default registry containing Go runtime metrics.
+var Registry = prometheus.NewRegistry()
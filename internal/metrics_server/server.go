@@ -1,13 +1,12 @@
 package metrics_server
 
 import (
-	"fmt"
 	"net/http"
 	"runtime"
 	"sync"
 
 	"github.com/lbryio/lbrytv/api"
-	"github.com/lbryio/lbrytv/app/proxy"
+	"github.com/lbryio/lbrytv/internal/metrics"
 	"github.com/lbryio/lbrytv/internal/monitor"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -17,25 +16,15 @@ import (
 
 var once sync.Once
 
-var monitoredProxyCalls = []string{
-	proxy.MethodClaimSearch,
-	proxy.MethodResolve,
-	proxy.MethodAccountBalance,
-	proxy.MethodAccountList,
-	proxy.MethodGet,
-	proxy.MethodFileList,
-}
-
 type Server struct {
 	monitor.ModuleLogger
 
-	proxy   *proxy.Service
 	Address string
 	Path    string
 }
 
-func NewServer(address string, path string, p *proxy.Service) *Server {
-	return &Server{monitor.NewModuleLogger("metrics_server"), p, address, path}
+func NewServer(address string, path string) *Server {
+	return &Server{monitor.NewModuleLogger("metrics_server"), address, path}
 }
 
 func (s *Server) Serve() {
@@ -43,7 +32,8 @@ func (s *Server) Serve() {
 		s.registerMetrics()
 
 		go func() {
-			http.Handle(s.Path, promhttp.Handler())
+			gatherers := prometheus.Gatherers{prometheus.DefaultGatherer, metrics.Registry}
+			http.Handle(s.Path, promhttp.HandlerFor(gatherers, promhttp.HandlerOpts{}))
 			http.ListenAndServe(s.Address, nil)
 		}()
 		s.Log().Infof("metrics server listening on %v%v", s.Address, s.Path)
@@ -51,20 +41,6 @@ func (s *Server) Serve() {
 }
 
 func (s *Server) registerMetrics() {
-	for _, m := range monitoredProxyCalls {
-		m := m
-		if err := prometheus.Register(prometheus.NewGaugeFunc(
-			prometheus.GaugeOpts{
-				Subsystem: "proxy",
-				Name:      fmt.Sprintf("%v_time", m),
-				Help:      "Time to serve a single resolve call.",
-			},
-			func() float64 { return s.proxy.GetMetricsValue(m).Value },
-		)); err == nil {
-			s.Log().Infof("gauge 'proxy_%v_time' registered", m)
-		}
-	}
-
 	if err := prometheus.Register(prometheus.NewGaugeFunc(
 		prometheus.GaugeOpts{
 			Subsystem: "player",
@@ -54,6 +54,9 @@ func ErrorLoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
 		hub := sentry.CurrentHub().Clone()
 		hub.Scope().SetRequest(sentry.Request{}.FromHTTPRequest(r))
+		if id := RequestID(r.Context()); id != "" {
+			hub.Scope().SetTag(RequestIDF, id)
+		}
 		ctx := sentry.SetHubOnContext(
 			r.Context(),
 			hub,
@@ -95,6 +98,9 @@ func CaptureRequestError(err error, r *http.Request, w http.ResponseWriter, para
 
 	extra["method"] = r.Method
 	extra["url"] = r.URL.Path
+	if id := RequestID(r.Context()); id != "" {
+		extra[RequestIDF] = id
+	}
 
 	if lw, ok := w.(*loggingWriter); ok {
 		extra["status"] = fmt.Sprintf("%v", lw.Status)
@@ -115,7 +115,7 @@ func LogFailedQuery(method string, query interface{}, errorResponse interface{})
 		"response": errorResponse,
 	}).Error("daemon responded with an error")
 
-	captureFailedQuery(method, query, errorResponse)
+	captureFailedQuery(method, query, errorResponse, "")
 }
 
 type QueryMonitor interface {
@@ -124,6 +124,7 @@ type QueryMonitor interface {
 	Error(message string)
 	Errorf(message string, args ...interface{})
 	Logger() *logrus.Logger
+	WithRequestID(id string) QueryMonitor
 }
 
 func getBaseLogger() *logrus.Logger {
@@ -139,8 +140,9 @@ func getBaseLogger() *logrus.Logger {
 }
 
 type ProxyLogger struct {
-	logger *logrus.Logger
-	entry  *logrus.Entry
+	logger    *logrus.Logger
+	entry     *logrus.Entry
+	requestID string
 }
 
 func NewProxyLogger() *ProxyLogger {
@@ -168,7 +170,7 @@ func (l *ProxyLogger) LogFailedQuery(method string, params interface{}, errorRes
 		"response": errorResponse,
 	}).Error("error from the target endpoint")
 
-	captureFailedQuery(method, params, errorResponse)
+	captureFailedQuery(method, params, errorResponse, l.requestID)
 }
 
 func (l *ProxyLogger) Error(message string) {
@@ -182,3 +184,12 @@ func (l *ProxyLogger) Errorf(message string, args ...interface{}) {
 func (l *ProxyLogger) Logger() *logrus.Logger {
 	return l.logger
 }
+
+// WithRequestID returns a copy of the logger attaching request ID to all log entries and Sentry events.
+func (l *ProxyLogger) WithRequestID(id string) QueryMonitor {
+	return &ProxyLogger{
+		logger:    l.logger,
+		entry:     l.entry.WithField(RequestIDF, id),
+		requestID: id,
+	}
+}
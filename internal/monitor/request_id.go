@@ -0,0 +1,57 @@
+package monitor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+)
+
+// RequestIDHeader is the name of HTTP header carrying request ID, it is accepted from clients,
+// returned in responses and forwarded to upstream services.
+const RequestIDHeader = "X-Request-Id"
+
+// RequestIDF is a log field name for request ID.
+const RequestIDF = "request_id"
+
+type requestIDKey struct{}
+
+// reValidRequestID limits client-supplied request IDs so they are safe to put into logs and headers.
+var reValidRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// NewRequestID generates a random request ID.
+func NewRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		Logger.Errorf("cannot generate request ID: %v", err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// ContextWithRequestID returns a copy of ctx carrying request ID.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns request ID stored in ctx or an empty string if there's none.
+func RequestID(ctx context.Context) string {
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// RequestIDMiddleware takes request ID from X-Request-Id header or generates a new one if it's missing or invalid,
+// stores it in request context and returns it in the response header.
+// It should go before ErrorLoggingMiddleware so request ID gets attached to Sentry events.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !reValidRequestID.MatchString(id) {
+			id = NewRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(ContextWithRequestID(r.Context(), id)))
+	})
+}
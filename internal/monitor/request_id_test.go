@@ -0,0 +1,73 @@
+package monitor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	logrus_test "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var seenID string
+	handler := RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seenID = RequestID(r.Context())
+	}))
+
+	cases := []struct {
+		name, header string
+		kept         bool
+	}{
+		{"Supplied", "abc-123.x:y_z", true},
+		{"Missing", "", false},
+		{"Invalid", "abc\"; drop", false},
+		{"TooLong", strings.Repeat("a", 129), false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r, _ := http.NewRequest("GET", "/", nil)
+			if c.header != "" {
+				r.Header.Set(RequestIDHeader, c.header)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, r)
+
+			require.NotEmpty(t, seenID)
+			assert.Equal(t, seenID, rr.Header().Get(RequestIDHeader))
+			if c.kept {
+				assert.Equal(t, c.header, seenID)
+			} else {
+				assert.Regexp(t, `^[0-9a-f]{32}$`, seenID)
+			}
+		})
+	}
+}
+
+func TestRequestIDErrorLogging(t *testing.T) {
+	hook := logrus_test.NewLocal(httpLogger.Logger)
+	handler := RequestIDMiddleware(ErrorLoggingMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})))
+
+	r, _ := http.NewRequest("GET", "/api/", nil)
+	r.Header.Set(RequestIDHeader, "abc")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	require.NotNil(t, hook.LastEntry())
+	assert.Equal(t, "abc", hook.LastEntry().Data[RequestIDF])
+}
+
+func TestProxyLoggerWithRequestID(t *testing.T) {
+	l := NewProxyLogger()
+	hook := logrus_test.NewLocal(l.Logger())
+
+	l.WithRequestID("abc").LogSuccessfulQuery("resolve", 0.1, nil)
+	assert.Equal(t, "abc", hook.LastEntry().Data[RequestIDF])
+
+	// Original logger is not affected
+	l.LogSuccessfulQuery("resolve", 0.1, nil)
+	assert.NotContains(t, hook.LastEntry().Data, RequestIDF)
+}
@@ -43,13 +43,17 @@ func CaptureException(err error, params ...map[string]string) {
 }
 
 // captureFailedQuery sends to Sentry details of a failed daemon call.
-func captureFailedQuery(method string, query interface{}, errorResponse interface{}) {
+func captureFailedQuery(method string, query interface{}, errorResponse interface{}, requestID string) {
+	extra := map[string]string{
+		"method":   method,
+		"query":    fmt.Sprintf("%v", query),
+		"response": fmt.Sprintf("%v", errorResponse),
+	}
+	if requestID != "" {
+		extra[RequestIDF] = requestID
+	}
 	CaptureException(
 		fmt.Errorf("daemon responded with an error when calling method %v", method),
-		map[string]string{
-			"method":   method,
-			"query":    fmt.Sprintf("%v", query),
-			"response": fmt.Sprintf("%v", errorResponse),
-		},
+		extra,
 	)
 }
@@ -0,0 +1,26 @@
+-- +migrate Up
+
+-- +migrate StatementBegin
+CREATE TABLE "idempotency_keys" (
+    "wallet_id" varchar NOT NULL,
+    "key" varchar NOT NULL,
+
+    "created_at" timestamp NOT NULL DEFAULT now(),
+
+    "method" varchar NOT NULL,
+    "request_hash" varchar NOT NULL,
+    "response" jsonb,
+
+    PRIMARY KEY ("wallet_id", "key")
+);
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE INDEX "idempotency_keys_created_at_idx" ON "idempotency_keys" ("created_at");
+-- +migrate StatementEnd
+
+-- +migrate Down
+
+-- +migrate StatementBegin
+DROP TABLE "idempotency_keys";
+-- +migrate StatementEnd
@@ -0,0 +1,39 @@
+-- +migrate Up
+
+-- +migrate StatementBegin
+CREATE TABLE "spendings" (
+    "id" serial NOT NULL PRIMARY KEY,
+    "user_id" uinteger NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+
+    "created_at" timestamp NOT NULL DEFAULT now(),
+
+    "method" varchar NOT NULL,
+    "amount" bigint NOT NULL
+);
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE INDEX "spendings_user_id_created_at_idx" ON "spendings" ("user_id", "created_at");
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE TABLE "spending_limits" (
+    "user_id" uinteger NOT NULL PRIMARY KEY REFERENCES "users" ("id") ON DELETE CASCADE,
+
+    "created_at" timestamp NOT NULL DEFAULT now(),
+    "updated_at" timestamp NOT NULL DEFAULT now(),
+
+    "daily_limit" bigint,
+    "transaction_limit" bigint
+);
+-- +migrate StatementEnd
+
+-- +migrate Down
+
+-- +migrate StatementBegin
+DROP TABLE "spending_limits";
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+DROP TABLE "spendings";
+-- +migrate StatementEnd
@@ -0,0 +1,21 @@
+-- +migrate Up
+
+-- +migrate StatementBegin
+CREATE TABLE "auth_tokens" (
+    "token_hash" varchar NOT NULL PRIMARY KEY,
+    "user_id" uinteger NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+
+    "created_at" timestamp NOT NULL DEFAULT now(),
+    "verified_at" timestamp NOT NULL DEFAULT now()
+);
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE INDEX "auth_tokens_verified_at_idx" ON "auth_tokens" ("verified_at");
+-- +migrate StatementEnd
+
+-- +migrate Down
+
+-- +migrate StatementBegin
+DROP TABLE "auth_tokens";
+-- +migrate StatementEnd
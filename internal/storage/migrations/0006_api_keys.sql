@@ -0,0 +1,28 @@
+-- +migrate Up
+
+-- +migrate StatementBegin
+CREATE TABLE "api_keys" (
+    "id" serial NOT NULL PRIMARY KEY,
+    "user_id" uinteger NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+
+    "created_at" timestamp NOT NULL DEFAULT now(),
+    "expires_at" timestamp,
+    "revoked_at" timestamp,
+    "last_used_at" timestamp,
+
+    "name" varchar NOT NULL,
+    "key_hash" varchar NOT NULL UNIQUE,
+    "scopes" varchar[] NOT NULL,
+    "allowed_ips" varchar[] NOT NULL DEFAULT '{}'
+);
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE INDEX "api_keys_user_id_idx" ON "api_keys" ("user_id");
+-- +migrate StatementEnd
+
+-- +migrate Down
+
+-- +migrate StatementBegin
+DROP TABLE "api_keys";
+-- +migrate StatementEnd
@@ -0,0 +1,28 @@
+-- +migrate Up
+
+-- +migrate StatementBegin
+CREATE TABLE "guests" (
+    "id" serial NOT NULL PRIMARY KEY,
+
+    "created_at" timestamp NOT NULL DEFAULT now(),
+    "last_used_at" timestamp NOT NULL DEFAULT now(),
+
+    "created_ip" varchar NOT NULL DEFAULT '',
+    "merged_user_id" uinteger REFERENCES "users" ("id") ON DELETE SET NULL,
+    "merged_at" timestamp
+);
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE INDEX "guests_last_used_at_idx" ON "guests" ("last_used_at");
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE INDEX "guests_created_ip_created_at_idx" ON "guests" ("created_ip", "created_at");
+-- +migrate StatementEnd
+
+-- +migrate Down
+
+-- +migrate StatementBegin
+DROP TABLE "guests";
+-- +migrate StatementEnd
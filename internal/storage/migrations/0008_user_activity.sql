@@ -0,0 +1,25 @@
+-- +migrate Up
+
+-- +migrate StatementBegin
+ALTER TABLE "users"
+    ADD COLUMN "email" varchar NOT NULL DEFAULT '',
+    ADD COLUMN "last_seen_at" timestamp,
+    ADD COLUMN "last_ip" varchar NOT NULL DEFAULT '',
+    ADD COLUMN "login_count" integer NOT NULL DEFAULT 0
+;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE INDEX "users_email_idx" ON "users" ("email");
+-- +migrate StatementEnd
+
+-- +migrate Down
+
+-- +migrate StatementBegin
+ALTER TABLE "users"
+    DROP COLUMN "email",
+    DROP COLUMN "last_seen_at",
+    DROP COLUMN "last_ip",
+    DROP COLUMN "login_count"
+;
+-- +migrate StatementEnd
@@ -0,0 +1,34 @@
+-- +migrate Up
+
+-- +migrate StatementBegin
+CREATE TABLE "wallets" (
+    "id" serial NOT NULL PRIMARY KEY,
+    "user_id" uinteger NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+
+    "created_at" timestamp NOT NULL DEFAULT now(),
+    "updated_at" timestamp NOT NULL DEFAULT now(),
+
+    "wallet_id" varchar NOT NULL UNIQUE,
+    "name" varchar NOT NULL,
+    "is_default" boolean NOT NULL DEFAULT false
+);
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE INDEX "wallets_user_id_idx" ON "wallets" ("user_id");
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE UNIQUE INDEX "wallets_user_id_default_idx" ON "wallets" ("user_id") WHERE "is_default";
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+INSERT INTO "wallets" ("user_id", "wallet_id", "name", "is_default")
+    SELECT "id", "wallet_id", 'Default', true FROM "users" WHERE "wallet_id" <> '';
+-- +migrate StatementEnd
+
+-- +migrate Down
+
+-- +migrate StatementBegin
+DROP TABLE "wallets";
+-- +migrate StatementEnd
@@ -0,0 +1,27 @@
+-- +migrate Up
+
+-- +migrate StatementBegin
+CREATE TABLE "wallet_migrations" (
+    "user_id" uinteger NOT NULL PRIMARY KEY REFERENCES "users" ("id") ON DELETE CASCADE,
+
+    "created_at" timestamp NOT NULL DEFAULT now(),
+    "updated_at" timestamp NOT NULL DEFAULT now(),
+
+    "status" varchar NOT NULL,
+    "account_id" varchar NOT NULL,
+    "wallet_id" varchar NOT NULL,
+    "escrowed_seed" bytea,
+    "attempts" integer NOT NULL DEFAULT 0,
+    "last_error" text NOT NULL DEFAULT ''
+);
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE INDEX "wallet_migrations_status_idx" ON "wallet_migrations" ("status");
+-- +migrate StatementEnd
+
+-- +migrate Down
+
+-- +migrate StatementBegin
+DROP TABLE "wallet_migrations";
+-- +migrate StatementEnd
@@ -0,0 +1,26 @@
+-- +migrate Up
+
+-- +migrate StatementBegin
+CREATE TABLE "user_deletions" (
+    "id" serial NOT NULL PRIMARY KEY,
+
+    "created_at" timestamp NOT NULL DEFAULT now(),
+
+    "wallets" integer NOT NULL DEFAULT 0,
+    "legacy_account" boolean NOT NULL DEFAULT false,
+    "exported" boolean NOT NULL DEFAULT false,
+    "wallet_files" integer NOT NULL DEFAULT 0,
+    "upload_dirs" integer NOT NULL DEFAULT 0,
+    "idempotency_keys" integer NOT NULL DEFAULT 0
+);
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE INDEX "user_deletions_created_at_idx" ON "user_deletions" ("created_at");
+-- +migrate StatementEnd
+
+-- +migrate Down
+
+-- +migrate StatementBegin
+DROP TABLE "user_deletions";
+-- +migrate StatementEnd
@@ -0,0 +1,1110 @@
+// Code generated by SQLBoiler (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+	"github.com/volatiletech/sqlboiler/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+// AuthToken is an object representing the database table.
+type AuthToken struct {
+	TokenHash  string    `boil:"token_hash" json:"token_hash" toml:"token_hash" yaml:"token_hash"`
+	UserID     int       `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	CreatedAt  time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	VerifiedAt time.Time `boil:"verified_at" json:"verified_at" toml:"verified_at" yaml:"verified_at"`
+
+	R *authTokenR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L authTokenL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var AuthTokenColumns = struct {
+	TokenHash  string
+	UserID     string
+	CreatedAt  string
+	VerifiedAt string
+}{
+	TokenHash:  "token_hash",
+	UserID:     "user_id",
+	CreatedAt:  "created_at",
+	VerifiedAt: "verified_at",
+}
+
+// Generated where
+
+var AuthTokenWhere = struct {
+	TokenHash  whereHelperstring
+	UserID     whereHelperint
+	CreatedAt  whereHelpertime_Time
+	VerifiedAt whereHelpertime_Time
+}{
+	TokenHash:  whereHelperstring{field: "\"auth_tokens\".\"token_hash\""},
+	UserID:     whereHelperint{field: "\"auth_tokens\".\"user_id\""},
+	CreatedAt:  whereHelpertime_Time{field: "\"auth_tokens\".\"created_at\""},
+	VerifiedAt: whereHelpertime_Time{field: "\"auth_tokens\".\"verified_at\""},
+}
+
+// AuthTokenRels is where relationship names are stored.
+var AuthTokenRels = struct {
+	User string
+}{
+	User: "User",
+}
+
+// authTokenR is where relationships are stored.
+type authTokenR struct {
+	User *User
+}
+
+// NewStruct creates a new relationship struct
+func (*authTokenR) NewStruct() *authTokenR {
+	return &authTokenR{}
+}
+
+// authTokenL is where Load methods for each relationship are stored.
+type authTokenL struct{}
+
+var (
+	authTokenAllColumns            = []string{"token_hash", "user_id", "created_at", "verified_at"}
+	authTokenColumnsWithoutDefault = []string{"token_hash", "user_id"}
+	authTokenColumnsWithDefault    = []string{"created_at", "verified_at"}
+	authTokenPrimaryKeyColumns     = []string{"token_hash"}
+)
+
+type (
+	// AuthTokenSlice is an alias for a slice of pointers to AuthToken.
+	// This should generally be used opposed to []AuthToken.
+	AuthTokenSlice []*AuthToken
+	// AuthTokenHook is the signature for custom AuthToken hook methods
+	AuthTokenHook func(boil.Executor, *AuthToken) error
+
+	authTokenQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	authTokenType                 = reflect.TypeOf(&AuthToken{})
+	authTokenMapping              = queries.MakeStructMapping(authTokenType)
+	authTokenPrimaryKeyMapping, _ = queries.BindMapping(authTokenType, authTokenMapping, authTokenPrimaryKeyColumns)
+	authTokenInsertCacheMut       sync.RWMutex
+	authTokenInsertCache          = make(map[string]insertCache)
+	authTokenUpdateCacheMut       sync.RWMutex
+	authTokenUpdateCache          = make(map[string]updateCache)
+	authTokenUpsertCacheMut       sync.RWMutex
+	authTokenUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var authTokenBeforeInsertHooks []AuthTokenHook
+var authTokenBeforeUpdateHooks []AuthTokenHook
+var authTokenBeforeDeleteHooks []AuthTokenHook
+var authTokenBeforeUpsertHooks []AuthTokenHook
+
+var authTokenAfterInsertHooks []AuthTokenHook
+var authTokenAfterSelectHooks []AuthTokenHook
+var authTokenAfterUpdateHooks []AuthTokenHook
+var authTokenAfterDeleteHooks []AuthTokenHook
+var authTokenAfterUpsertHooks []AuthTokenHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *AuthToken) doBeforeInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range authTokenBeforeInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *AuthToken) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range authTokenBeforeUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *AuthToken) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range authTokenBeforeDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *AuthToken) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range authTokenBeforeUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *AuthToken) doAfterInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range authTokenAfterInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *AuthToken) doAfterSelectHooks(exec boil.Executor) (err error) {
+	for _, hook := range authTokenAfterSelectHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *AuthToken) doAfterUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range authTokenAfterUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *AuthToken) doAfterDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range authTokenAfterDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *AuthToken) doAfterUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range authTokenAfterUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddAuthTokenHook registers your hook function for all future operations.
+func AddAuthTokenHook(hookPoint boil.HookPoint, authTokenHook AuthTokenHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		authTokenBeforeInsertHooks = append(authTokenBeforeInsertHooks, authTokenHook)
+	case boil.BeforeUpdateHook:
+		authTokenBeforeUpdateHooks = append(authTokenBeforeUpdateHooks, authTokenHook)
+	case boil.BeforeDeleteHook:
+		authTokenBeforeDeleteHooks = append(authTokenBeforeDeleteHooks, authTokenHook)
+	case boil.BeforeUpsertHook:
+		authTokenBeforeUpsertHooks = append(authTokenBeforeUpsertHooks, authTokenHook)
+	case boil.AfterInsertHook:
+		authTokenAfterInsertHooks = append(authTokenAfterInsertHooks, authTokenHook)
+	case boil.AfterSelectHook:
+		authTokenAfterSelectHooks = append(authTokenAfterSelectHooks, authTokenHook)
+	case boil.AfterUpdateHook:
+		authTokenAfterUpdateHooks = append(authTokenAfterUpdateHooks, authTokenHook)
+	case boil.AfterDeleteHook:
+		authTokenAfterDeleteHooks = append(authTokenAfterDeleteHooks, authTokenHook)
+	case boil.AfterUpsertHook:
+		authTokenAfterUpsertHooks = append(authTokenAfterUpsertHooks, authTokenHook)
+	}
+}
+
+// OneG returns a single authToken record from the query using the global executor.
+func (q authTokenQuery) OneG() (*AuthToken, error) {
+	return q.One(boil.GetDB())
+}
+
+// One returns a single authToken record from the query.
+func (q authTokenQuery) One(exec boil.Executor) (*AuthToken, error) {
+	o := &AuthToken{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(nil, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for auth_tokens")
+	}
+
+	if err := o.doAfterSelectHooks(exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// AllG returns all AuthToken records from the query using the global executor.
+func (q authTokenQuery) AllG() (AuthTokenSlice, error) {
+	return q.All(boil.GetDB())
+}
+
+// All returns all AuthToken records from the query.
+func (q authTokenQuery) All(exec boil.Executor) (AuthTokenSlice, error) {
+	var o []*AuthToken
+
+	err := q.Bind(nil, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to AuthToken slice")
+	}
+
+	if len(authTokenAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// CountG returns the count of all AuthToken records in the query, and panics on error.
+func (q authTokenQuery) CountG() (int64, error) {
+	return q.Count(boil.GetDB())
+}
+
+// Count returns the count of all AuthToken records in the query.
+func (q authTokenQuery) Count(exec boil.Executor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count auth_tokens rows")
+	}
+
+	return count, nil
+}
+
+// ExistsG checks if the row exists in the table, and panics on error.
+func (q authTokenQuery) ExistsG() (bool, error) {
+	return q.Exists(boil.GetDB())
+}
+
+// Exists checks if the row exists in the table.
+func (q authTokenQuery) Exists(exec boil.Executor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if auth_tokens exists")
+	}
+
+	return count > 0, nil
+}
+
+// User pointed to by the foreign key.
+func (o *AuthToken) User(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("id=?", o.UserID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Users(queryMods...)
+	queries.SetFrom(query.Query, "\"users\"")
+
+	return query
+}
+
+// LoadUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (authTokenL) LoadUser(e boil.Executor, singular bool, maybeAuthToken interface{}, mods queries.Applicator) error {
+	var slice []*AuthToken
+	var object *AuthToken
+
+	if singular {
+		object = maybeAuthToken.(*AuthToken)
+	} else {
+		slice = *maybeAuthToken.(*[]*AuthToken)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &authTokenR{}
+		}
+		args = append(args, object.UserID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &authTokenR{}
+			}
+
+			for _, a := range args {
+				if a == obj.UserID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.UserID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`users`), qm.WhereIn(`id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(authTokenAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.User = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.AuthTokens = append(foreign.R.AuthTokens, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.UserID == foreign.ID {
+				local.R.User = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.AuthTokens = append(foreign.R.AuthTokens, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetUserG of the authToken to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.AuthTokens.
+// Uses the global database handle.
+func (o *AuthToken) SetUserG(insert bool, related *User) error {
+	return o.SetUser(boil.GetDB(), insert, related)
+}
+
+// SetUser of the authToken to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.AuthTokens.
+func (o *AuthToken) SetUser(exec boil.Executor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"auth_tokens\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, authTokenPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.TokenHash}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	if _, err = exec.Exec(updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.UserID = related.ID
+	if o.R == nil {
+		o.R = &authTokenR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			AuthTokens: AuthTokenSlice{o},
+		}
+	} else {
+		related.R.AuthTokens = append(related.R.AuthTokens, o)
+	}
+
+	return nil
+}
+
+// AuthTokens retrieves all the records using an executor.
+func AuthTokens(mods ...qm.QueryMod) authTokenQuery {
+	mods = append(mods, qm.From("\"auth_tokens\""))
+	return authTokenQuery{NewQuery(mods...)}
+}
+
+// FindAuthTokenG retrieves a single record by ID.
+func FindAuthTokenG(tokenHash string, selectCols ...string) (*AuthToken, error) {
+	return FindAuthToken(boil.GetDB(), tokenHash, selectCols...)
+}
+
+// FindAuthToken retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindAuthToken(exec boil.Executor, tokenHash string, selectCols ...string) (*AuthToken, error) {
+	authTokenObj := &AuthToken{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"auth_tokens\" where \"token_hash\"=$1", sel,
+	)
+
+	q := queries.Raw(query, tokenHash)
+
+	err := q.Bind(nil, exec, authTokenObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from auth_tokens")
+	}
+
+	return authTokenObj, nil
+}
+
+// InsertG a single record. See Insert for whitelist behavior description.
+func (o *AuthToken) InsertG(columns boil.Columns) error {
+	return o.Insert(boil.GetDB(), columns)
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *AuthToken) Insert(exec boil.Executor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no auth_tokens provided for insertion")
+	}
+
+	var err error
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+
+	if err := o.doBeforeInsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(authTokenColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	authTokenInsertCacheMut.RLock()
+	cache, cached := authTokenInsertCache[key]
+	authTokenInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			authTokenAllColumns,
+			authTokenColumnsWithDefault,
+			authTokenColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(authTokenType, authTokenMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(authTokenType, authTokenMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"auth_tokens\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"auth_tokens\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into auth_tokens")
+	}
+
+	if !cached {
+		authTokenInsertCacheMut.Lock()
+		authTokenInsertCache[key] = cache
+		authTokenInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(exec)
+}
+
+// UpdateG a single AuthToken record using the global executor.
+// See Update for more documentation.
+func (o *AuthToken) UpdateG(columns boil.Columns) (int64, error) {
+	return o.Update(boil.GetDB(), columns)
+}
+
+// Update uses an executor to update the AuthToken.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *AuthToken) Update(exec boil.Executor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	authTokenUpdateCacheMut.RLock()
+	cache, cached := authTokenUpdateCache[key]
+	authTokenUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			authTokenAllColumns,
+			authTokenPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update auth_tokens, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"auth_tokens\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, authTokenPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(authTokenType, authTokenMapping, append(wl, authTokenPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	var result sql.Result
+	result, err = exec.Exec(cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update auth_tokens row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for auth_tokens")
+	}
+
+	if !cached {
+		authTokenUpdateCacheMut.Lock()
+		authTokenUpdateCache[key] = cache
+		authTokenUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(exec)
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (q authTokenQuery) UpdateAllG(cols M) (int64, error) {
+	return q.UpdateAll(boil.GetDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q authTokenQuery) UpdateAll(exec boil.Executor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.Exec(exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for auth_tokens")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for auth_tokens")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (o AuthTokenSlice) UpdateAllG(cols M) (int64, error) {
+	return o.UpdateAll(boil.GetDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o AuthTokenSlice) UpdateAll(exec boil.Executor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), authTokenPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"auth_tokens\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, authTokenPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.Exec(sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in authToken slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all authToken")
+	}
+	return rowsAff, nil
+}
+
+// UpsertG attempts an insert, and does an update or ignore on conflict.
+func (o *AuthToken) UpsertG(updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	return o.Upsert(boil.GetDB(), updateOnConflict, conflictColumns, updateColumns, insertColumns)
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *AuthToken) Upsert(exec boil.Executor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no auth_tokens provided for upsert")
+	}
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(authTokenColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	authTokenUpsertCacheMut.RLock()
+	cache, cached := authTokenUpsertCache[key]
+	authTokenUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			authTokenAllColumns,
+			authTokenColumnsWithDefault,
+			authTokenColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			authTokenAllColumns,
+			authTokenPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert auth_tokens, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(authTokenPrimaryKeyColumns))
+			copy(conflict, authTokenPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"auth_tokens\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(authTokenType, authTokenMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(authTokenType, authTokenMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert auth_tokens")
+	}
+
+	if !cached {
+		authTokenUpsertCacheMut.Lock()
+		authTokenUpsertCache[key] = cache
+		authTokenUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(exec)
+}
+
+// DeleteG deletes a single AuthToken record.
+// DeleteG will match against the primary key column to find the record to delete.
+func (o *AuthToken) DeleteG() (int64, error) {
+	return o.Delete(boil.GetDB())
+}
+
+// Delete deletes a single AuthToken record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *AuthToken) Delete(exec boil.Executor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no AuthToken provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), authTokenPrimaryKeyMapping)
+	sql := "DELETE FROM \"auth_tokens\" WHERE \"token_hash\"=$1"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.Exec(sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from auth_tokens")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for auth_tokens")
+	}
+
+	if err := o.doAfterDeleteHooks(exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q authTokenQuery) DeleteAll(exec boil.Executor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no authTokenQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.Exec(exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from auth_tokens")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for auth_tokens")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAllG deletes all rows in the slice.
+func (o AuthTokenSlice) DeleteAllG() (int64, error) {
+	return o.DeleteAll(boil.GetDB())
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o AuthTokenSlice) DeleteAll(exec boil.Executor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(authTokenBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), authTokenPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"auth_tokens\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, authTokenPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+
+	result, err := exec.Exec(sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from authToken slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for auth_tokens")
+	}
+
+	if len(authTokenAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// ReloadG refetches the object from the database using the primary keys.
+func (o *AuthToken) ReloadG() error {
+	if o == nil {
+		return errors.New("models: no AuthToken provided for reload")
+	}
+
+	return o.Reload(boil.GetDB())
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *AuthToken) Reload(exec boil.Executor) error {
+	ret, err := FindAuthToken(exec, o.TokenHash)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAllG refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *AuthTokenSlice) ReloadAllG() error {
+	if o == nil {
+		return errors.New("models: empty AuthTokenSlice provided for reload all")
+	}
+
+	return o.ReloadAll(boil.GetDB())
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *AuthTokenSlice) ReloadAll(exec boil.Executor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := AuthTokenSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), authTokenPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"auth_tokens\".* FROM \"auth_tokens\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, authTokenPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(nil, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in AuthTokenSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// AuthTokenExistsG checks if the AuthToken row exists.
+func AuthTokenExistsG(tokenHash string) (bool, error) {
+	return AuthTokenExists(boil.GetDB(), tokenHash)
+}
+
+// AuthTokenExists checks if the AuthToken row exists.
+func AuthTokenExists(exec boil.Executor, tokenHash string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"auth_tokens\" where \"token_hash\"=$1 limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, tokenHash)
+	}
+
+	row := exec.QueryRow(sql, tokenHash)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if auth_tokens exists")
+	}
+
+	return exists, nil
+}
@@ -12,90 +12,237 @@ import "testing"
 // It does NOT run each operation group in parallel.
 // Separating the tests thusly grants avoidance of Postgres deadlocks.
 func TestParent(t *testing.T) {
+	t.Run("APIKeys", testAPIKeys)
+	t.Run("AuthTokens", testAuthTokens)
 	t.Run("GorpMigrations", testGorpMigrations)
+	t.Run("Guests", testGuests)
+	t.Run("IdempotencyKeys", testIdempotencyKeys)
+	t.Run("SpendingLimits", testSpendingLimits)
+	t.Run("Spendings", testSpendings)
+	t.Run("UserDeletions", testUserDeletions)
 	t.Run("Users", testUsers)
+	t.Run("WalletMigrations", testWalletMigrations)
+	t.Run("Wallets", testWallets)
 }
 
 func TestDelete(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysDelete)
+	t.Run("AuthTokens", testAuthTokensDelete)
 	t.Run("GorpMigrations", testGorpMigrationsDelete)
+	t.Run("Guests", testGuestsDelete)
+	t.Run("IdempotencyKeys", testIdempotencyKeysDelete)
+	t.Run("SpendingLimits", testSpendingLimitsDelete)
+	t.Run("Spendings", testSpendingsDelete)
+	t.Run("UserDeletions", testUserDeletionsDelete)
 	t.Run("Users", testUsersDelete)
+	t.Run("WalletMigrations", testWalletMigrationsDelete)
+	t.Run("Wallets", testWalletsDelete)
 }
 
 func TestQueryDeleteAll(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysQueryDeleteAll)
+	t.Run("AuthTokens", testAuthTokensQueryDeleteAll)
 	t.Run("GorpMigrations", testGorpMigrationsQueryDeleteAll)
+	t.Run("Guests", testGuestsQueryDeleteAll)
+	t.Run("IdempotencyKeys", testIdempotencyKeysQueryDeleteAll)
+	t.Run("SpendingLimits", testSpendingLimitsQueryDeleteAll)
+	t.Run("Spendings", testSpendingsQueryDeleteAll)
+	t.Run("UserDeletions", testUserDeletionsQueryDeleteAll)
 	t.Run("Users", testUsersQueryDeleteAll)
+	t.Run("WalletMigrations", testWalletMigrationsQueryDeleteAll)
+	t.Run("Wallets", testWalletsQueryDeleteAll)
 }
 
 func TestSliceDeleteAll(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysSliceDeleteAll)
+	t.Run("AuthTokens", testAuthTokensSliceDeleteAll)
 	t.Run("GorpMigrations", testGorpMigrationsSliceDeleteAll)
+	t.Run("Guests", testGuestsSliceDeleteAll)
+	t.Run("IdempotencyKeys", testIdempotencyKeysSliceDeleteAll)
+	t.Run("SpendingLimits", testSpendingLimitsSliceDeleteAll)
+	t.Run("Spendings", testSpendingsSliceDeleteAll)
+	t.Run("UserDeletions", testUserDeletionsSliceDeleteAll)
 	t.Run("Users", testUsersSliceDeleteAll)
+	t.Run("WalletMigrations", testWalletMigrationsSliceDeleteAll)
+	t.Run("Wallets", testWalletsSliceDeleteAll)
 }
 
 func TestExists(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysExists)
+	t.Run("AuthTokens", testAuthTokensExists)
 	t.Run("GorpMigrations", testGorpMigrationsExists)
+	t.Run("Guests", testGuestsExists)
+	t.Run("IdempotencyKeys", testIdempotencyKeysExists)
+	t.Run("SpendingLimits", testSpendingLimitsExists)
+	t.Run("Spendings", testSpendingsExists)
+	t.Run("UserDeletions", testUserDeletionsExists)
 	t.Run("Users", testUsersExists)
+	t.Run("WalletMigrations", testWalletMigrationsExists)
+	t.Run("Wallets", testWalletsExists)
 }
 
 func TestFind(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysFind)
+	t.Run("AuthTokens", testAuthTokensFind)
 	t.Run("GorpMigrations", testGorpMigrationsFind)
+	t.Run("Guests", testGuestsFind)
+	t.Run("IdempotencyKeys", testIdempotencyKeysFind)
+	t.Run("SpendingLimits", testSpendingLimitsFind)
+	t.Run("Spendings", testSpendingsFind)
+	t.Run("UserDeletions", testUserDeletionsFind)
 	t.Run("Users", testUsersFind)
+	t.Run("WalletMigrations", testWalletMigrationsFind)
+	t.Run("Wallets", testWalletsFind)
 }
 
 func TestBind(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysBind)
+	t.Run("AuthTokens", testAuthTokensBind)
 	t.Run("GorpMigrations", testGorpMigrationsBind)
+	t.Run("Guests", testGuestsBind)
+	t.Run("IdempotencyKeys", testIdempotencyKeysBind)
+	t.Run("SpendingLimits", testSpendingLimitsBind)
+	t.Run("Spendings", testSpendingsBind)
+	t.Run("UserDeletions", testUserDeletionsBind)
 	t.Run("Users", testUsersBind)
+	t.Run("WalletMigrations", testWalletMigrationsBind)
+	t.Run("Wallets", testWalletsBind)
 }
 
 func TestOne(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysOne)
+	t.Run("AuthTokens", testAuthTokensOne)
 	t.Run("GorpMigrations", testGorpMigrationsOne)
+	t.Run("Guests", testGuestsOne)
+	t.Run("IdempotencyKeys", testIdempotencyKeysOne)
+	t.Run("SpendingLimits", testSpendingLimitsOne)
+	t.Run("Spendings", testSpendingsOne)
+	t.Run("UserDeletions", testUserDeletionsOne)
 	t.Run("Users", testUsersOne)
+	t.Run("WalletMigrations", testWalletMigrationsOne)
+	t.Run("Wallets", testWalletsOne)
 }
 
 func TestAll(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysAll)
+	t.Run("AuthTokens", testAuthTokensAll)
 	t.Run("GorpMigrations", testGorpMigrationsAll)
+	t.Run("Guests", testGuestsAll)
+	t.Run("IdempotencyKeys", testIdempotencyKeysAll)
+	t.Run("SpendingLimits", testSpendingLimitsAll)
+	t.Run("Spendings", testSpendingsAll)
+	t.Run("UserDeletions", testUserDeletionsAll)
 	t.Run("Users", testUsersAll)
+	t.Run("WalletMigrations", testWalletMigrationsAll)
+	t.Run("Wallets", testWalletsAll)
 }
 
 func TestCount(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysCount)
+	t.Run("AuthTokens", testAuthTokensCount)
 	t.Run("GorpMigrations", testGorpMigrationsCount)
+	t.Run("Guests", testGuestsCount)
+	t.Run("IdempotencyKeys", testIdempotencyKeysCount)
+	t.Run("SpendingLimits", testSpendingLimitsCount)
+	t.Run("Spendings", testSpendingsCount)
+	t.Run("UserDeletions", testUserDeletionsCount)
 	t.Run("Users", testUsersCount)
+	t.Run("WalletMigrations", testWalletMigrationsCount)
+	t.Run("Wallets", testWalletsCount)
 }
 
 func TestHooks(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysHooks)
+	t.Run("AuthTokens", testAuthTokensHooks)
 	t.Run("GorpMigrations", testGorpMigrationsHooks)
+	t.Run("Guests", testGuestsHooks)
+	t.Run("IdempotencyKeys", testIdempotencyKeysHooks)
+	t.Run("SpendingLimits", testSpendingLimitsHooks)
+	t.Run("Spendings", testSpendingsHooks)
+	t.Run("UserDeletions", testUserDeletionsHooks)
 	t.Run("Users", testUsersHooks)
+	t.Run("WalletMigrations", testWalletMigrationsHooks)
+	t.Run("Wallets", testWalletsHooks)
 }
 
 func TestInsert(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysInsert)
+	t.Run("APIKeys", testAPIKeysInsertWhitelist)
+	t.Run("AuthTokens", testAuthTokensInsert)
+	t.Run("AuthTokens", testAuthTokensInsertWhitelist)
 	t.Run("GorpMigrations", testGorpMigrationsInsert)
 	t.Run("GorpMigrations", testGorpMigrationsInsertWhitelist)
+	t.Run("Guests", testGuestsInsert)
+	t.Run("Guests", testGuestsInsertWhitelist)
+	t.Run("IdempotencyKeys", testIdempotencyKeysInsert)
+	t.Run("IdempotencyKeys", testIdempotencyKeysInsertWhitelist)
+	t.Run("SpendingLimits", testSpendingLimitsInsert)
+	t.Run("SpendingLimits", testSpendingLimitsInsertWhitelist)
+	t.Run("Spendings", testSpendingsInsert)
+	t.Run("Spendings", testSpendingsInsertWhitelist)
+	t.Run("UserDeletions", testUserDeletionsInsert)
+	t.Run("UserDeletions", testUserDeletionsInsertWhitelist)
 	t.Run("Users", testUsersInsert)
 	t.Run("Users", testUsersInsertWhitelist)
+	t.Run("WalletMigrations", testWalletMigrationsInsert)
+	t.Run("WalletMigrations", testWalletMigrationsInsertWhitelist)
+	t.Run("Wallets", testWalletsInsert)
+	t.Run("Wallets", testWalletsInsertWhitelist)
 }
 
 // TestToOne tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToOne(t *testing.T) {}
+func TestToOne(t *testing.T) {
+	t.Run("APIKeyToUserUsingUser", testAPIKeyToOneUserUsingUser)
+	t.Run("AuthTokenToUserUsingUser", testAuthTokenToOneUserUsingUser)
+	t.Run("GuestToUserUsingMergedUser", testGuestToOneUserUsingMergedUser)
+	t.Run("SpendingLimitToUserUsingUser", testSpendingLimitToOneUserUsingUser)
+	t.Run("SpendingToUserUsingUser", testSpendingToOneUserUsingUser)
+	t.Run("WalletMigrationToUserUsingUser", testWalletMigrationToOneUserUsingUser)
+	t.Run("WalletToUserUsingUser", testWalletToOneUserUsingUser)
+}
 
 // TestOneToOne tests cannot be run in parallel
 // or deadlocks can occur.
-func TestOneToOne(t *testing.T) {}
+func TestOneToOne(t *testing.T) {
+	t.Run("UserToSpendingLimitUsingSpendingLimit", testUserOneToOneSpendingLimitUsingSpendingLimit)
+	t.Run("UserToWalletMigrationUsingWalletMigration", testUserOneToOneWalletMigrationUsingWalletMigration)
+}
 
 // TestToMany tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToMany(t *testing.T) {}
+func TestToMany(t *testing.T) {
+	t.Run("UserToAPIKeys", testUserToManyAPIKeys)
+	t.Run("UserToAuthTokens", testUserToManyAuthTokens)
+	t.Run("UserToMergedUserGuests", testUserToManyMergedUserGuests)
+	t.Run("UserToSpendings", testUserToManySpendings)
+	t.Run("UserToWallets", testUserToManyWallets)
+}
 
 // TestToOneSet tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToOneSet(t *testing.T) {}
+func TestToOneSet(t *testing.T) {
+	t.Run("APIKeyToUserUsingAPIKeys", testAPIKeyToOneSetOpUserUsingUser)
+	t.Run("AuthTokenToUserUsingAuthTokens", testAuthTokenToOneSetOpUserUsingUser)
+	t.Run("GuestToUserUsingMergedUserGuests", testGuestToOneSetOpUserUsingMergedUser)
+	t.Run("SpendingLimitToUserUsingSpendingLimit", testSpendingLimitToOneSetOpUserUsingUser)
+	t.Run("SpendingToUserUsingSpendings", testSpendingToOneSetOpUserUsingUser)
+	t.Run("WalletMigrationToUserUsingWalletMigration", testWalletMigrationToOneSetOpUserUsingUser)
+	t.Run("WalletToUserUsingWallets", testWalletToOneSetOpUserUsingUser)
+}
 
 // TestToOneRemove tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToOneRemove(t *testing.T) {}
+func TestToOneRemove(t *testing.T) {
+	t.Run("GuestToUserUsingMergedUserGuests", testGuestToOneRemoveOpUserUsingMergedUser)
+}
 
 // TestOneToOneSet tests cannot be run in parallel
 // or deadlocks can occur.
-func TestOneToOneSet(t *testing.T) {}
+func TestOneToOneSet(t *testing.T) {
+	t.Run("UserToSpendingLimitUsingSpendingLimit", testUserOneToOneSetOpSpendingLimitUsingSpendingLimit)
+	t.Run("UserToWalletMigrationUsingWalletMigration", testUserOneToOneSetOpWalletMigrationUsingWalletMigration)
+}
 
 // TestOneToOneRemove tests cannot be run in parallel
 // or deadlocks can occur.
@@ -103,37 +250,92 @@ func TestOneToOneRemove(t *testing.T) {}
 
 // TestToManyAdd tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToManyAdd(t *testing.T) {}
+func TestToManyAdd(t *testing.T) {
+	t.Run("UserToAPIKeys", testUserToManyAddOpAPIKeys)
+	t.Run("UserToAuthTokens", testUserToManyAddOpAuthTokens)
+	t.Run("UserToMergedUserGuests", testUserToManyAddOpMergedUserGuests)
+	t.Run("UserToSpendings", testUserToManyAddOpSpendings)
+	t.Run("UserToWallets", testUserToManyAddOpWallets)
+}
 
 // TestToManySet tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToManySet(t *testing.T) {}
+func TestToManySet(t *testing.T) {
+	t.Run("UserToMergedUserGuests", testUserToManySetOpMergedUserGuests)
+}
 
 // TestToManyRemove tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToManyRemove(t *testing.T) {}
+func TestToManyRemove(t *testing.T) {
+	t.Run("UserToMergedUserGuests", testUserToManyRemoveOpMergedUserGuests)
+}
 
 func TestReload(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysReload)
+	t.Run("AuthTokens", testAuthTokensReload)
 	t.Run("GorpMigrations", testGorpMigrationsReload)
+	t.Run("Guests", testGuestsReload)
+	t.Run("IdempotencyKeys", testIdempotencyKeysReload)
+	t.Run("SpendingLimits", testSpendingLimitsReload)
+	t.Run("Spendings", testSpendingsReload)
+	t.Run("UserDeletions", testUserDeletionsReload)
 	t.Run("Users", testUsersReload)
+	t.Run("WalletMigrations", testWalletMigrationsReload)
+	t.Run("Wallets", testWalletsReload)
 }
 
 func TestReloadAll(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysReloadAll)
+	t.Run("AuthTokens", testAuthTokensReloadAll)
 	t.Run("GorpMigrations", testGorpMigrationsReloadAll)
+	t.Run("Guests", testGuestsReloadAll)
+	t.Run("IdempotencyKeys", testIdempotencyKeysReloadAll)
+	t.Run("SpendingLimits", testSpendingLimitsReloadAll)
+	t.Run("Spendings", testSpendingsReloadAll)
+	t.Run("UserDeletions", testUserDeletionsReloadAll)
 	t.Run("Users", testUsersReloadAll)
+	t.Run("WalletMigrations", testWalletMigrationsReloadAll)
+	t.Run("Wallets", testWalletsReloadAll)
 }
 
 func TestSelect(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysSelect)
+	t.Run("AuthTokens", testAuthTokensSelect)
 	t.Run("GorpMigrations", testGorpMigrationsSelect)
+	t.Run("Guests", testGuestsSelect)
+	t.Run("IdempotencyKeys", testIdempotencyKeysSelect)
+	t.Run("SpendingLimits", testSpendingLimitsSelect)
+	t.Run("Spendings", testSpendingsSelect)
+	t.Run("UserDeletions", testUserDeletionsSelect)
 	t.Run("Users", testUsersSelect)
+	t.Run("WalletMigrations", testWalletMigrationsSelect)
+	t.Run("Wallets", testWalletsSelect)
 }
 
 func TestUpdate(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysUpdate)
+	t.Run("AuthTokens", testAuthTokensUpdate)
 	t.Run("GorpMigrations", testGorpMigrationsUpdate)
+	t.Run("Guests", testGuestsUpdate)
+	t.Run("IdempotencyKeys", testIdempotencyKeysUpdate)
+	t.Run("SpendingLimits", testSpendingLimitsUpdate)
+	t.Run("Spendings", testSpendingsUpdate)
+	t.Run("UserDeletions", testUserDeletionsUpdate)
 	t.Run("Users", testUsersUpdate)
+	t.Run("WalletMigrations", testWalletMigrationsUpdate)
+	t.Run("Wallets", testWalletsUpdate)
 }
 
 func TestSliceUpdateAll(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysSliceUpdateAll)
+	t.Run("AuthTokens", testAuthTokensSliceUpdateAll)
 	t.Run("GorpMigrations", testGorpMigrationsSliceUpdateAll)
+	t.Run("Guests", testGuestsSliceUpdateAll)
+	t.Run("IdempotencyKeys", testIdempotencyKeysSliceUpdateAll)
+	t.Run("SpendingLimits", testSpendingLimitsSliceUpdateAll)
+	t.Run("Spendings", testSpendingsSliceUpdateAll)
+	t.Run("UserDeletions", testUserDeletionsSliceUpdateAll)
 	t.Run("Users", testUsersSliceUpdateAll)
+	t.Run("WalletMigrations", testWalletMigrationsSliceUpdateAll)
+	t.Run("Wallets", testWalletsSliceUpdateAll)
 }
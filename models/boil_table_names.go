@@ -4,9 +4,27 @@
 package models
 
 var TableNames = struct {
-	GorpMigrations string
-	Users          string
+	APIKeys          string
+	AuthTokens       string
+	GorpMigrations   string
+	Guests           string
+	IdempotencyKeys  string
+	SpendingLimits   string
+	Spendings        string
+	UserDeletions    string
+	Users            string
+	WalletMigrations string
+	Wallets          string
 }{
-	GorpMigrations: "gorp_migrations",
-	Users:          "users",
+	APIKeys:          "api_keys",
+	AuthTokens:       "auth_tokens",
+	GorpMigrations:   "gorp_migrations",
+	Guests:           "guests",
+	IdempotencyKeys:  "idempotency_keys",
+	SpendingLimits:   "spending_limits",
+	Spendings:        "spendings",
+	UserDeletions:    "user_deletions",
+	Users:            "users",
+	WalletMigrations: "wallet_migrations",
+	Wallets:          "wallets",
 }
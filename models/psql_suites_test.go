@@ -6,7 +6,25 @@ package models
 import "testing"
 
 func TestUpsert(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysUpsert)
+
+	t.Run("AuthTokens", testAuthTokensUpsert)
+
 	t.Run("GorpMigrations", testGorpMigrationsUpsert)
 
+	t.Run("Guests", testGuestsUpsert)
+
+	t.Run("IdempotencyKeys", testIdempotencyKeysUpsert)
+
+	t.Run("SpendingLimits", testSpendingLimitsUpsert)
+
+	t.Run("Spendings", testSpendingsUpsert)
+
+	t.Run("UserDeletions", testUserDeletionsUpsert)
+
 	t.Run("Users", testUsersUpsert)
+
+	t.Run("WalletMigrations", testWalletMigrationsUpsert)
+
+	t.Run("Wallets", testWalletsUpsert)
 }
@@ -0,0 +1,1147 @@
+// Code generated by SQLBoiler (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+	"github.com/volatiletech/sqlboiler/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+// SpendingLimit is an object representing the database table.
+type SpendingLimit struct {
+	UserID           int        `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	CreatedAt        time.Time  `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt        time.Time  `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	DailyLimit       null.Int64 `boil:"daily_limit" json:"daily_limit,omitempty" toml:"daily_limit" yaml:"daily_limit,omitempty"`
+	TransactionLimit null.Int64 `boil:"transaction_limit" json:"transaction_limit,omitempty" toml:"transaction_limit" yaml:"transaction_limit,omitempty"`
+
+	R *spendingLimitR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L spendingLimitL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var SpendingLimitColumns = struct {
+	UserID           string
+	CreatedAt        string
+	UpdatedAt        string
+	DailyLimit       string
+	TransactionLimit string
+}{
+	UserID:           "user_id",
+	CreatedAt:        "created_at",
+	UpdatedAt:        "updated_at",
+	DailyLimit:       "daily_limit",
+	TransactionLimit: "transaction_limit",
+}
+
+// Generated where
+
+type whereHelpernull_Int64 struct{ field string }
+
+func (w whereHelpernull_Int64) EQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int64) NEQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+func (w whereHelpernull_Int64) LT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int64) LTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int64) GT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int64) GTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var SpendingLimitWhere = struct {
+	UserID           whereHelperint
+	CreatedAt        whereHelpertime_Time
+	UpdatedAt        whereHelpertime_Time
+	DailyLimit       whereHelpernull_Int64
+	TransactionLimit whereHelpernull_Int64
+}{
+	UserID:           whereHelperint{field: "\"spending_limits\".\"user_id\""},
+	CreatedAt:        whereHelpertime_Time{field: "\"spending_limits\".\"created_at\""},
+	UpdatedAt:        whereHelpertime_Time{field: "\"spending_limits\".\"updated_at\""},
+	DailyLimit:       whereHelpernull_Int64{field: "\"spending_limits\".\"daily_limit\""},
+	TransactionLimit: whereHelpernull_Int64{field: "\"spending_limits\".\"transaction_limit\""},
+}
+
+// SpendingLimitRels is where relationship names are stored.
+var SpendingLimitRels = struct {
+	User string
+}{
+	User: "User",
+}
+
+// spendingLimitR is where relationships are stored.
+type spendingLimitR struct {
+	User *User
+}
+
+// NewStruct creates a new relationship struct
+func (*spendingLimitR) NewStruct() *spendingLimitR {
+	return &spendingLimitR{}
+}
+
+// spendingLimitL is where Load methods for each relationship are stored.
+type spendingLimitL struct{}
+
+var (
+	spendingLimitAllColumns            = []string{"user_id", "created_at", "updated_at", "daily_limit", "transaction_limit"}
+	spendingLimitColumnsWithoutDefault = []string{"user_id", "daily_limit", "transaction_limit"}
+	spendingLimitColumnsWithDefault    = []string{"created_at", "updated_at"}
+	spendingLimitPrimaryKeyColumns     = []string{"user_id"}
+)
+
+type (
+	// SpendingLimitSlice is an alias for a slice of pointers to SpendingLimit.
+	// This should generally be used opposed to []SpendingLimit.
+	SpendingLimitSlice []*SpendingLimit
+	// SpendingLimitHook is the signature for custom SpendingLimit hook methods
+	SpendingLimitHook func(boil.Executor, *SpendingLimit) error
+
+	spendingLimitQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	spendingLimitType                 = reflect.TypeOf(&SpendingLimit{})
+	spendingLimitMapping              = queries.MakeStructMapping(spendingLimitType)
+	spendingLimitPrimaryKeyMapping, _ = queries.BindMapping(spendingLimitType, spendingLimitMapping, spendingLimitPrimaryKeyColumns)
+	spendingLimitInsertCacheMut       sync.RWMutex
+	spendingLimitInsertCache          = make(map[string]insertCache)
+	spendingLimitUpdateCacheMut       sync.RWMutex
+	spendingLimitUpdateCache          = make(map[string]updateCache)
+	spendingLimitUpsertCacheMut       sync.RWMutex
+	spendingLimitUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var spendingLimitBeforeInsertHooks []SpendingLimitHook
+var spendingLimitBeforeUpdateHooks []SpendingLimitHook
+var spendingLimitBeforeDeleteHooks []SpendingLimitHook
+var spendingLimitBeforeUpsertHooks []SpendingLimitHook
+
+var spendingLimitAfterInsertHooks []SpendingLimitHook
+var spendingLimitAfterSelectHooks []SpendingLimitHook
+var spendingLimitAfterUpdateHooks []SpendingLimitHook
+var spendingLimitAfterDeleteHooks []SpendingLimitHook
+var spendingLimitAfterUpsertHooks []SpendingLimitHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *SpendingLimit) doBeforeInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range spendingLimitBeforeInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *SpendingLimit) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range spendingLimitBeforeUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *SpendingLimit) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range spendingLimitBeforeDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *SpendingLimit) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range spendingLimitBeforeUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *SpendingLimit) doAfterInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range spendingLimitAfterInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *SpendingLimit) doAfterSelectHooks(exec boil.Executor) (err error) {
+	for _, hook := range spendingLimitAfterSelectHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *SpendingLimit) doAfterUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range spendingLimitAfterUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *SpendingLimit) doAfterDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range spendingLimitAfterDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *SpendingLimit) doAfterUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range spendingLimitAfterUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddSpendingLimitHook registers your hook function for all future operations.
+func AddSpendingLimitHook(hookPoint boil.HookPoint, spendingLimitHook SpendingLimitHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		spendingLimitBeforeInsertHooks = append(spendingLimitBeforeInsertHooks, spendingLimitHook)
+	case boil.BeforeUpdateHook:
+		spendingLimitBeforeUpdateHooks = append(spendingLimitBeforeUpdateHooks, spendingLimitHook)
+	case boil.BeforeDeleteHook:
+		spendingLimitBeforeDeleteHooks = append(spendingLimitBeforeDeleteHooks, spendingLimitHook)
+	case boil.BeforeUpsertHook:
+		spendingLimitBeforeUpsertHooks = append(spendingLimitBeforeUpsertHooks, spendingLimitHook)
+	case boil.AfterInsertHook:
+		spendingLimitAfterInsertHooks = append(spendingLimitAfterInsertHooks, spendingLimitHook)
+	case boil.AfterSelectHook:
+		spendingLimitAfterSelectHooks = append(spendingLimitAfterSelectHooks, spendingLimitHook)
+	case boil.AfterUpdateHook:
+		spendingLimitAfterUpdateHooks = append(spendingLimitAfterUpdateHooks, spendingLimitHook)
+	case boil.AfterDeleteHook:
+		spendingLimitAfterDeleteHooks = append(spendingLimitAfterDeleteHooks, spendingLimitHook)
+	case boil.AfterUpsertHook:
+		spendingLimitAfterUpsertHooks = append(spendingLimitAfterUpsertHooks, spendingLimitHook)
+	}
+}
+
+// OneG returns a single spendingLimit record from the query using the global executor.
+func (q spendingLimitQuery) OneG() (*SpendingLimit, error) {
+	return q.One(boil.GetDB())
+}
+
+// One returns a single spendingLimit record from the query.
+func (q spendingLimitQuery) One(exec boil.Executor) (*SpendingLimit, error) {
+	o := &SpendingLimit{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(nil, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for spending_limits")
+	}
+
+	if err := o.doAfterSelectHooks(exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// AllG returns all SpendingLimit records from the query using the global executor.
+func (q spendingLimitQuery) AllG() (SpendingLimitSlice, error) {
+	return q.All(boil.GetDB())
+}
+
+// All returns all SpendingLimit records from the query.
+func (q spendingLimitQuery) All(exec boil.Executor) (SpendingLimitSlice, error) {
+	var o []*SpendingLimit
+
+	err := q.Bind(nil, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to SpendingLimit slice")
+	}
+
+	if len(spendingLimitAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// CountG returns the count of all SpendingLimit records in the query, and panics on error.
+func (q spendingLimitQuery) CountG() (int64, error) {
+	return q.Count(boil.GetDB())
+}
+
+// Count returns the count of all SpendingLimit records in the query.
+func (q spendingLimitQuery) Count(exec boil.Executor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count spending_limits rows")
+	}
+
+	return count, nil
+}
+
+// ExistsG checks if the row exists in the table, and panics on error.
+func (q spendingLimitQuery) ExistsG() (bool, error) {
+	return q.Exists(boil.GetDB())
+}
+
+// Exists checks if the row exists in the table.
+func (q spendingLimitQuery) Exists(exec boil.Executor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if spending_limits exists")
+	}
+
+	return count > 0, nil
+}
+
+// User pointed to by the foreign key.
+func (o *SpendingLimit) User(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("id=?", o.UserID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Users(queryMods...)
+	queries.SetFrom(query.Query, "\"users\"")
+
+	return query
+}
+
+// LoadUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (spendingLimitL) LoadUser(e boil.Executor, singular bool, maybeSpendingLimit interface{}, mods queries.Applicator) error {
+	var slice []*SpendingLimit
+	var object *SpendingLimit
+
+	if singular {
+		object = maybeSpendingLimit.(*SpendingLimit)
+	} else {
+		slice = *maybeSpendingLimit.(*[]*SpendingLimit)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &spendingLimitR{}
+		}
+		args = append(args, object.UserID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &spendingLimitR{}
+			}
+
+			for _, a := range args {
+				if a == obj.UserID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.UserID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`users`), qm.WhereIn(`id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(spendingLimitAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.User = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.SpendingLimit = object
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.UserID == foreign.ID {
+				local.R.User = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.SpendingLimit = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetUserG of the spendingLimit to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.SpendingLimit.
+// Uses the global database handle.
+func (o *SpendingLimit) SetUserG(insert bool, related *User) error {
+	return o.SetUser(boil.GetDB(), insert, related)
+}
+
+// SetUser of the spendingLimit to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.SpendingLimit.
+func (o *SpendingLimit) SetUser(exec boil.Executor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"spending_limits\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, spendingLimitPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.UserID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	if _, err = exec.Exec(updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.UserID = related.ID
+	if o.R == nil {
+		o.R = &spendingLimitR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			SpendingLimit: o,
+		}
+	} else {
+		related.R.SpendingLimit = o
+	}
+
+	return nil
+}
+
+// SpendingLimits retrieves all the records using an executor.
+func SpendingLimits(mods ...qm.QueryMod) spendingLimitQuery {
+	mods = append(mods, qm.From("\"spending_limits\""))
+	return spendingLimitQuery{NewQuery(mods...)}
+}
+
+// FindSpendingLimitG retrieves a single record by ID.
+func FindSpendingLimitG(userID int, selectCols ...string) (*SpendingLimit, error) {
+	return FindSpendingLimit(boil.GetDB(), userID, selectCols...)
+}
+
+// FindSpendingLimit retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindSpendingLimit(exec boil.Executor, userID int, selectCols ...string) (*SpendingLimit, error) {
+	spendingLimitObj := &SpendingLimit{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"spending_limits\" where \"user_id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, userID)
+
+	err := q.Bind(nil, exec, spendingLimitObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from spending_limits")
+	}
+
+	return spendingLimitObj, nil
+}
+
+// InsertG a single record. See Insert for whitelist behavior description.
+func (o *SpendingLimit) InsertG(columns boil.Columns) error {
+	return o.Insert(boil.GetDB(), columns)
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *SpendingLimit) Insert(exec boil.Executor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no spending_limits provided for insertion")
+	}
+
+	var err error
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+	if o.UpdatedAt.IsZero() {
+		o.UpdatedAt = currTime
+	}
+
+	if err := o.doBeforeInsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(spendingLimitColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	spendingLimitInsertCacheMut.RLock()
+	cache, cached := spendingLimitInsertCache[key]
+	spendingLimitInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			spendingLimitAllColumns,
+			spendingLimitColumnsWithDefault,
+			spendingLimitColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(spendingLimitType, spendingLimitMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(spendingLimitType, spendingLimitMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"spending_limits\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"spending_limits\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into spending_limits")
+	}
+
+	if !cached {
+		spendingLimitInsertCacheMut.Lock()
+		spendingLimitInsertCache[key] = cache
+		spendingLimitInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(exec)
+}
+
+// UpdateG a single SpendingLimit record using the global executor.
+// See Update for more documentation.
+func (o *SpendingLimit) UpdateG(columns boil.Columns) (int64, error) {
+	return o.Update(boil.GetDB(), columns)
+}
+
+// Update uses an executor to update the SpendingLimit.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *SpendingLimit) Update(exec boil.Executor, columns boil.Columns) (int64, error) {
+	currTime := time.Now().In(boil.GetLocation())
+
+	o.UpdatedAt = currTime
+
+	var err error
+	if err = o.doBeforeUpdateHooks(exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	spendingLimitUpdateCacheMut.RLock()
+	cache, cached := spendingLimitUpdateCache[key]
+	spendingLimitUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			spendingLimitAllColumns,
+			spendingLimitPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update spending_limits, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"spending_limits\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, spendingLimitPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(spendingLimitType, spendingLimitMapping, append(wl, spendingLimitPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	var result sql.Result
+	result, err = exec.Exec(cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update spending_limits row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for spending_limits")
+	}
+
+	if !cached {
+		spendingLimitUpdateCacheMut.Lock()
+		spendingLimitUpdateCache[key] = cache
+		spendingLimitUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(exec)
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (q spendingLimitQuery) UpdateAllG(cols M) (int64, error) {
+	return q.UpdateAll(boil.GetDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q spendingLimitQuery) UpdateAll(exec boil.Executor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.Exec(exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for spending_limits")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for spending_limits")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (o SpendingLimitSlice) UpdateAllG(cols M) (int64, error) {
+	return o.UpdateAll(boil.GetDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o SpendingLimitSlice) UpdateAll(exec boil.Executor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), spendingLimitPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"spending_limits\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, spendingLimitPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.Exec(sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in spendingLimit slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all spendingLimit")
+	}
+	return rowsAff, nil
+}
+
+// UpsertG attempts an insert, and does an update or ignore on conflict.
+func (o *SpendingLimit) UpsertG(updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	return o.Upsert(boil.GetDB(), updateOnConflict, conflictColumns, updateColumns, insertColumns)
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *SpendingLimit) Upsert(exec boil.Executor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no spending_limits provided for upsert")
+	}
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+	o.UpdatedAt = currTime
+
+	if err := o.doBeforeUpsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(spendingLimitColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	spendingLimitUpsertCacheMut.RLock()
+	cache, cached := spendingLimitUpsertCache[key]
+	spendingLimitUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			spendingLimitAllColumns,
+			spendingLimitColumnsWithDefault,
+			spendingLimitColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			spendingLimitAllColumns,
+			spendingLimitPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert spending_limits, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(spendingLimitPrimaryKeyColumns))
+			copy(conflict, spendingLimitPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"spending_limits\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(spendingLimitType, spendingLimitMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(spendingLimitType, spendingLimitMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert spending_limits")
+	}
+
+	if !cached {
+		spendingLimitUpsertCacheMut.Lock()
+		spendingLimitUpsertCache[key] = cache
+		spendingLimitUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(exec)
+}
+
+// DeleteG deletes a single SpendingLimit record.
+// DeleteG will match against the primary key column to find the record to delete.
+func (o *SpendingLimit) DeleteG() (int64, error) {
+	return o.Delete(boil.GetDB())
+}
+
+// Delete deletes a single SpendingLimit record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *SpendingLimit) Delete(exec boil.Executor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no SpendingLimit provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), spendingLimitPrimaryKeyMapping)
+	sql := "DELETE FROM \"spending_limits\" WHERE \"user_id\"=$1"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.Exec(sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from spending_limits")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for spending_limits")
+	}
+
+	if err := o.doAfterDeleteHooks(exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q spendingLimitQuery) DeleteAll(exec boil.Executor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no spendingLimitQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.Exec(exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from spending_limits")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for spending_limits")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAllG deletes all rows in the slice.
+func (o SpendingLimitSlice) DeleteAllG() (int64, error) {
+	return o.DeleteAll(boil.GetDB())
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o SpendingLimitSlice) DeleteAll(exec boil.Executor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(spendingLimitBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), spendingLimitPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"spending_limits\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, spendingLimitPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+
+	result, err := exec.Exec(sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from spendingLimit slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for spending_limits")
+	}
+
+	if len(spendingLimitAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// ReloadG refetches the object from the database using the primary keys.
+func (o *SpendingLimit) ReloadG() error {
+	if o == nil {
+		return errors.New("models: no SpendingLimit provided for reload")
+	}
+
+	return o.Reload(boil.GetDB())
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *SpendingLimit) Reload(exec boil.Executor) error {
+	ret, err := FindSpendingLimit(exec, o.UserID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAllG refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *SpendingLimitSlice) ReloadAllG() error {
+	if o == nil {
+		return errors.New("models: empty SpendingLimitSlice provided for reload all")
+	}
+
+	return o.ReloadAll(boil.GetDB())
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *SpendingLimitSlice) ReloadAll(exec boil.Executor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := SpendingLimitSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), spendingLimitPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"spending_limits\".* FROM \"spending_limits\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, spendingLimitPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(nil, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in SpendingLimitSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// SpendingLimitExistsG checks if the SpendingLimit row exists.
+func SpendingLimitExistsG(userID int) (bool, error) {
+	return SpendingLimitExists(boil.GetDB(), userID)
+}
+
+// SpendingLimitExists checks if the SpendingLimit row exists.
+func SpendingLimitExists(exec boil.Executor, userID int) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"spending_limits\" where \"user_id\"=$1 limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, userID)
+	}
+
+	row := exec.QueryRow(sql, userID)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if spending_limits exists")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,817 @@
+// Code generated by SQLBoiler (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/randomize"
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testSpendingLimits(t *testing.T) {
+	t.Parallel()
+
+	query := SpendingLimits()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testSpendingLimitsDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &SpendingLimit{}
+	if err = randomize.Struct(seed, o, spendingLimitDBTypes, true, spendingLimitColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := SpendingLimits().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testSpendingLimitsQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &SpendingLimit{}
+	if err = randomize.Struct(seed, o, spendingLimitDBTypes, true, spendingLimitColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := SpendingLimits().DeleteAll(tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := SpendingLimits().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testSpendingLimitsSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &SpendingLimit{}
+	if err = randomize.Struct(seed, o, spendingLimitDBTypes, true, spendingLimitColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := SpendingLimitSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := SpendingLimits().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testSpendingLimitsExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &SpendingLimit{}
+	if err = randomize.Struct(seed, o, spendingLimitDBTypes, true, spendingLimitColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := SpendingLimitExists(tx, o.UserID)
+	if err != nil {
+		t.Errorf("Unable to check if SpendingLimit exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected SpendingLimitExists to return true, but got false.")
+	}
+}
+
+func testSpendingLimitsFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &SpendingLimit{}
+	if err = randomize.Struct(seed, o, spendingLimitDBTypes, true, spendingLimitColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	spendingLimitFound, err := FindSpendingLimit(tx, o.UserID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if spendingLimitFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testSpendingLimitsBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &SpendingLimit{}
+	if err = randomize.Struct(seed, o, spendingLimitDBTypes, true, spendingLimitColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = SpendingLimits().Bind(nil, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testSpendingLimitsOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &SpendingLimit{}
+	if err = randomize.Struct(seed, o, spendingLimitDBTypes, true, spendingLimitColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := SpendingLimits().One(tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testSpendingLimitsAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	spendingLimitOne := &SpendingLimit{}
+	spendingLimitTwo := &SpendingLimit{}
+	if err = randomize.Struct(seed, spendingLimitOne, spendingLimitDBTypes, false, spendingLimitColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+	if err = randomize.Struct(seed, spendingLimitTwo, spendingLimitDBTypes, false, spendingLimitColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = spendingLimitOne.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = spendingLimitTwo.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := SpendingLimits().All(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testSpendingLimitsCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	spendingLimitOne := &SpendingLimit{}
+	spendingLimitTwo := &SpendingLimit{}
+	if err = randomize.Struct(seed, spendingLimitOne, spendingLimitDBTypes, false, spendingLimitColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+	if err = randomize.Struct(seed, spendingLimitTwo, spendingLimitDBTypes, false, spendingLimitColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = spendingLimitOne.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = spendingLimitTwo.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := SpendingLimits().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func spendingLimitBeforeInsertHook(e boil.Executor, o *SpendingLimit) error {
+	*o = SpendingLimit{}
+	return nil
+}
+
+func spendingLimitAfterInsertHook(e boil.Executor, o *SpendingLimit) error {
+	*o = SpendingLimit{}
+	return nil
+}
+
+func spendingLimitAfterSelectHook(e boil.Executor, o *SpendingLimit) error {
+	*o = SpendingLimit{}
+	return nil
+}
+
+func spendingLimitBeforeUpdateHook(e boil.Executor, o *SpendingLimit) error {
+	*o = SpendingLimit{}
+	return nil
+}
+
+func spendingLimitAfterUpdateHook(e boil.Executor, o *SpendingLimit) error {
+	*o = SpendingLimit{}
+	return nil
+}
+
+func spendingLimitBeforeDeleteHook(e boil.Executor, o *SpendingLimit) error {
+	*o = SpendingLimit{}
+	return nil
+}
+
+func spendingLimitAfterDeleteHook(e boil.Executor, o *SpendingLimit) error {
+	*o = SpendingLimit{}
+	return nil
+}
+
+func spendingLimitBeforeUpsertHook(e boil.Executor, o *SpendingLimit) error {
+	*o = SpendingLimit{}
+	return nil
+}
+
+func spendingLimitAfterUpsertHook(e boil.Executor, o *SpendingLimit) error {
+	*o = SpendingLimit{}
+	return nil
+}
+
+func testSpendingLimitsHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	empty := &SpendingLimit{}
+	o := &SpendingLimit{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, spendingLimitDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit object: %s", err)
+	}
+
+	AddSpendingLimitHook(boil.BeforeInsertHook, spendingLimitBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	spendingLimitBeforeInsertHooks = []SpendingLimitHook{}
+
+	AddSpendingLimitHook(boil.AfterInsertHook, spendingLimitAfterInsertHook)
+	if err = o.doAfterInsertHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	spendingLimitAfterInsertHooks = []SpendingLimitHook{}
+
+	AddSpendingLimitHook(boil.AfterSelectHook, spendingLimitAfterSelectHook)
+	if err = o.doAfterSelectHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	spendingLimitAfterSelectHooks = []SpendingLimitHook{}
+
+	AddSpendingLimitHook(boil.BeforeUpdateHook, spendingLimitBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	spendingLimitBeforeUpdateHooks = []SpendingLimitHook{}
+
+	AddSpendingLimitHook(boil.AfterUpdateHook, spendingLimitAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	spendingLimitAfterUpdateHooks = []SpendingLimitHook{}
+
+	AddSpendingLimitHook(boil.BeforeDeleteHook, spendingLimitBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	spendingLimitBeforeDeleteHooks = []SpendingLimitHook{}
+
+	AddSpendingLimitHook(boil.AfterDeleteHook, spendingLimitAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	spendingLimitAfterDeleteHooks = []SpendingLimitHook{}
+
+	AddSpendingLimitHook(boil.BeforeUpsertHook, spendingLimitBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	spendingLimitBeforeUpsertHooks = []SpendingLimitHook{}
+
+	AddSpendingLimitHook(boil.AfterUpsertHook, spendingLimitAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	spendingLimitAfterUpsertHooks = []SpendingLimitHook{}
+}
+
+func testSpendingLimitsInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &SpendingLimit{}
+	if err = randomize.Struct(seed, o, spendingLimitDBTypes, true, spendingLimitColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := SpendingLimits().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testSpendingLimitsInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &SpendingLimit{}
+	if err = randomize.Struct(seed, o, spendingLimitDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Whitelist(spendingLimitColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := SpendingLimits().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testSpendingLimitToOneUserUsingUser(t *testing.T) {
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+
+	var local SpendingLimit
+	var foreign User
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &local, spendingLimitDBTypes, false, spendingLimitColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &foreign, userDBTypes, false, userColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize User struct: %s", err)
+	}
+
+	if err := foreign.Insert(tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	local.UserID = foreign.ID
+	if err := local.Insert(tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.User().One(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check.ID != foreign.ID {
+		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
+	}
+
+	slice := SpendingLimitSlice{&local}
+	if err = local.L.LoadUser(tx, false, (*[]*SpendingLimit)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.User == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.User = nil
+	if err = local.L.LoadUser(tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.User == nil {
+		t.Error("struct should have been eager loaded")
+	}
+}
+
+func testSpendingLimitToOneSetOpUserUsingUser(t *testing.T) {
+	var err error
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+
+	var a SpendingLimit
+	var b, c User
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, spendingLimitDBTypes, false, strmangle.SetComplement(spendingLimitPrimaryKeyColumns, spendingLimitColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, userDBTypes, false, strmangle.SetComplement(userPrimaryKeyColumns, userColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, userDBTypes, false, strmangle.SetComplement(userPrimaryKeyColumns, userColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Insert(tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*User{&b, &c} {
+		err = a.SetUser(tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.User != x {
+			t.Error("relationship struct not set to correct value")
+		}
+
+		if x.R.SpendingLimit != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+		if a.UserID != x.ID {
+			t.Error("foreign key was wrong value", a.UserID)
+		}
+
+		if exists, err := SpendingLimitExists(tx, a.UserID); err != nil {
+			t.Fatal(err)
+		} else if !exists {
+			t.Error("want 'a' to exist")
+		}
+
+	}
+}
+
+func testSpendingLimitsReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &SpendingLimit{}
+	if err = randomize.Struct(seed, o, spendingLimitDBTypes, true, spendingLimitColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testSpendingLimitsReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &SpendingLimit{}
+	if err = randomize.Struct(seed, o, spendingLimitDBTypes, true, spendingLimitColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := SpendingLimitSlice{o}
+
+	if err = slice.ReloadAll(tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testSpendingLimitsSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &SpendingLimit{}
+	if err = randomize.Struct(seed, o, spendingLimitDBTypes, true, spendingLimitColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := SpendingLimits().All(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	spendingLimitDBTypes = map[string]string{`UserID`: `integer`, `CreatedAt`: `timestamp without time zone`, `UpdatedAt`: `timestamp without time zone`, `DailyLimit`: `bigint`, `TransactionLimit`: `bigint`}
+	_                    = bytes.MinRead
+)
+
+func testSpendingLimitsUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(spendingLimitPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(spendingLimitAllColumns) == len(spendingLimitPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &SpendingLimit{}
+	if err = randomize.Struct(seed, o, spendingLimitDBTypes, true, spendingLimitColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := SpendingLimits().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, spendingLimitDBTypes, true, spendingLimitPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testSpendingLimitsSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(spendingLimitAllColumns) == len(spendingLimitPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &SpendingLimit{}
+	if err = randomize.Struct(seed, o, spendingLimitDBTypes, true, spendingLimitColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := SpendingLimits().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, spendingLimitDBTypes, true, spendingLimitPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(spendingLimitAllColumns, spendingLimitPrimaryKeyColumns) {
+		fields = spendingLimitAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			spendingLimitAllColumns,
+			spendingLimitPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := SpendingLimitSlice{o}
+	if rowsAff, err := slice.UpdateAll(tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testSpendingLimitsUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(spendingLimitAllColumns) == len(spendingLimitPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := SpendingLimit{}
+	if err = randomize.Struct(seed, &o, spendingLimitDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert SpendingLimit: %s", err)
+	}
+
+	count, err := SpendingLimits().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, spendingLimitDBTypes, false, spendingLimitPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize SpendingLimit struct: %s", err)
+	}
+
+	if err = o.Upsert(tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert SpendingLimit: %s", err)
+	}
+
+	count, err = SpendingLimits().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
@@ -0,0 +1,821 @@
+// Code generated by SQLBoiler (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/randomize"
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testSpendings(t *testing.T) {
+	t.Parallel()
+
+	query := Spendings()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testSpendingsDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Spending{}
+	if err = randomize.Struct(seed, o, spendingDBTypes, true, spendingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := Spendings().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testSpendingsQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Spending{}
+	if err = randomize.Struct(seed, o, spendingDBTypes, true, spendingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := Spendings().DeleteAll(tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := Spendings().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testSpendingsSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Spending{}
+	if err = randomize.Struct(seed, o, spendingDBTypes, true, spendingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := SpendingSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := Spendings().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testSpendingsExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Spending{}
+	if err = randomize.Struct(seed, o, spendingDBTypes, true, spendingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := SpendingExists(tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if Spending exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected SpendingExists to return true, but got false.")
+	}
+}
+
+func testSpendingsFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Spending{}
+	if err = randomize.Struct(seed, o, spendingDBTypes, true, spendingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	spendingFound, err := FindSpending(tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if spendingFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testSpendingsBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Spending{}
+	if err = randomize.Struct(seed, o, spendingDBTypes, true, spendingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = Spendings().Bind(nil, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testSpendingsOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Spending{}
+	if err = randomize.Struct(seed, o, spendingDBTypes, true, spendingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := Spendings().One(tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testSpendingsAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	spendingOne := &Spending{}
+	spendingTwo := &Spending{}
+	if err = randomize.Struct(seed, spendingOne, spendingDBTypes, false, spendingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+	if err = randomize.Struct(seed, spendingTwo, spendingDBTypes, false, spendingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = spendingOne.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = spendingTwo.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := Spendings().All(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testSpendingsCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	spendingOne := &Spending{}
+	spendingTwo := &Spending{}
+	if err = randomize.Struct(seed, spendingOne, spendingDBTypes, false, spendingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+	if err = randomize.Struct(seed, spendingTwo, spendingDBTypes, false, spendingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = spendingOne.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = spendingTwo.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Spendings().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func spendingBeforeInsertHook(e boil.Executor, o *Spending) error {
+	*o = Spending{}
+	return nil
+}
+
+func spendingAfterInsertHook(e boil.Executor, o *Spending) error {
+	*o = Spending{}
+	return nil
+}
+
+func spendingAfterSelectHook(e boil.Executor, o *Spending) error {
+	*o = Spending{}
+	return nil
+}
+
+func spendingBeforeUpdateHook(e boil.Executor, o *Spending) error {
+	*o = Spending{}
+	return nil
+}
+
+func spendingAfterUpdateHook(e boil.Executor, o *Spending) error {
+	*o = Spending{}
+	return nil
+}
+
+func spendingBeforeDeleteHook(e boil.Executor, o *Spending) error {
+	*o = Spending{}
+	return nil
+}
+
+func spendingAfterDeleteHook(e boil.Executor, o *Spending) error {
+	*o = Spending{}
+	return nil
+}
+
+func spendingBeforeUpsertHook(e boil.Executor, o *Spending) error {
+	*o = Spending{}
+	return nil
+}
+
+func spendingAfterUpsertHook(e boil.Executor, o *Spending) error {
+	*o = Spending{}
+	return nil
+}
+
+func testSpendingsHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	empty := &Spending{}
+	o := &Spending{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, spendingDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize Spending object: %s", err)
+	}
+
+	AddSpendingHook(boil.BeforeInsertHook, spendingBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	spendingBeforeInsertHooks = []SpendingHook{}
+
+	AddSpendingHook(boil.AfterInsertHook, spendingAfterInsertHook)
+	if err = o.doAfterInsertHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	spendingAfterInsertHooks = []SpendingHook{}
+
+	AddSpendingHook(boil.AfterSelectHook, spendingAfterSelectHook)
+	if err = o.doAfterSelectHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	spendingAfterSelectHooks = []SpendingHook{}
+
+	AddSpendingHook(boil.BeforeUpdateHook, spendingBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	spendingBeforeUpdateHooks = []SpendingHook{}
+
+	AddSpendingHook(boil.AfterUpdateHook, spendingAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	spendingAfterUpdateHooks = []SpendingHook{}
+
+	AddSpendingHook(boil.BeforeDeleteHook, spendingBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	spendingBeforeDeleteHooks = []SpendingHook{}
+
+	AddSpendingHook(boil.AfterDeleteHook, spendingAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	spendingAfterDeleteHooks = []SpendingHook{}
+
+	AddSpendingHook(boil.BeforeUpsertHook, spendingBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	spendingBeforeUpsertHooks = []SpendingHook{}
+
+	AddSpendingHook(boil.AfterUpsertHook, spendingAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	spendingAfterUpsertHooks = []SpendingHook{}
+}
+
+func testSpendingsInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Spending{}
+	if err = randomize.Struct(seed, o, spendingDBTypes, true, spendingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Spendings().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testSpendingsInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Spending{}
+	if err = randomize.Struct(seed, o, spendingDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Whitelist(spendingColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Spendings().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testSpendingToOneUserUsingUser(t *testing.T) {
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+
+	var local Spending
+	var foreign User
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &local, spendingDBTypes, false, spendingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &foreign, userDBTypes, false, userColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize User struct: %s", err)
+	}
+
+	if err := foreign.Insert(tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	local.UserID = foreign.ID
+	if err := local.Insert(tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.User().One(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check.ID != foreign.ID {
+		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
+	}
+
+	slice := SpendingSlice{&local}
+	if err = local.L.LoadUser(tx, false, (*[]*Spending)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.User == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.User = nil
+	if err = local.L.LoadUser(tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.User == nil {
+		t.Error("struct should have been eager loaded")
+	}
+}
+
+func testSpendingToOneSetOpUserUsingUser(t *testing.T) {
+	var err error
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+
+	var a Spending
+	var b, c User
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, spendingDBTypes, false, strmangle.SetComplement(spendingPrimaryKeyColumns, spendingColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, userDBTypes, false, strmangle.SetComplement(userPrimaryKeyColumns, userColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, userDBTypes, false, strmangle.SetComplement(userPrimaryKeyColumns, userColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Insert(tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*User{&b, &c} {
+		err = a.SetUser(tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.User != x {
+			t.Error("relationship struct not set to correct value")
+		}
+
+		if x.R.Spendings[0] != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+		if a.UserID != x.ID {
+			t.Error("foreign key was wrong value", a.UserID)
+		}
+
+		zero := reflect.Zero(reflect.TypeOf(a.UserID))
+		reflect.Indirect(reflect.ValueOf(&a.UserID)).Set(zero)
+
+		if err = a.Reload(tx); err != nil {
+			t.Fatal("failed to reload", err)
+		}
+
+		if a.UserID != x.ID {
+			t.Error("foreign key was wrong value", a.UserID, x.ID)
+		}
+	}
+}
+
+func testSpendingsReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Spending{}
+	if err = randomize.Struct(seed, o, spendingDBTypes, true, spendingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testSpendingsReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Spending{}
+	if err = randomize.Struct(seed, o, spendingDBTypes, true, spendingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := SpendingSlice{o}
+
+	if err = slice.ReloadAll(tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testSpendingsSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Spending{}
+	if err = randomize.Struct(seed, o, spendingDBTypes, true, spendingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := Spendings().All(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	spendingDBTypes = map[string]string{`ID`: `integer`, `UserID`: `integer`, `CreatedAt`: `timestamp without time zone`, `Method`: `character varying`, `Amount`: `bigint`}
+	_               = bytes.MinRead
+)
+
+func testSpendingsUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(spendingPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(spendingAllColumns) == len(spendingPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Spending{}
+	if err = randomize.Struct(seed, o, spendingDBTypes, true, spendingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Spendings().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, spendingDBTypes, true, spendingPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testSpendingsSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(spendingAllColumns) == len(spendingPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Spending{}
+	if err = randomize.Struct(seed, o, spendingDBTypes, true, spendingColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Spendings().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, spendingDBTypes, true, spendingPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(spendingAllColumns, spendingPrimaryKeyColumns) {
+		fields = spendingAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			spendingAllColumns,
+			spendingPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := SpendingSlice{o}
+	if rowsAff, err := slice.UpdateAll(tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testSpendingsUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(spendingAllColumns) == len(spendingPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := Spending{}
+	if err = randomize.Struct(seed, &o, spendingDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert Spending: %s", err)
+	}
+
+	count, err := Spendings().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, spendingDBTypes, false, spendingPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize Spending struct: %s", err)
+	}
+
+	if err = o.Upsert(tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert Spending: %s", err)
+	}
+
+	count, err = Spendings().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
@@ -0,0 +1,965 @@
+// Code generated by SQLBoiler (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+	"github.com/volatiletech/sqlboiler/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+// UserDeletion is an object representing the database table.
+type UserDeletion struct {
+	ID              int       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CreatedAt       time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	Wallets         int       `boil:"wallets" json:"wallets" toml:"wallets" yaml:"wallets"`
+	LegacyAccount   bool      `boil:"legacy_account" json:"legacy_account" toml:"legacy_account" yaml:"legacy_account"`
+	Exported        bool      `boil:"exported" json:"exported" toml:"exported" yaml:"exported"`
+	WalletFiles     int       `boil:"wallet_files" json:"wallet_files" toml:"wallet_files" yaml:"wallet_files"`
+	UploadDirs      int       `boil:"upload_dirs" json:"upload_dirs" toml:"upload_dirs" yaml:"upload_dirs"`
+	IdempotencyKeys int       `boil:"idempotency_keys" json:"idempotency_keys" toml:"idempotency_keys" yaml:"idempotency_keys"`
+
+	R *userDeletionR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L userDeletionL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var UserDeletionColumns = struct {
+	ID              string
+	CreatedAt       string
+	Wallets         string
+	LegacyAccount   string
+	Exported        string
+	WalletFiles     string
+	UploadDirs      string
+	IdempotencyKeys string
+}{
+	ID:              "id",
+	CreatedAt:       "created_at",
+	Wallets:         "wallets",
+	LegacyAccount:   "legacy_account",
+	Exported:        "exported",
+	WalletFiles:     "wallet_files",
+	UploadDirs:      "upload_dirs",
+	IdempotencyKeys: "idempotency_keys",
+}
+
+// Generated where
+
+type whereHelperbool struct{ field string }
+
+func (w whereHelperbool) EQ(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperbool) NEQ(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperbool) LT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
+var UserDeletionWhere = struct {
+	ID              whereHelperint
+	CreatedAt       whereHelpertime_Time
+	Wallets         whereHelperint
+	LegacyAccount   whereHelperbool
+	Exported        whereHelperbool
+	WalletFiles     whereHelperint
+	UploadDirs      whereHelperint
+	IdempotencyKeys whereHelperint
+}{
+	ID:              whereHelperint{field: "\"user_deletions\".\"id\""},
+	CreatedAt:       whereHelpertime_Time{field: "\"user_deletions\".\"created_at\""},
+	Wallets:         whereHelperint{field: "\"user_deletions\".\"wallets\""},
+	LegacyAccount:   whereHelperbool{field: "\"user_deletions\".\"legacy_account\""},
+	Exported:        whereHelperbool{field: "\"user_deletions\".\"exported\""},
+	WalletFiles:     whereHelperint{field: "\"user_deletions\".\"wallet_files\""},
+	UploadDirs:      whereHelperint{field: "\"user_deletions\".\"upload_dirs\""},
+	IdempotencyKeys: whereHelperint{field: "\"user_deletions\".\"idempotency_keys\""},
+}
+
+// UserDeletionRels is where relationship names are stored.
+var UserDeletionRels = struct {
+}{}
+
+// userDeletionR is where relationships are stored.
+type userDeletionR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*userDeletionR) NewStruct() *userDeletionR {
+	return &userDeletionR{}
+}
+
+// userDeletionL is where Load methods for each relationship are stored.
+type userDeletionL struct{}
+
+var (
+	userDeletionAllColumns            = []string{"id", "created_at", "wallets", "legacy_account", "exported", "wallet_files", "upload_dirs", "idempotency_keys"}
+	userDeletionColumnsWithoutDefault = []string{}
+	userDeletionColumnsWithDefault    = []string{"id", "created_at", "wallets", "legacy_account", "exported", "wallet_files", "upload_dirs", "idempotency_keys"}
+	userDeletionPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// UserDeletionSlice is an alias for a slice of pointers to UserDeletion.
+	// This should generally be used opposed to []UserDeletion.
+	UserDeletionSlice []*UserDeletion
+	// UserDeletionHook is the signature for custom UserDeletion hook methods
+	UserDeletionHook func(boil.Executor, *UserDeletion) error
+
+	userDeletionQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	userDeletionType                 = reflect.TypeOf(&UserDeletion{})
+	userDeletionMapping              = queries.MakeStructMapping(userDeletionType)
+	userDeletionPrimaryKeyMapping, _ = queries.BindMapping(userDeletionType, userDeletionMapping, userDeletionPrimaryKeyColumns)
+	userDeletionInsertCacheMut       sync.RWMutex
+	userDeletionInsertCache          = make(map[string]insertCache)
+	userDeletionUpdateCacheMut       sync.RWMutex
+	userDeletionUpdateCache          = make(map[string]updateCache)
+	userDeletionUpsertCacheMut       sync.RWMutex
+	userDeletionUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var userDeletionBeforeInsertHooks []UserDeletionHook
+var userDeletionBeforeUpdateHooks []UserDeletionHook
+var userDeletionBeforeDeleteHooks []UserDeletionHook
+var userDeletionBeforeUpsertHooks []UserDeletionHook
+
+var userDeletionAfterInsertHooks []UserDeletionHook
+var userDeletionAfterSelectHooks []UserDeletionHook
+var userDeletionAfterUpdateHooks []UserDeletionHook
+var userDeletionAfterDeleteHooks []UserDeletionHook
+var userDeletionAfterUpsertHooks []UserDeletionHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *UserDeletion) doBeforeInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range userDeletionBeforeInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *UserDeletion) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range userDeletionBeforeUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *UserDeletion) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range userDeletionBeforeDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *UserDeletion) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range userDeletionBeforeUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *UserDeletion) doAfterInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range userDeletionAfterInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *UserDeletion) doAfterSelectHooks(exec boil.Executor) (err error) {
+	for _, hook := range userDeletionAfterSelectHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *UserDeletion) doAfterUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range userDeletionAfterUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *UserDeletion) doAfterDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range userDeletionAfterDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *UserDeletion) doAfterUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range userDeletionAfterUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddUserDeletionHook registers your hook function for all future operations.
+func AddUserDeletionHook(hookPoint boil.HookPoint, userDeletionHook UserDeletionHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		userDeletionBeforeInsertHooks = append(userDeletionBeforeInsertHooks, userDeletionHook)
+	case boil.BeforeUpdateHook:
+		userDeletionBeforeUpdateHooks = append(userDeletionBeforeUpdateHooks, userDeletionHook)
+	case boil.BeforeDeleteHook:
+		userDeletionBeforeDeleteHooks = append(userDeletionBeforeDeleteHooks, userDeletionHook)
+	case boil.BeforeUpsertHook:
+		userDeletionBeforeUpsertHooks = append(userDeletionBeforeUpsertHooks, userDeletionHook)
+	case boil.AfterInsertHook:
+		userDeletionAfterInsertHooks = append(userDeletionAfterInsertHooks, userDeletionHook)
+	case boil.AfterSelectHook:
+		userDeletionAfterSelectHooks = append(userDeletionAfterSelectHooks, userDeletionHook)
+	case boil.AfterUpdateHook:
+		userDeletionAfterUpdateHooks = append(userDeletionAfterUpdateHooks, userDeletionHook)
+	case boil.AfterDeleteHook:
+		userDeletionAfterDeleteHooks = append(userDeletionAfterDeleteHooks, userDeletionHook)
+	case boil.AfterUpsertHook:
+		userDeletionAfterUpsertHooks = append(userDeletionAfterUpsertHooks, userDeletionHook)
+	}
+}
+
+// OneG returns a single userDeletion record from the query using the global executor.
+func (q userDeletionQuery) OneG() (*UserDeletion, error) {
+	return q.One(boil.GetDB())
+}
+
+// One returns a single userDeletion record from the query.
+func (q userDeletionQuery) One(exec boil.Executor) (*UserDeletion, error) {
+	o := &UserDeletion{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(nil, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for user_deletions")
+	}
+
+	if err := o.doAfterSelectHooks(exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// AllG returns all UserDeletion records from the query using the global executor.
+func (q userDeletionQuery) AllG() (UserDeletionSlice, error) {
+	return q.All(boil.GetDB())
+}
+
+// All returns all UserDeletion records from the query.
+func (q userDeletionQuery) All(exec boil.Executor) (UserDeletionSlice, error) {
+	var o []*UserDeletion
+
+	err := q.Bind(nil, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to UserDeletion slice")
+	}
+
+	if len(userDeletionAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// CountG returns the count of all UserDeletion records in the query, and panics on error.
+func (q userDeletionQuery) CountG() (int64, error) {
+	return q.Count(boil.GetDB())
+}
+
+// Count returns the count of all UserDeletion records in the query.
+func (q userDeletionQuery) Count(exec boil.Executor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count user_deletions rows")
+	}
+
+	return count, nil
+}
+
+// ExistsG checks if the row exists in the table, and panics on error.
+func (q userDeletionQuery) ExistsG() (bool, error) {
+	return q.Exists(boil.GetDB())
+}
+
+// Exists checks if the row exists in the table.
+func (q userDeletionQuery) Exists(exec boil.Executor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if user_deletions exists")
+	}
+
+	return count > 0, nil
+}
+
+// UserDeletions retrieves all the records using an executor.
+func UserDeletions(mods ...qm.QueryMod) userDeletionQuery {
+	mods = append(mods, qm.From("\"user_deletions\""))
+	return userDeletionQuery{NewQuery(mods...)}
+}
+
+// FindUserDeletionG retrieves a single record by ID.
+func FindUserDeletionG(iD int, selectCols ...string) (*UserDeletion, error) {
+	return FindUserDeletion(boil.GetDB(), iD, selectCols...)
+}
+
+// FindUserDeletion retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindUserDeletion(exec boil.Executor, iD int, selectCols ...string) (*UserDeletion, error) {
+	userDeletionObj := &UserDeletion{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"user_deletions\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(nil, exec, userDeletionObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from user_deletions")
+	}
+
+	return userDeletionObj, nil
+}
+
+// InsertG a single record. See Insert for whitelist behavior description.
+func (o *UserDeletion) InsertG(columns boil.Columns) error {
+	return o.Insert(boil.GetDB(), columns)
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *UserDeletion) Insert(exec boil.Executor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no user_deletions provided for insertion")
+	}
+
+	var err error
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+
+	if err := o.doBeforeInsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(userDeletionColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	userDeletionInsertCacheMut.RLock()
+	cache, cached := userDeletionInsertCache[key]
+	userDeletionInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			userDeletionAllColumns,
+			userDeletionColumnsWithDefault,
+			userDeletionColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(userDeletionType, userDeletionMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(userDeletionType, userDeletionMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"user_deletions\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"user_deletions\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into user_deletions")
+	}
+
+	if !cached {
+		userDeletionInsertCacheMut.Lock()
+		userDeletionInsertCache[key] = cache
+		userDeletionInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(exec)
+}
+
+// UpdateG a single UserDeletion record using the global executor.
+// See Update for more documentation.
+func (o *UserDeletion) UpdateG(columns boil.Columns) (int64, error) {
+	return o.Update(boil.GetDB(), columns)
+}
+
+// Update uses an executor to update the UserDeletion.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *UserDeletion) Update(exec boil.Executor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	userDeletionUpdateCacheMut.RLock()
+	cache, cached := userDeletionUpdateCache[key]
+	userDeletionUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			userDeletionAllColumns,
+			userDeletionPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update user_deletions, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"user_deletions\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, userDeletionPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(userDeletionType, userDeletionMapping, append(wl, userDeletionPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	var result sql.Result
+	result, err = exec.Exec(cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update user_deletions row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for user_deletions")
+	}
+
+	if !cached {
+		userDeletionUpdateCacheMut.Lock()
+		userDeletionUpdateCache[key] = cache
+		userDeletionUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(exec)
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (q userDeletionQuery) UpdateAllG(cols M) (int64, error) {
+	return q.UpdateAll(boil.GetDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q userDeletionQuery) UpdateAll(exec boil.Executor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.Exec(exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for user_deletions")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for user_deletions")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (o UserDeletionSlice) UpdateAllG(cols M) (int64, error) {
+	return o.UpdateAll(boil.GetDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o UserDeletionSlice) UpdateAll(exec boil.Executor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), userDeletionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"user_deletions\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, userDeletionPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.Exec(sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in userDeletion slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all userDeletion")
+	}
+	return rowsAff, nil
+}
+
+// UpsertG attempts an insert, and does an update or ignore on conflict.
+func (o *UserDeletion) UpsertG(updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	return o.Upsert(boil.GetDB(), updateOnConflict, conflictColumns, updateColumns, insertColumns)
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *UserDeletion) Upsert(exec boil.Executor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no user_deletions provided for upsert")
+	}
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(userDeletionColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	userDeletionUpsertCacheMut.RLock()
+	cache, cached := userDeletionUpsertCache[key]
+	userDeletionUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			userDeletionAllColumns,
+			userDeletionColumnsWithDefault,
+			userDeletionColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			userDeletionAllColumns,
+			userDeletionPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert user_deletions, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(userDeletionPrimaryKeyColumns))
+			copy(conflict, userDeletionPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"user_deletions\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(userDeletionType, userDeletionMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(userDeletionType, userDeletionMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert user_deletions")
+	}
+
+	if !cached {
+		userDeletionUpsertCacheMut.Lock()
+		userDeletionUpsertCache[key] = cache
+		userDeletionUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(exec)
+}
+
+// DeleteG deletes a single UserDeletion record.
+// DeleteG will match against the primary key column to find the record to delete.
+func (o *UserDeletion) DeleteG() (int64, error) {
+	return o.Delete(boil.GetDB())
+}
+
+// Delete deletes a single UserDeletion record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *UserDeletion) Delete(exec boil.Executor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no UserDeletion provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), userDeletionPrimaryKeyMapping)
+	sql := "DELETE FROM \"user_deletions\" WHERE \"id\"=$1"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.Exec(sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from user_deletions")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for user_deletions")
+	}
+
+	if err := o.doAfterDeleteHooks(exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q userDeletionQuery) DeleteAll(exec boil.Executor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no userDeletionQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.Exec(exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from user_deletions")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for user_deletions")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAllG deletes all rows in the slice.
+func (o UserDeletionSlice) DeleteAllG() (int64, error) {
+	return o.DeleteAll(boil.GetDB())
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o UserDeletionSlice) DeleteAll(exec boil.Executor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(userDeletionBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), userDeletionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"user_deletions\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, userDeletionPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+
+	result, err := exec.Exec(sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from userDeletion slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for user_deletions")
+	}
+
+	if len(userDeletionAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// ReloadG refetches the object from the database using the primary keys.
+func (o *UserDeletion) ReloadG() error {
+	if o == nil {
+		return errors.New("models: no UserDeletion provided for reload")
+	}
+
+	return o.Reload(boil.GetDB())
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *UserDeletion) Reload(exec boil.Executor) error {
+	ret, err := FindUserDeletion(exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAllG refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *UserDeletionSlice) ReloadAllG() error {
+	if o == nil {
+		return errors.New("models: empty UserDeletionSlice provided for reload all")
+	}
+
+	return o.ReloadAll(boil.GetDB())
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *UserDeletionSlice) ReloadAll(exec boil.Executor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := UserDeletionSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), userDeletionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"user_deletions\".* FROM \"user_deletions\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, userDeletionPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(nil, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in UserDeletionSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// UserDeletionExistsG checks if the UserDeletion row exists.
+func UserDeletionExistsG(iD int) (bool, error) {
+	return UserDeletionExists(boil.GetDB(), iD)
+}
+
+// UserDeletionExists checks if the UserDeletion row exists.
+func UserDeletionExists(exec boil.Executor, iD int) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"user_deletions\" where \"id\"=$1 limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+
+	row := exec.QueryRow(sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if user_deletions exists")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,713 @@
+// Code generated by SQLBoiler (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/randomize"
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testUserDeletions(t *testing.T) {
+	t.Parallel()
+
+	query := UserDeletions()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testUserDeletionsDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserDeletion{}
+	if err = randomize.Struct(seed, o, userDeletionDBTypes, true, userDeletionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := UserDeletions().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testUserDeletionsQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserDeletion{}
+	if err = randomize.Struct(seed, o, userDeletionDBTypes, true, userDeletionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := UserDeletions().DeleteAll(tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := UserDeletions().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testUserDeletionsSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserDeletion{}
+	if err = randomize.Struct(seed, o, userDeletionDBTypes, true, userDeletionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := UserDeletionSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := UserDeletions().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testUserDeletionsExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserDeletion{}
+	if err = randomize.Struct(seed, o, userDeletionDBTypes, true, userDeletionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := UserDeletionExists(tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if UserDeletion exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected UserDeletionExists to return true, but got false.")
+	}
+}
+
+func testUserDeletionsFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserDeletion{}
+	if err = randomize.Struct(seed, o, userDeletionDBTypes, true, userDeletionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	userDeletionFound, err := FindUserDeletion(tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if userDeletionFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testUserDeletionsBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserDeletion{}
+	if err = randomize.Struct(seed, o, userDeletionDBTypes, true, userDeletionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = UserDeletions().Bind(nil, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testUserDeletionsOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserDeletion{}
+	if err = randomize.Struct(seed, o, userDeletionDBTypes, true, userDeletionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := UserDeletions().One(tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testUserDeletionsAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	userDeletionOne := &UserDeletion{}
+	userDeletionTwo := &UserDeletion{}
+	if err = randomize.Struct(seed, userDeletionOne, userDeletionDBTypes, false, userDeletionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+	if err = randomize.Struct(seed, userDeletionTwo, userDeletionDBTypes, false, userDeletionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = userDeletionOne.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = userDeletionTwo.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := UserDeletions().All(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testUserDeletionsCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	userDeletionOne := &UserDeletion{}
+	userDeletionTwo := &UserDeletion{}
+	if err = randomize.Struct(seed, userDeletionOne, userDeletionDBTypes, false, userDeletionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+	if err = randomize.Struct(seed, userDeletionTwo, userDeletionDBTypes, false, userDeletionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = userDeletionOne.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = userDeletionTwo.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := UserDeletions().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func userDeletionBeforeInsertHook(e boil.Executor, o *UserDeletion) error {
+	*o = UserDeletion{}
+	return nil
+}
+
+func userDeletionAfterInsertHook(e boil.Executor, o *UserDeletion) error {
+	*o = UserDeletion{}
+	return nil
+}
+
+func userDeletionAfterSelectHook(e boil.Executor, o *UserDeletion) error {
+	*o = UserDeletion{}
+	return nil
+}
+
+func userDeletionBeforeUpdateHook(e boil.Executor, o *UserDeletion) error {
+	*o = UserDeletion{}
+	return nil
+}
+
+func userDeletionAfterUpdateHook(e boil.Executor, o *UserDeletion) error {
+	*o = UserDeletion{}
+	return nil
+}
+
+func userDeletionBeforeDeleteHook(e boil.Executor, o *UserDeletion) error {
+	*o = UserDeletion{}
+	return nil
+}
+
+func userDeletionAfterDeleteHook(e boil.Executor, o *UserDeletion) error {
+	*o = UserDeletion{}
+	return nil
+}
+
+func userDeletionBeforeUpsertHook(e boil.Executor, o *UserDeletion) error {
+	*o = UserDeletion{}
+	return nil
+}
+
+func userDeletionAfterUpsertHook(e boil.Executor, o *UserDeletion) error {
+	*o = UserDeletion{}
+	return nil
+}
+
+func testUserDeletionsHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	empty := &UserDeletion{}
+	o := &UserDeletion{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, userDeletionDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize UserDeletion object: %s", err)
+	}
+
+	AddUserDeletionHook(boil.BeforeInsertHook, userDeletionBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	userDeletionBeforeInsertHooks = []UserDeletionHook{}
+
+	AddUserDeletionHook(boil.AfterInsertHook, userDeletionAfterInsertHook)
+	if err = o.doAfterInsertHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	userDeletionAfterInsertHooks = []UserDeletionHook{}
+
+	AddUserDeletionHook(boil.AfterSelectHook, userDeletionAfterSelectHook)
+	if err = o.doAfterSelectHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	userDeletionAfterSelectHooks = []UserDeletionHook{}
+
+	AddUserDeletionHook(boil.BeforeUpdateHook, userDeletionBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	userDeletionBeforeUpdateHooks = []UserDeletionHook{}
+
+	AddUserDeletionHook(boil.AfterUpdateHook, userDeletionAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	userDeletionAfterUpdateHooks = []UserDeletionHook{}
+
+	AddUserDeletionHook(boil.BeforeDeleteHook, userDeletionBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	userDeletionBeforeDeleteHooks = []UserDeletionHook{}
+
+	AddUserDeletionHook(boil.AfterDeleteHook, userDeletionAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	userDeletionAfterDeleteHooks = []UserDeletionHook{}
+
+	AddUserDeletionHook(boil.BeforeUpsertHook, userDeletionBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	userDeletionBeforeUpsertHooks = []UserDeletionHook{}
+
+	AddUserDeletionHook(boil.AfterUpsertHook, userDeletionAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	userDeletionAfterUpsertHooks = []UserDeletionHook{}
+}
+
+func testUserDeletionsInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserDeletion{}
+	if err = randomize.Struct(seed, o, userDeletionDBTypes, true, userDeletionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := UserDeletions().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testUserDeletionsInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserDeletion{}
+	if err = randomize.Struct(seed, o, userDeletionDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Whitelist(userDeletionColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := UserDeletions().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testUserDeletionsReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserDeletion{}
+	if err = randomize.Struct(seed, o, userDeletionDBTypes, true, userDeletionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testUserDeletionsReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserDeletion{}
+	if err = randomize.Struct(seed, o, userDeletionDBTypes, true, userDeletionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := UserDeletionSlice{o}
+
+	if err = slice.ReloadAll(tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testUserDeletionsSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserDeletion{}
+	if err = randomize.Struct(seed, o, userDeletionDBTypes, true, userDeletionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := UserDeletions().All(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	userDeletionDBTypes = map[string]string{`ID`: `integer`, `CreatedAt`: `timestamp without time zone`, `Wallets`: `integer`, `LegacyAccount`: `boolean`, `Exported`: `boolean`, `WalletFiles`: `integer`, `UploadDirs`: `integer`, `IdempotencyKeys`: `integer`}
+	_                   = bytes.MinRead
+)
+
+func testUserDeletionsUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(userDeletionPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(userDeletionAllColumns) == len(userDeletionPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserDeletion{}
+	if err = randomize.Struct(seed, o, userDeletionDBTypes, true, userDeletionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := UserDeletions().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, userDeletionDBTypes, true, userDeletionPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testUserDeletionsSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(userDeletionAllColumns) == len(userDeletionPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserDeletion{}
+	if err = randomize.Struct(seed, o, userDeletionDBTypes, true, userDeletionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := UserDeletions().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, userDeletionDBTypes, true, userDeletionPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(userDeletionAllColumns, userDeletionPrimaryKeyColumns) {
+		fields = userDeletionAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			userDeletionAllColumns,
+			userDeletionPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := UserDeletionSlice{o}
+	if rowsAff, err := slice.UpdateAll(tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testUserDeletionsUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(userDeletionAllColumns) == len(userDeletionPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := UserDeletion{}
+	if err = randomize.Struct(seed, &o, userDeletionDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert UserDeletion: %s", err)
+	}
+
+	count, err := UserDeletions().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, userDeletionDBTypes, false, userDeletionPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize UserDeletion struct: %s", err)
+	}
+
+	if err = o.Upsert(tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert UserDeletion: %s", err)
+	}
+
+	count, err = UserDeletions().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
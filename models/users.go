@@ -28,6 +28,10 @@ type User struct {
 	UpdatedAt    time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
 	SDKAccountID null.String `boil:"sdk_account_id" json:"sdk_account_id,omitempty" toml:"sdk_account_id" yaml:"sdk_account_id,omitempty"`
 	WalletID     string      `boil:"wallet_id" json:"wallet_id" toml:"wallet_id" yaml:"wallet_id"`
+	Email        string      `boil:"email" json:"email" toml:"email" yaml:"email"`
+	LastSeenAt   null.Time   `boil:"last_seen_at" json:"last_seen_at,omitempty" toml:"last_seen_at" yaml:"last_seen_at,omitempty"`
+	LastIP       string      `boil:"last_ip" json:"last_ip" toml:"last_ip" yaml:"last_ip"`
+	LoginCount   int         `boil:"login_count" json:"login_count" toml:"login_count" yaml:"login_count"`
 
 	R *userR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L userL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -39,46 +43,24 @@ var UserColumns = struct {
 	UpdatedAt    string
 	SDKAccountID string
 	WalletID     string
+	Email        string
+	LastSeenAt   string
+	LastIP       string
+	LoginCount   string
 }{
 	ID:           "id",
 	CreatedAt:    "created_at",
 	UpdatedAt:    "updated_at",
 	SDKAccountID: "sdk_account_id",
 	WalletID:     "wallet_id",
+	Email:        "email",
+	LastSeenAt:   "last_seen_at",
+	LastIP:       "last_ip",
+	LoginCount:   "login_count",
 }
 
 // Generated where
 
-type whereHelperint struct{ field string }
-
-func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-
-type whereHelpertime_Time struct{ field string }
-
-func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
-}
-func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
-}
-func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
 type whereHelpernull_String struct{ field string }
 
 func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
@@ -108,20 +90,50 @@ var UserWhere = struct {
 	UpdatedAt    whereHelpertime_Time
 	SDKAccountID whereHelpernull_String
 	WalletID     whereHelperstring
+	Email        whereHelperstring
+	LastSeenAt   whereHelpernull_Time
+	LastIP       whereHelperstring
+	LoginCount   whereHelperint
 }{
 	ID:           whereHelperint{field: "\"users\".\"id\""},
 	CreatedAt:    whereHelpertime_Time{field: "\"users\".\"created_at\""},
 	UpdatedAt:    whereHelpertime_Time{field: "\"users\".\"updated_at\""},
 	SDKAccountID: whereHelpernull_String{field: "\"users\".\"sdk_account_id\""},
 	WalletID:     whereHelperstring{field: "\"users\".\"wallet_id\""},
+	Email:        whereHelperstring{field: "\"users\".\"email\""},
+	LastSeenAt:   whereHelpernull_Time{field: "\"users\".\"last_seen_at\""},
+	LastIP:       whereHelperstring{field: "\"users\".\"last_ip\""},
+	LoginCount:   whereHelperint{field: "\"users\".\"login_count\""},
 }
 
 // UserRels is where relationship names are stored.
 var UserRels = struct {
-}{}
+	SpendingLimit    string
+	WalletMigration  string
+	APIKeys          string
+	AuthTokens       string
+	MergedUserGuests string
+	Spendings        string
+	Wallets          string
+}{
+	SpendingLimit:    "SpendingLimit",
+	WalletMigration:  "WalletMigration",
+	APIKeys:          "APIKeys",
+	AuthTokens:       "AuthTokens",
+	MergedUserGuests: "MergedUserGuests",
+	Spendings:        "Spendings",
+	Wallets:          "Wallets",
+}
 
 // userR is where relationships are stored.
 type userR struct {
+	SpendingLimit    *SpendingLimit
+	WalletMigration  *WalletMigration
+	APIKeys          APIKeySlice
+	AuthTokens       AuthTokenSlice
+	MergedUserGuests GuestSlice
+	Spendings        SpendingSlice
+	Wallets          WalletSlice
 }
 
 // NewStruct creates a new relationship struct
@@ -133,9 +145,9 @@ func (*userR) NewStruct() *userR {
 type userL struct{}
 
 var (
-	userAllColumns            = []string{"id", "created_at", "updated_at", "sdk_account_id", "wallet_id"}
-	userColumnsWithoutDefault = []string{"id", "sdk_account_id", "wallet_id"}
-	userColumnsWithDefault    = []string{"created_at", "updated_at"}
+	userAllColumns            = []string{"id", "created_at", "updated_at", "sdk_account_id", "wallet_id", "email", "last_seen_at", "last_ip", "login_count"}
+	userColumnsWithoutDefault = []string{"id", "sdk_account_id", "wallet_id", "last_seen_at"}
+	userColumnsWithDefault    = []string{"created_at", "updated_at", "email", "last_ip", "login_count"}
 	userPrimaryKeyColumns     = []string{"id"}
 )
 
@@ -398,6 +410,1327 @@ func (q userQuery) Exists(exec boil.Executor) (bool, error) {
 	return count > 0, nil
 }
 
+// SpendingLimit pointed to by the foreign key.
+func (o *User) SpendingLimit(mods ...qm.QueryMod) spendingLimitQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("user_id=?", o.ID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := SpendingLimits(queryMods...)
+	queries.SetFrom(query.Query, "\"spending_limits\"")
+
+	return query
+}
+
+// WalletMigration pointed to by the foreign key.
+func (o *User) WalletMigration(mods ...qm.QueryMod) walletMigrationQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("user_id=?", o.ID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := WalletMigrations(queryMods...)
+	queries.SetFrom(query.Query, "\"wallet_migrations\"")
+
+	return query
+}
+
+// APIKeys retrieves all the api_key's APIKeys with an executor.
+func (o *User) APIKeys(mods ...qm.QueryMod) apiKeyQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"api_keys\".\"user_id\"=?", o.ID),
+	)
+
+	query := APIKeys(queryMods...)
+	queries.SetFrom(query.Query, "\"api_keys\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"api_keys\".*"})
+	}
+
+	return query
+}
+
+// AuthTokens retrieves all the auth_token's AuthTokens with an executor.
+func (o *User) AuthTokens(mods ...qm.QueryMod) authTokenQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"auth_tokens\".\"user_id\"=?", o.ID),
+	)
+
+	query := AuthTokens(queryMods...)
+	queries.SetFrom(query.Query, "\"auth_tokens\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"auth_tokens\".*"})
+	}
+
+	return query
+}
+
+// MergedUserGuests retrieves all the guest's Guests with an executor via merged_user_id column.
+func (o *User) MergedUserGuests(mods ...qm.QueryMod) guestQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"guests\".\"merged_user_id\"=?", o.ID),
+	)
+
+	query := Guests(queryMods...)
+	queries.SetFrom(query.Query, "\"guests\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"guests\".*"})
+	}
+
+	return query
+}
+
+// Spendings retrieves all the spending's Spendings with an executor.
+func (o *User) Spendings(mods ...qm.QueryMod) spendingQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"spendings\".\"user_id\"=?", o.ID),
+	)
+
+	query := Spendings(queryMods...)
+	queries.SetFrom(query.Query, "\"spendings\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"spendings\".*"})
+	}
+
+	return query
+}
+
+// Wallets retrieves all the wallet's Wallets with an executor.
+func (o *User) Wallets(mods ...qm.QueryMod) walletQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"wallets\".\"user_id\"=?", o.ID),
+	)
+
+	query := Wallets(queryMods...)
+	queries.SetFrom(query.Query, "\"wallets\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"wallets\".*"})
+	}
+
+	return query
+}
+
+// LoadSpendingLimit allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-1 relationship.
+func (userL) LoadSpendingLimit(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`spending_limits`), qm.WhereIn(`user_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load SpendingLimit")
+	}
+
+	var resultSlice []*SpendingLimit
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice SpendingLimit")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for spending_limits")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for spending_limits")
+	}
+
+	if len(userAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.SpendingLimit = foreign
+		if foreign.R == nil {
+			foreign.R = &spendingLimitR{}
+		}
+		foreign.R.User = object
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ID == foreign.UserID {
+				local.R.SpendingLimit = foreign
+				if foreign.R == nil {
+					foreign.R = &spendingLimitR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadWalletMigration allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-1 relationship.
+func (userL) LoadWalletMigration(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`wallet_migrations`), qm.WhereIn(`user_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load WalletMigration")
+	}
+
+	var resultSlice []*WalletMigration
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice WalletMigration")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for wallet_migrations")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for wallet_migrations")
+	}
+
+	if len(userAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.WalletMigration = foreign
+		if foreign.R == nil {
+			foreign.R = &walletMigrationR{}
+		}
+		foreign.R.User = object
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ID == foreign.UserID {
+				local.R.WalletMigration = foreign
+				if foreign.R == nil {
+					foreign.R = &walletMigrationR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadAPIKeys allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadAPIKeys(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`api_keys`), qm.WhereIn(`user_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load api_keys")
+	}
+
+	var resultSlice []*APIKey
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice api_keys")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on api_keys")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for api_keys")
+	}
+
+	if len(apiKeyAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.APIKeys = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &apiKeyR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.APIKeys = append(local.R.APIKeys, foreign)
+				if foreign.R == nil {
+					foreign.R = &apiKeyR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadAuthTokens allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadAuthTokens(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`auth_tokens`), qm.WhereIn(`user_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load auth_tokens")
+	}
+
+	var resultSlice []*AuthToken
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice auth_tokens")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on auth_tokens")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for auth_tokens")
+	}
+
+	if len(authTokenAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.AuthTokens = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &authTokenR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.AuthTokens = append(local.R.AuthTokens, foreign)
+				if foreign.R == nil {
+					foreign.R = &authTokenR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadMergedUserGuests allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadMergedUserGuests(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`guests`), qm.WhereIn(`merged_user_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load guests")
+	}
+
+	var resultSlice []*Guest
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice guests")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on guests")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for guests")
+	}
+
+	if len(guestAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.MergedUserGuests = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &guestR{}
+			}
+			foreign.R.MergedUser = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.MergedUserID) {
+				local.R.MergedUserGuests = append(local.R.MergedUserGuests, foreign)
+				if foreign.R == nil {
+					foreign.R = &guestR{}
+				}
+				foreign.R.MergedUser = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadSpendings allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadSpendings(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`spendings`), qm.WhereIn(`user_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load spendings")
+	}
+
+	var resultSlice []*Spending
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice spendings")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on spendings")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for spendings")
+	}
+
+	if len(spendingAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Spendings = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &spendingR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.Spendings = append(local.R.Spendings, foreign)
+				if foreign.R == nil {
+					foreign.R = &spendingR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadWallets allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadWallets(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`wallets`), qm.WhereIn(`user_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load wallets")
+	}
+
+	var resultSlice []*Wallet
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice wallets")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on wallets")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for wallets")
+	}
+
+	if len(walletAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Wallets = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &walletR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.Wallets = append(local.R.Wallets, foreign)
+				if foreign.R == nil {
+					foreign.R = &walletR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetSpendingLimitG of the user to the related item.
+// Sets o.R.SpendingLimit to related.
+// Adds o to related.R.User.
+// Uses the global database handle.
+func (o *User) SetSpendingLimitG(insert bool, related *SpendingLimit) error {
+	return o.SetSpendingLimit(boil.GetDB(), insert, related)
+}
+
+// SetSpendingLimit of the user to the related item.
+// Sets o.R.SpendingLimit to related.
+// Adds o to related.R.User.
+func (o *User) SetSpendingLimit(exec boil.Executor, insert bool, related *SpendingLimit) error {
+	var err error
+
+	if insert {
+		related.UserID = o.ID
+
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	} else {
+		updateQuery := fmt.Sprintf(
+			"UPDATE \"spending_limits\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+			strmangle.WhereClause("\"", "\"", 2, spendingLimitPrimaryKeyColumns),
+		)
+		values := []interface{}{o.ID, related.UserID}
+
+		if boil.DebugMode {
+			fmt.Fprintln(boil.DebugWriter, updateQuery)
+			fmt.Fprintln(boil.DebugWriter, values)
+		}
+
+		if _, err = exec.Exec(updateQuery, values...); err != nil {
+			return errors.Wrap(err, "failed to update foreign table")
+		}
+
+		related.UserID = o.ID
+
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			SpendingLimit: related,
+		}
+	} else {
+		o.R.SpendingLimit = related
+	}
+
+	if related.R == nil {
+		related.R = &spendingLimitR{
+			User: o,
+		}
+	} else {
+		related.R.User = o
+	}
+	return nil
+}
+
+// SetWalletMigrationG of the user to the related item.
+// Sets o.R.WalletMigration to related.
+// Adds o to related.R.User.
+// Uses the global database handle.
+func (o *User) SetWalletMigrationG(insert bool, related *WalletMigration) error {
+	return o.SetWalletMigration(boil.GetDB(), insert, related)
+}
+
+// SetWalletMigration of the user to the related item.
+// Sets o.R.WalletMigration to related.
+// Adds o to related.R.User.
+func (o *User) SetWalletMigration(exec boil.Executor, insert bool, related *WalletMigration) error {
+	var err error
+
+	if insert {
+		related.UserID = o.ID
+
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	} else {
+		updateQuery := fmt.Sprintf(
+			"UPDATE \"wallet_migrations\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+			strmangle.WhereClause("\"", "\"", 2, walletMigrationPrimaryKeyColumns),
+		)
+		values := []interface{}{o.ID, related.UserID}
+
+		if boil.DebugMode {
+			fmt.Fprintln(boil.DebugWriter, updateQuery)
+			fmt.Fprintln(boil.DebugWriter, values)
+		}
+
+		if _, err = exec.Exec(updateQuery, values...); err != nil {
+			return errors.Wrap(err, "failed to update foreign table")
+		}
+
+		related.UserID = o.ID
+
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			WalletMigration: related,
+		}
+	} else {
+		o.R.WalletMigration = related
+	}
+
+	if related.R == nil {
+		related.R = &walletMigrationR{
+			User: o,
+		}
+	} else {
+		related.R.User = o
+	}
+	return nil
+}
+
+// AddAPIKeysG adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.APIKeys.
+// Sets related.R.User appropriately.
+// Uses the global database handle.
+func (o *User) AddAPIKeysG(insert bool, related ...*APIKey) error {
+	return o.AddAPIKeys(boil.GetDB(), insert, related...)
+}
+
+// AddAPIKeys adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.APIKeys.
+// Sets related.R.User appropriately.
+func (o *User) AddAPIKeys(exec boil.Executor, insert bool, related ...*APIKey) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"api_keys\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, apiKeyPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			APIKeys: related,
+		}
+	} else {
+		o.R.APIKeys = append(o.R.APIKeys, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &apiKeyR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddAuthTokensG adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.AuthTokens.
+// Sets related.R.User appropriately.
+// Uses the global database handle.
+func (o *User) AddAuthTokensG(insert bool, related ...*AuthToken) error {
+	return o.AddAuthTokens(boil.GetDB(), insert, related...)
+}
+
+// AddAuthTokens adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.AuthTokens.
+// Sets related.R.User appropriately.
+func (o *User) AddAuthTokens(exec boil.Executor, insert bool, related ...*AuthToken) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"auth_tokens\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, authTokenPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.TokenHash}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			AuthTokens: related,
+		}
+	} else {
+		o.R.AuthTokens = append(o.R.AuthTokens, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &authTokenR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddMergedUserGuestsG adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.MergedUserGuests.
+// Sets related.R.MergedUser appropriately.
+// Uses the global database handle.
+func (o *User) AddMergedUserGuestsG(insert bool, related ...*Guest) error {
+	return o.AddMergedUserGuests(boil.GetDB(), insert, related...)
+}
+
+// AddMergedUserGuests adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.MergedUserGuests.
+// Sets related.R.MergedUser appropriately.
+func (o *User) AddMergedUserGuests(exec boil.Executor, insert bool, related ...*Guest) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.MergedUserID, o.ID)
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"guests\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"merged_user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, guestPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.MergedUserID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			MergedUserGuests: related,
+		}
+	} else {
+		o.R.MergedUserGuests = append(o.R.MergedUserGuests, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &guestR{
+				MergedUser: o,
+			}
+		} else {
+			rel.R.MergedUser = o
+		}
+	}
+	return nil
+}
+
+// SetMergedUserGuestsG removes all previously related items of the
+// user replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.MergedUser's MergedUserGuests accordingly.
+// Replaces o.R.MergedUserGuests with related.
+// Sets related.R.MergedUser's MergedUserGuests accordingly.
+// Uses the global database handle.
+func (o *User) SetMergedUserGuestsG(insert bool, related ...*Guest) error {
+	return o.SetMergedUserGuests(boil.GetDB(), insert, related...)
+}
+
+// SetMergedUserGuests removes all previously related items of the
+// user replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.MergedUser's MergedUserGuests accordingly.
+// Replaces o.R.MergedUserGuests with related.
+// Sets related.R.MergedUser's MergedUserGuests accordingly.
+func (o *User) SetMergedUserGuests(exec boil.Executor, insert bool, related ...*Guest) error {
+	query := "update \"guests\" set \"merged_user_id\" = null where \"merged_user_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	_, err := exec.Exec(query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.MergedUserGuests {
+			queries.SetScanner(&rel.MergedUserID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.MergedUser = nil
+		}
+
+		o.R.MergedUserGuests = nil
+	}
+	return o.AddMergedUserGuests(exec, insert, related...)
+}
+
+// RemoveMergedUserGuestsG relationships from objects passed in.
+// Removes related items from R.MergedUserGuests (uses pointer comparison, removal does not keep order)
+// Sets related.R.MergedUser.
+// Uses the global database handle.
+func (o *User) RemoveMergedUserGuestsG(related ...*Guest) error {
+	return o.RemoveMergedUserGuests(boil.GetDB(), related...)
+}
+
+// RemoveMergedUserGuests relationships from objects passed in.
+// Removes related items from R.MergedUserGuests (uses pointer comparison, removal does not keep order)
+// Sets related.R.MergedUser.
+func (o *User) RemoveMergedUserGuests(exec boil.Executor, related ...*Guest) error {
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.MergedUserID, nil)
+		if rel.R != nil {
+			rel.R.MergedUser = nil
+		}
+		if _, err = rel.Update(exec, boil.Whitelist("merged_user_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.MergedUserGuests {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.MergedUserGuests)
+			if ln > 1 && i < ln-1 {
+				o.R.MergedUserGuests[i] = o.R.MergedUserGuests[ln-1]
+			}
+			o.R.MergedUserGuests = o.R.MergedUserGuests[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// AddSpendingsG adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.Spendings.
+// Sets related.R.User appropriately.
+// Uses the global database handle.
+func (o *User) AddSpendingsG(insert bool, related ...*Spending) error {
+	return o.AddSpendings(boil.GetDB(), insert, related...)
+}
+
+// AddSpendings adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.Spendings.
+// Sets related.R.User appropriately.
+func (o *User) AddSpendings(exec boil.Executor, insert bool, related ...*Spending) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"spendings\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, spendingPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			Spendings: related,
+		}
+	} else {
+		o.R.Spendings = append(o.R.Spendings, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &spendingR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddWalletsG adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.Wallets.
+// Sets related.R.User appropriately.
+// Uses the global database handle.
+func (o *User) AddWalletsG(insert bool, related ...*Wallet) error {
+	return o.AddWallets(boil.GetDB(), insert, related...)
+}
+
+// AddWallets adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.Wallets.
+// Sets related.R.User appropriately.
+func (o *User) AddWallets(exec boil.Executor, insert bool, related ...*Wallet) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"wallets\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, walletPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			Wallets: related,
+		}
+	} else {
+		o.R.Wallets = append(o.R.Wallets, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &walletR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
 // Users retrieves all the records using an executor.
 func Users(mods ...qm.QueryMod) userQuery {
 	mods = append(mods, qm.From("\"users\""))
@@ -0,0 +1,1167 @@
+// Code generated by SQLBoiler (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+	"github.com/volatiletech/sqlboiler/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+// WalletMigration is an object representing the database table.
+type WalletMigration struct {
+	UserID       int        `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	CreatedAt    time.Time  `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt    time.Time  `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	Status       string     `boil:"status" json:"status" toml:"status" yaml:"status"`
+	AccountID    string     `boil:"account_id" json:"account_id" toml:"account_id" yaml:"account_id"`
+	WalletID     string     `boil:"wallet_id" json:"wallet_id" toml:"wallet_id" yaml:"wallet_id"`
+	EscrowedSeed null.Bytes `boil:"escrowed_seed" json:"escrowed_seed,omitempty" toml:"escrowed_seed" yaml:"escrowed_seed,omitempty"`
+	Attempts     int        `boil:"attempts" json:"attempts" toml:"attempts" yaml:"attempts"`
+	LastError    string     `boil:"last_error" json:"last_error" toml:"last_error" yaml:"last_error"`
+
+	R *walletMigrationR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L walletMigrationL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var WalletMigrationColumns = struct {
+	UserID       string
+	CreatedAt    string
+	UpdatedAt    string
+	Status       string
+	AccountID    string
+	WalletID     string
+	EscrowedSeed string
+	Attempts     string
+	LastError    string
+}{
+	UserID:       "user_id",
+	CreatedAt:    "created_at",
+	UpdatedAt:    "updated_at",
+	Status:       "status",
+	AccountID:    "account_id",
+	WalletID:     "wallet_id",
+	EscrowedSeed: "escrowed_seed",
+	Attempts:     "attempts",
+	LastError:    "last_error",
+}
+
+// Generated where
+
+type whereHelpernull_Bytes struct{ field string }
+
+func (w whereHelpernull_Bytes) EQ(x null.Bytes) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Bytes) NEQ(x null.Bytes) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Bytes) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Bytes) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+func (w whereHelpernull_Bytes) LT(x null.Bytes) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Bytes) LTE(x null.Bytes) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Bytes) GT(x null.Bytes) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Bytes) GTE(x null.Bytes) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var WalletMigrationWhere = struct {
+	UserID       whereHelperint
+	CreatedAt    whereHelpertime_Time
+	UpdatedAt    whereHelpertime_Time
+	Status       whereHelperstring
+	AccountID    whereHelperstring
+	WalletID     whereHelperstring
+	EscrowedSeed whereHelpernull_Bytes
+	Attempts     whereHelperint
+	LastError    whereHelperstring
+}{
+	UserID:       whereHelperint{field: "\"wallet_migrations\".\"user_id\""},
+	CreatedAt:    whereHelpertime_Time{field: "\"wallet_migrations\".\"created_at\""},
+	UpdatedAt:    whereHelpertime_Time{field: "\"wallet_migrations\".\"updated_at\""},
+	Status:       whereHelperstring{field: "\"wallet_migrations\".\"status\""},
+	AccountID:    whereHelperstring{field: "\"wallet_migrations\".\"account_id\""},
+	WalletID:     whereHelperstring{field: "\"wallet_migrations\".\"wallet_id\""},
+	EscrowedSeed: whereHelpernull_Bytes{field: "\"wallet_migrations\".\"escrowed_seed\""},
+	Attempts:     whereHelperint{field: "\"wallet_migrations\".\"attempts\""},
+	LastError:    whereHelperstring{field: "\"wallet_migrations\".\"last_error\""},
+}
+
+// WalletMigrationRels is where relationship names are stored.
+var WalletMigrationRels = struct {
+	User string
+}{
+	User: "User",
+}
+
+// walletMigrationR is where relationships are stored.
+type walletMigrationR struct {
+	User *User
+}
+
+// NewStruct creates a new relationship struct
+func (*walletMigrationR) NewStruct() *walletMigrationR {
+	return &walletMigrationR{}
+}
+
+// walletMigrationL is where Load methods for each relationship are stored.
+type walletMigrationL struct{}
+
+var (
+	walletMigrationAllColumns            = []string{"user_id", "created_at", "updated_at", "status", "account_id", "wallet_id", "escrowed_seed", "attempts", "last_error"}
+	walletMigrationColumnsWithoutDefault = []string{"user_id", "status", "account_id", "wallet_id", "escrowed_seed"}
+	walletMigrationColumnsWithDefault    = []string{"created_at", "updated_at", "attempts", "last_error"}
+	walletMigrationPrimaryKeyColumns     = []string{"user_id"}
+)
+
+type (
+	// WalletMigrationSlice is an alias for a slice of pointers to WalletMigration.
+	// This should generally be used opposed to []WalletMigration.
+	WalletMigrationSlice []*WalletMigration
+	// WalletMigrationHook is the signature for custom WalletMigration hook methods
+	WalletMigrationHook func(boil.Executor, *WalletMigration) error
+
+	walletMigrationQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	walletMigrationType                 = reflect.TypeOf(&WalletMigration{})
+	walletMigrationMapping              = queries.MakeStructMapping(walletMigrationType)
+	walletMigrationPrimaryKeyMapping, _ = queries.BindMapping(walletMigrationType, walletMigrationMapping, walletMigrationPrimaryKeyColumns)
+	walletMigrationInsertCacheMut       sync.RWMutex
+	walletMigrationInsertCache          = make(map[string]insertCache)
+	walletMigrationUpdateCacheMut       sync.RWMutex
+	walletMigrationUpdateCache          = make(map[string]updateCache)
+	walletMigrationUpsertCacheMut       sync.RWMutex
+	walletMigrationUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var walletMigrationBeforeInsertHooks []WalletMigrationHook
+var walletMigrationBeforeUpdateHooks []WalletMigrationHook
+var walletMigrationBeforeDeleteHooks []WalletMigrationHook
+var walletMigrationBeforeUpsertHooks []WalletMigrationHook
+
+var walletMigrationAfterInsertHooks []WalletMigrationHook
+var walletMigrationAfterSelectHooks []WalletMigrationHook
+var walletMigrationAfterUpdateHooks []WalletMigrationHook
+var walletMigrationAfterDeleteHooks []WalletMigrationHook
+var walletMigrationAfterUpsertHooks []WalletMigrationHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *WalletMigration) doBeforeInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range walletMigrationBeforeInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *WalletMigration) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range walletMigrationBeforeUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *WalletMigration) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range walletMigrationBeforeDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *WalletMigration) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range walletMigrationBeforeUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *WalletMigration) doAfterInsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range walletMigrationAfterInsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *WalletMigration) doAfterSelectHooks(exec boil.Executor) (err error) {
+	for _, hook := range walletMigrationAfterSelectHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *WalletMigration) doAfterUpdateHooks(exec boil.Executor) (err error) {
+	for _, hook := range walletMigrationAfterUpdateHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *WalletMigration) doAfterDeleteHooks(exec boil.Executor) (err error) {
+	for _, hook := range walletMigrationAfterDeleteHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *WalletMigration) doAfterUpsertHooks(exec boil.Executor) (err error) {
+	for _, hook := range walletMigrationAfterUpsertHooks {
+		if err := hook(exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddWalletMigrationHook registers your hook function for all future operations.
+func AddWalletMigrationHook(hookPoint boil.HookPoint, walletMigrationHook WalletMigrationHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		walletMigrationBeforeInsertHooks = append(walletMigrationBeforeInsertHooks, walletMigrationHook)
+	case boil.BeforeUpdateHook:
+		walletMigrationBeforeUpdateHooks = append(walletMigrationBeforeUpdateHooks, walletMigrationHook)
+	case boil.BeforeDeleteHook:
+		walletMigrationBeforeDeleteHooks = append(walletMigrationBeforeDeleteHooks, walletMigrationHook)
+	case boil.BeforeUpsertHook:
+		walletMigrationBeforeUpsertHooks = append(walletMigrationBeforeUpsertHooks, walletMigrationHook)
+	case boil.AfterInsertHook:
+		walletMigrationAfterInsertHooks = append(walletMigrationAfterInsertHooks, walletMigrationHook)
+	case boil.AfterSelectHook:
+		walletMigrationAfterSelectHooks = append(walletMigrationAfterSelectHooks, walletMigrationHook)
+	case boil.AfterUpdateHook:
+		walletMigrationAfterUpdateHooks = append(walletMigrationAfterUpdateHooks, walletMigrationHook)
+	case boil.AfterDeleteHook:
+		walletMigrationAfterDeleteHooks = append(walletMigrationAfterDeleteHooks, walletMigrationHook)
+	case boil.AfterUpsertHook:
+		walletMigrationAfterUpsertHooks = append(walletMigrationAfterUpsertHooks, walletMigrationHook)
+	}
+}
+
+// OneG returns a single walletMigration record from the query using the global executor.
+func (q walletMigrationQuery) OneG() (*WalletMigration, error) {
+	return q.One(boil.GetDB())
+}
+
+// One returns a single walletMigration record from the query.
+func (q walletMigrationQuery) One(exec boil.Executor) (*WalletMigration, error) {
+	o := &WalletMigration{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(nil, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for wallet_migrations")
+	}
+
+	if err := o.doAfterSelectHooks(exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// AllG returns all WalletMigration records from the query using the global executor.
+func (q walletMigrationQuery) AllG() (WalletMigrationSlice, error) {
+	return q.All(boil.GetDB())
+}
+
+// All returns all WalletMigration records from the query.
+func (q walletMigrationQuery) All(exec boil.Executor) (WalletMigrationSlice, error) {
+	var o []*WalletMigration
+
+	err := q.Bind(nil, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to WalletMigration slice")
+	}
+
+	if len(walletMigrationAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// CountG returns the count of all WalletMigration records in the query, and panics on error.
+func (q walletMigrationQuery) CountG() (int64, error) {
+	return q.Count(boil.GetDB())
+}
+
+// Count returns the count of all WalletMigration records in the query.
+func (q walletMigrationQuery) Count(exec boil.Executor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count wallet_migrations rows")
+	}
+
+	return count, nil
+}
+
+// ExistsG checks if the row exists in the table, and panics on error.
+func (q walletMigrationQuery) ExistsG() (bool, error) {
+	return q.Exists(boil.GetDB())
+}
+
+// Exists checks if the row exists in the table.
+func (q walletMigrationQuery) Exists(exec boil.Executor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRow(exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if wallet_migrations exists")
+	}
+
+	return count > 0, nil
+}
+
+// User pointed to by the foreign key.
+func (o *WalletMigration) User(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("id=?", o.UserID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Users(queryMods...)
+	queries.SetFrom(query.Query, "\"users\"")
+
+	return query
+}
+
+// LoadUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (walletMigrationL) LoadUser(e boil.Executor, singular bool, maybeWalletMigration interface{}, mods queries.Applicator) error {
+	var slice []*WalletMigration
+	var object *WalletMigration
+
+	if singular {
+		object = maybeWalletMigration.(*WalletMigration)
+	} else {
+		slice = *maybeWalletMigration.(*[]*WalletMigration)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &walletMigrationR{}
+		}
+		args = append(args, object.UserID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &walletMigrationR{}
+			}
+
+			for _, a := range args {
+				if a == obj.UserID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.UserID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`users`), qm.WhereIn(`id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(walletMigrationAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.User = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.WalletMigration = object
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.UserID == foreign.ID {
+				local.R.User = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.WalletMigration = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetUserG of the walletMigration to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.WalletMigration.
+// Uses the global database handle.
+func (o *WalletMigration) SetUserG(insert bool, related *User) error {
+	return o.SetUser(boil.GetDB(), insert, related)
+}
+
+// SetUser of the walletMigration to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.WalletMigration.
+func (o *WalletMigration) SetUser(exec boil.Executor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"wallet_migrations\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, walletMigrationPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.UserID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	if _, err = exec.Exec(updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.UserID = related.ID
+	if o.R == nil {
+		o.R = &walletMigrationR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			WalletMigration: o,
+		}
+	} else {
+		related.R.WalletMigration = o
+	}
+
+	return nil
+}
+
+// WalletMigrations retrieves all the records using an executor.
+func WalletMigrations(mods ...qm.QueryMod) walletMigrationQuery {
+	mods = append(mods, qm.From("\"wallet_migrations\""))
+	return walletMigrationQuery{NewQuery(mods...)}
+}
+
+// FindWalletMigrationG retrieves a single record by ID.
+func FindWalletMigrationG(userID int, selectCols ...string) (*WalletMigration, error) {
+	return FindWalletMigration(boil.GetDB(), userID, selectCols...)
+}
+
+// FindWalletMigration retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindWalletMigration(exec boil.Executor, userID int, selectCols ...string) (*WalletMigration, error) {
+	walletMigrationObj := &WalletMigration{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"wallet_migrations\" where \"user_id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, userID)
+
+	err := q.Bind(nil, exec, walletMigrationObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from wallet_migrations")
+	}
+
+	return walletMigrationObj, nil
+}
+
+// InsertG a single record. See Insert for whitelist behavior description.
+func (o *WalletMigration) InsertG(columns boil.Columns) error {
+	return o.Insert(boil.GetDB(), columns)
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *WalletMigration) Insert(exec boil.Executor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no wallet_migrations provided for insertion")
+	}
+
+	var err error
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+	if o.UpdatedAt.IsZero() {
+		o.UpdatedAt = currTime
+	}
+
+	if err := o.doBeforeInsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(walletMigrationColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	walletMigrationInsertCacheMut.RLock()
+	cache, cached := walletMigrationInsertCache[key]
+	walletMigrationInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			walletMigrationAllColumns,
+			walletMigrationColumnsWithDefault,
+			walletMigrationColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(walletMigrationType, walletMigrationMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(walletMigrationType, walletMigrationMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"wallet_migrations\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"wallet_migrations\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into wallet_migrations")
+	}
+
+	if !cached {
+		walletMigrationInsertCacheMut.Lock()
+		walletMigrationInsertCache[key] = cache
+		walletMigrationInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(exec)
+}
+
+// UpdateG a single WalletMigration record using the global executor.
+// See Update for more documentation.
+func (o *WalletMigration) UpdateG(columns boil.Columns) (int64, error) {
+	return o.Update(boil.GetDB(), columns)
+}
+
+// Update uses an executor to update the WalletMigration.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *WalletMigration) Update(exec boil.Executor, columns boil.Columns) (int64, error) {
+	currTime := time.Now().In(boil.GetLocation())
+
+	o.UpdatedAt = currTime
+
+	var err error
+	if err = o.doBeforeUpdateHooks(exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	walletMigrationUpdateCacheMut.RLock()
+	cache, cached := walletMigrationUpdateCache[key]
+	walletMigrationUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			walletMigrationAllColumns,
+			walletMigrationPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update wallet_migrations, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"wallet_migrations\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, walletMigrationPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(walletMigrationType, walletMigrationMapping, append(wl, walletMigrationPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	var result sql.Result
+	result, err = exec.Exec(cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update wallet_migrations row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for wallet_migrations")
+	}
+
+	if !cached {
+		walletMigrationUpdateCacheMut.Lock()
+		walletMigrationUpdateCache[key] = cache
+		walletMigrationUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(exec)
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (q walletMigrationQuery) UpdateAllG(cols M) (int64, error) {
+	return q.UpdateAll(boil.GetDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q walletMigrationQuery) UpdateAll(exec boil.Executor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.Exec(exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for wallet_migrations")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for wallet_migrations")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (o WalletMigrationSlice) UpdateAllG(cols M) (int64, error) {
+	return o.UpdateAll(boil.GetDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o WalletMigrationSlice) UpdateAll(exec boil.Executor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), walletMigrationPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"wallet_migrations\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, walletMigrationPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.Exec(sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in walletMigration slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all walletMigration")
+	}
+	return rowsAff, nil
+}
+
+// UpsertG attempts an insert, and does an update or ignore on conflict.
+func (o *WalletMigration) UpsertG(updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	return o.Upsert(boil.GetDB(), updateOnConflict, conflictColumns, updateColumns, insertColumns)
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *WalletMigration) Upsert(exec boil.Executor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no wallet_migrations provided for upsert")
+	}
+	currTime := time.Now().In(boil.GetLocation())
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = currTime
+	}
+	o.UpdatedAt = currTime
+
+	if err := o.doBeforeUpsertHooks(exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(walletMigrationColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	walletMigrationUpsertCacheMut.RLock()
+	cache, cached := walletMigrationUpsertCache[key]
+	walletMigrationUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			walletMigrationAllColumns,
+			walletMigrationColumnsWithDefault,
+			walletMigrationColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			walletMigrationAllColumns,
+			walletMigrationPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert wallet_migrations, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(walletMigrationPrimaryKeyColumns))
+			copy(conflict, walletMigrationPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"wallet_migrations\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(walletMigrationType, walletMigrationMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(walletMigrationType, walletMigrationMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRow(cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.Exec(cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert wallet_migrations")
+	}
+
+	if !cached {
+		walletMigrationUpsertCacheMut.Lock()
+		walletMigrationUpsertCache[key] = cache
+		walletMigrationUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(exec)
+}
+
+// DeleteG deletes a single WalletMigration record.
+// DeleteG will match against the primary key column to find the record to delete.
+func (o *WalletMigration) DeleteG() (int64, error) {
+	return o.Delete(boil.GetDB())
+}
+
+// Delete deletes a single WalletMigration record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *WalletMigration) Delete(exec boil.Executor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no WalletMigration provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), walletMigrationPrimaryKeyMapping)
+	sql := "DELETE FROM \"wallet_migrations\" WHERE \"user_id\"=$1"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.Exec(sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from wallet_migrations")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for wallet_migrations")
+	}
+
+	if err := o.doAfterDeleteHooks(exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q walletMigrationQuery) DeleteAll(exec boil.Executor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no walletMigrationQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.Exec(exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from wallet_migrations")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for wallet_migrations")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAllG deletes all rows in the slice.
+func (o WalletMigrationSlice) DeleteAllG() (int64, error) {
+	return o.DeleteAll(boil.GetDB())
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o WalletMigrationSlice) DeleteAll(exec boil.Executor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(walletMigrationBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), walletMigrationPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"wallet_migrations\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, walletMigrationPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+
+	result, err := exec.Exec(sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from walletMigration slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for wallet_migrations")
+	}
+
+	if len(walletMigrationAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// ReloadG refetches the object from the database using the primary keys.
+func (o *WalletMigration) ReloadG() error {
+	if o == nil {
+		return errors.New("models: no WalletMigration provided for reload")
+	}
+
+	return o.Reload(boil.GetDB())
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *WalletMigration) Reload(exec boil.Executor) error {
+	ret, err := FindWalletMigration(exec, o.UserID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAllG refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *WalletMigrationSlice) ReloadAllG() error {
+	if o == nil {
+		return errors.New("models: empty WalletMigrationSlice provided for reload all")
+	}
+
+	return o.ReloadAll(boil.GetDB())
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *WalletMigrationSlice) ReloadAll(exec boil.Executor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := WalletMigrationSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), walletMigrationPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"wallet_migrations\".* FROM \"wallet_migrations\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, walletMigrationPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(nil, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in WalletMigrationSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// WalletMigrationExistsG checks if the WalletMigration row exists.
+func WalletMigrationExistsG(userID int) (bool, error) {
+	return WalletMigrationExists(boil.GetDB(), userID)
+}
+
+// WalletMigrationExists checks if the WalletMigration row exists.
+func WalletMigrationExists(exec boil.Executor, userID int) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"wallet_migrations\" where \"user_id\"=$1 limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, userID)
+	}
+
+	row := exec.QueryRow(sql, userID)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if wallet_migrations exists")
+	}
+
+	return exists, nil
+}
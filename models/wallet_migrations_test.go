@@ -0,0 +1,817 @@
+// Code generated by SQLBoiler (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/randomize"
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testWalletMigrations(t *testing.T) {
+	t.Parallel()
+
+	query := WalletMigrations()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testWalletMigrationsDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WalletMigration{}
+	if err = randomize.Struct(seed, o, walletMigrationDBTypes, true, walletMigrationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := WalletMigrations().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testWalletMigrationsQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WalletMigration{}
+	if err = randomize.Struct(seed, o, walletMigrationDBTypes, true, walletMigrationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := WalletMigrations().DeleteAll(tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := WalletMigrations().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testWalletMigrationsSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WalletMigration{}
+	if err = randomize.Struct(seed, o, walletMigrationDBTypes, true, walletMigrationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := WalletMigrationSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := WalletMigrations().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testWalletMigrationsExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WalletMigration{}
+	if err = randomize.Struct(seed, o, walletMigrationDBTypes, true, walletMigrationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := WalletMigrationExists(tx, o.UserID)
+	if err != nil {
+		t.Errorf("Unable to check if WalletMigration exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected WalletMigrationExists to return true, but got false.")
+	}
+}
+
+func testWalletMigrationsFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WalletMigration{}
+	if err = randomize.Struct(seed, o, walletMigrationDBTypes, true, walletMigrationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	walletMigrationFound, err := FindWalletMigration(tx, o.UserID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if walletMigrationFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testWalletMigrationsBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WalletMigration{}
+	if err = randomize.Struct(seed, o, walletMigrationDBTypes, true, walletMigrationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = WalletMigrations().Bind(nil, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testWalletMigrationsOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WalletMigration{}
+	if err = randomize.Struct(seed, o, walletMigrationDBTypes, true, walletMigrationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := WalletMigrations().One(tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testWalletMigrationsAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	walletMigrationOne := &WalletMigration{}
+	walletMigrationTwo := &WalletMigration{}
+	if err = randomize.Struct(seed, walletMigrationOne, walletMigrationDBTypes, false, walletMigrationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+	if err = randomize.Struct(seed, walletMigrationTwo, walletMigrationDBTypes, false, walletMigrationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = walletMigrationOne.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = walletMigrationTwo.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := WalletMigrations().All(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testWalletMigrationsCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	walletMigrationOne := &WalletMigration{}
+	walletMigrationTwo := &WalletMigration{}
+	if err = randomize.Struct(seed, walletMigrationOne, walletMigrationDBTypes, false, walletMigrationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+	if err = randomize.Struct(seed, walletMigrationTwo, walletMigrationDBTypes, false, walletMigrationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = walletMigrationOne.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = walletMigrationTwo.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := WalletMigrations().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func walletMigrationBeforeInsertHook(e boil.Executor, o *WalletMigration) error {
+	*o = WalletMigration{}
+	return nil
+}
+
+func walletMigrationAfterInsertHook(e boil.Executor, o *WalletMigration) error {
+	*o = WalletMigration{}
+	return nil
+}
+
+func walletMigrationAfterSelectHook(e boil.Executor, o *WalletMigration) error {
+	*o = WalletMigration{}
+	return nil
+}
+
+func walletMigrationBeforeUpdateHook(e boil.Executor, o *WalletMigration) error {
+	*o = WalletMigration{}
+	return nil
+}
+
+func walletMigrationAfterUpdateHook(e boil.Executor, o *WalletMigration) error {
+	*o = WalletMigration{}
+	return nil
+}
+
+func walletMigrationBeforeDeleteHook(e boil.Executor, o *WalletMigration) error {
+	*o = WalletMigration{}
+	return nil
+}
+
+func walletMigrationAfterDeleteHook(e boil.Executor, o *WalletMigration) error {
+	*o = WalletMigration{}
+	return nil
+}
+
+func walletMigrationBeforeUpsertHook(e boil.Executor, o *WalletMigration) error {
+	*o = WalletMigration{}
+	return nil
+}
+
+func walletMigrationAfterUpsertHook(e boil.Executor, o *WalletMigration) error {
+	*o = WalletMigration{}
+	return nil
+}
+
+func testWalletMigrationsHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	empty := &WalletMigration{}
+	o := &WalletMigration{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, walletMigrationDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize WalletMigration object: %s", err)
+	}
+
+	AddWalletMigrationHook(boil.BeforeInsertHook, walletMigrationBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	walletMigrationBeforeInsertHooks = []WalletMigrationHook{}
+
+	AddWalletMigrationHook(boil.AfterInsertHook, walletMigrationAfterInsertHook)
+	if err = o.doAfterInsertHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	walletMigrationAfterInsertHooks = []WalletMigrationHook{}
+
+	AddWalletMigrationHook(boil.AfterSelectHook, walletMigrationAfterSelectHook)
+	if err = o.doAfterSelectHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	walletMigrationAfterSelectHooks = []WalletMigrationHook{}
+
+	AddWalletMigrationHook(boil.BeforeUpdateHook, walletMigrationBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	walletMigrationBeforeUpdateHooks = []WalletMigrationHook{}
+
+	AddWalletMigrationHook(boil.AfterUpdateHook, walletMigrationAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	walletMigrationAfterUpdateHooks = []WalletMigrationHook{}
+
+	AddWalletMigrationHook(boil.BeforeDeleteHook, walletMigrationBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	walletMigrationBeforeDeleteHooks = []WalletMigrationHook{}
+
+	AddWalletMigrationHook(boil.AfterDeleteHook, walletMigrationAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	walletMigrationAfterDeleteHooks = []WalletMigrationHook{}
+
+	AddWalletMigrationHook(boil.BeforeUpsertHook, walletMigrationBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	walletMigrationBeforeUpsertHooks = []WalletMigrationHook{}
+
+	AddWalletMigrationHook(boil.AfterUpsertHook, walletMigrationAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	walletMigrationAfterUpsertHooks = []WalletMigrationHook{}
+}
+
+func testWalletMigrationsInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WalletMigration{}
+	if err = randomize.Struct(seed, o, walletMigrationDBTypes, true, walletMigrationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := WalletMigrations().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testWalletMigrationsInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WalletMigration{}
+	if err = randomize.Struct(seed, o, walletMigrationDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Whitelist(walletMigrationColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := WalletMigrations().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testWalletMigrationToOneUserUsingUser(t *testing.T) {
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+
+	var local WalletMigration
+	var foreign User
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &local, walletMigrationDBTypes, false, walletMigrationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &foreign, userDBTypes, false, userColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize User struct: %s", err)
+	}
+
+	if err := foreign.Insert(tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	local.UserID = foreign.ID
+	if err := local.Insert(tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.User().One(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check.ID != foreign.ID {
+		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
+	}
+
+	slice := WalletMigrationSlice{&local}
+	if err = local.L.LoadUser(tx, false, (*[]*WalletMigration)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.User == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.User = nil
+	if err = local.L.LoadUser(tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.User == nil {
+		t.Error("struct should have been eager loaded")
+	}
+}
+
+func testWalletMigrationToOneSetOpUserUsingUser(t *testing.T) {
+	var err error
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+
+	var a WalletMigration
+	var b, c User
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, walletMigrationDBTypes, false, strmangle.SetComplement(walletMigrationPrimaryKeyColumns, walletMigrationColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, userDBTypes, false, strmangle.SetComplement(userPrimaryKeyColumns, userColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, userDBTypes, false, strmangle.SetComplement(userPrimaryKeyColumns, userColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Insert(tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*User{&b, &c} {
+		err = a.SetUser(tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.User != x {
+			t.Error("relationship struct not set to correct value")
+		}
+
+		if x.R.WalletMigration != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+		if a.UserID != x.ID {
+			t.Error("foreign key was wrong value", a.UserID)
+		}
+
+		if exists, err := WalletMigrationExists(tx, a.UserID); err != nil {
+			t.Fatal(err)
+		} else if !exists {
+			t.Error("want 'a' to exist")
+		}
+
+	}
+}
+
+func testWalletMigrationsReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WalletMigration{}
+	if err = randomize.Struct(seed, o, walletMigrationDBTypes, true, walletMigrationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testWalletMigrationsReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WalletMigration{}
+	if err = randomize.Struct(seed, o, walletMigrationDBTypes, true, walletMigrationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := WalletMigrationSlice{o}
+
+	if err = slice.ReloadAll(tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testWalletMigrationsSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WalletMigration{}
+	if err = randomize.Struct(seed, o, walletMigrationDBTypes, true, walletMigrationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := WalletMigrations().All(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	walletMigrationDBTypes = map[string]string{`UserID`: `integer`, `CreatedAt`: `timestamp without time zone`, `UpdatedAt`: `timestamp without time zone`, `Status`: `character varying`, `AccountID`: `character varying`, `WalletID`: `character varying`, `EscrowedSeed`: `bytea`, `Attempts`: `integer`, `LastError`: `text`}
+	_                      = bytes.MinRead
+)
+
+func testWalletMigrationsUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(walletMigrationPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(walletMigrationAllColumns) == len(walletMigrationPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WalletMigration{}
+	if err = randomize.Struct(seed, o, walletMigrationDBTypes, true, walletMigrationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := WalletMigrations().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, walletMigrationDBTypes, true, walletMigrationPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testWalletMigrationsSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(walletMigrationAllColumns) == len(walletMigrationPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WalletMigration{}
+	if err = randomize.Struct(seed, o, walletMigrationDBTypes, true, walletMigrationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := WalletMigrations().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, walletMigrationDBTypes, true, walletMigrationPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(walletMigrationAllColumns, walletMigrationPrimaryKeyColumns) {
+		fields = walletMigrationAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			walletMigrationAllColumns,
+			walletMigrationPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := WalletMigrationSlice{o}
+	if rowsAff, err := slice.UpdateAll(tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testWalletMigrationsUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(walletMigrationAllColumns) == len(walletMigrationPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := WalletMigration{}
+	if err = randomize.Struct(seed, &o, walletMigrationDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert WalletMigration: %s", err)
+	}
+
+	count, err := WalletMigrations().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, walletMigrationDBTypes, false, walletMigrationPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize WalletMigration struct: %s", err)
+	}
+
+	if err = o.Upsert(tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert WalletMigration: %s", err)
+	}
+
+	count, err = WalletMigrations().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
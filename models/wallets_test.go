@@ -0,0 +1,821 @@
+// Code generated by SQLBoiler (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/randomize"
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testWallets(t *testing.T) {
+	t.Parallel()
+
+	query := Wallets()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testWalletsDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Wallet{}
+	if err = randomize.Struct(seed, o, walletDBTypes, true, walletColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := Wallets().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testWalletsQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Wallet{}
+	if err = randomize.Struct(seed, o, walletDBTypes, true, walletColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := Wallets().DeleteAll(tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := Wallets().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testWalletsSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Wallet{}
+	if err = randomize.Struct(seed, o, walletDBTypes, true, walletColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := WalletSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := Wallets().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testWalletsExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Wallet{}
+	if err = randomize.Struct(seed, o, walletDBTypes, true, walletColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := WalletExists(tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if Wallet exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected WalletExists to return true, but got false.")
+	}
+}
+
+func testWalletsFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Wallet{}
+	if err = randomize.Struct(seed, o, walletDBTypes, true, walletColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	walletFound, err := FindWallet(tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if walletFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testWalletsBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Wallet{}
+	if err = randomize.Struct(seed, o, walletDBTypes, true, walletColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = Wallets().Bind(nil, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testWalletsOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Wallet{}
+	if err = randomize.Struct(seed, o, walletDBTypes, true, walletColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := Wallets().One(tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testWalletsAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	walletOne := &Wallet{}
+	walletTwo := &Wallet{}
+	if err = randomize.Struct(seed, walletOne, walletDBTypes, false, walletColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+	if err = randomize.Struct(seed, walletTwo, walletDBTypes, false, walletColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = walletOne.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = walletTwo.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := Wallets().All(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testWalletsCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	walletOne := &Wallet{}
+	walletTwo := &Wallet{}
+	if err = randomize.Struct(seed, walletOne, walletDBTypes, false, walletColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+	if err = randomize.Struct(seed, walletTwo, walletDBTypes, false, walletColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = walletOne.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = walletTwo.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Wallets().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func walletBeforeInsertHook(e boil.Executor, o *Wallet) error {
+	*o = Wallet{}
+	return nil
+}
+
+func walletAfterInsertHook(e boil.Executor, o *Wallet) error {
+	*o = Wallet{}
+	return nil
+}
+
+func walletAfterSelectHook(e boil.Executor, o *Wallet) error {
+	*o = Wallet{}
+	return nil
+}
+
+func walletBeforeUpdateHook(e boil.Executor, o *Wallet) error {
+	*o = Wallet{}
+	return nil
+}
+
+func walletAfterUpdateHook(e boil.Executor, o *Wallet) error {
+	*o = Wallet{}
+	return nil
+}
+
+func walletBeforeDeleteHook(e boil.Executor, o *Wallet) error {
+	*o = Wallet{}
+	return nil
+}
+
+func walletAfterDeleteHook(e boil.Executor, o *Wallet) error {
+	*o = Wallet{}
+	return nil
+}
+
+func walletBeforeUpsertHook(e boil.Executor, o *Wallet) error {
+	*o = Wallet{}
+	return nil
+}
+
+func walletAfterUpsertHook(e boil.Executor, o *Wallet) error {
+	*o = Wallet{}
+	return nil
+}
+
+func testWalletsHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	empty := &Wallet{}
+	o := &Wallet{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, walletDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize Wallet object: %s", err)
+	}
+
+	AddWalletHook(boil.BeforeInsertHook, walletBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	walletBeforeInsertHooks = []WalletHook{}
+
+	AddWalletHook(boil.AfterInsertHook, walletAfterInsertHook)
+	if err = o.doAfterInsertHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	walletAfterInsertHooks = []WalletHook{}
+
+	AddWalletHook(boil.AfterSelectHook, walletAfterSelectHook)
+	if err = o.doAfterSelectHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	walletAfterSelectHooks = []WalletHook{}
+
+	AddWalletHook(boil.BeforeUpdateHook, walletBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	walletBeforeUpdateHooks = []WalletHook{}
+
+	AddWalletHook(boil.AfterUpdateHook, walletAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	walletAfterUpdateHooks = []WalletHook{}
+
+	AddWalletHook(boil.BeforeDeleteHook, walletBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	walletBeforeDeleteHooks = []WalletHook{}
+
+	AddWalletHook(boil.AfterDeleteHook, walletAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	walletAfterDeleteHooks = []WalletHook{}
+
+	AddWalletHook(boil.BeforeUpsertHook, walletBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	walletBeforeUpsertHooks = []WalletHook{}
+
+	AddWalletHook(boil.AfterUpsertHook, walletAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	walletAfterUpsertHooks = []WalletHook{}
+}
+
+func testWalletsInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Wallet{}
+	if err = randomize.Struct(seed, o, walletDBTypes, true, walletColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Wallets().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testWalletsInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Wallet{}
+	if err = randomize.Struct(seed, o, walletDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Whitelist(walletColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Wallets().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testWalletToOneUserUsingUser(t *testing.T) {
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+
+	var local Wallet
+	var foreign User
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &local, walletDBTypes, false, walletColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &foreign, userDBTypes, false, userColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize User struct: %s", err)
+	}
+
+	if err := foreign.Insert(tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	local.UserID = foreign.ID
+	if err := local.Insert(tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.User().One(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check.ID != foreign.ID {
+		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
+	}
+
+	slice := WalletSlice{&local}
+	if err = local.L.LoadUser(tx, false, (*[]*Wallet)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.User == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.User = nil
+	if err = local.L.LoadUser(tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.User == nil {
+		t.Error("struct should have been eager loaded")
+	}
+}
+
+func testWalletToOneSetOpUserUsingUser(t *testing.T) {
+	var err error
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+
+	var a Wallet
+	var b, c User
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, walletDBTypes, false, strmangle.SetComplement(walletPrimaryKeyColumns, walletColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, userDBTypes, false, strmangle.SetComplement(userPrimaryKeyColumns, userColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, userDBTypes, false, strmangle.SetComplement(userPrimaryKeyColumns, userColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Insert(tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*User{&b, &c} {
+		err = a.SetUser(tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.User != x {
+			t.Error("relationship struct not set to correct value")
+		}
+
+		if x.R.Wallets[0] != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+		if a.UserID != x.ID {
+			t.Error("foreign key was wrong value", a.UserID)
+		}
+
+		zero := reflect.Zero(reflect.TypeOf(a.UserID))
+		reflect.Indirect(reflect.ValueOf(&a.UserID)).Set(zero)
+
+		if err = a.Reload(tx); err != nil {
+			t.Fatal("failed to reload", err)
+		}
+
+		if a.UserID != x.ID {
+			t.Error("foreign key was wrong value", a.UserID, x.ID)
+		}
+	}
+}
+
+func testWalletsReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Wallet{}
+	if err = randomize.Struct(seed, o, walletDBTypes, true, walletColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testWalletsReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Wallet{}
+	if err = randomize.Struct(seed, o, walletDBTypes, true, walletColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := WalletSlice{o}
+
+	if err = slice.ReloadAll(tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testWalletsSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Wallet{}
+	if err = randomize.Struct(seed, o, walletDBTypes, true, walletColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := Wallets().All(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	walletDBTypes = map[string]string{`ID`: `integer`, `UserID`: `integer`, `CreatedAt`: `timestamp without time zone`, `UpdatedAt`: `timestamp without time zone`, `WalletID`: `character varying`, `Name`: `character varying`, `IsDefault`: `boolean`}
+	_             = bytes.MinRead
+)
+
+func testWalletsUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(walletPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(walletAllColumns) == len(walletPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Wallet{}
+	if err = randomize.Struct(seed, o, walletDBTypes, true, walletColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Wallets().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, walletDBTypes, true, walletPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testWalletsSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(walletAllColumns) == len(walletPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Wallet{}
+	if err = randomize.Struct(seed, o, walletDBTypes, true, walletColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Wallets().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, walletDBTypes, true, walletPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(walletAllColumns, walletPrimaryKeyColumns) {
+		fields = walletAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			walletAllColumns,
+			walletPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := WalletSlice{o}
+	if rowsAff, err := slice.UpdateAll(tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testWalletsUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(walletAllColumns) == len(walletPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := Wallet{}
+	if err = randomize.Struct(seed, &o, walletDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert Wallet: %s", err)
+	}
+
+	count, err := Wallets().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, walletDBTypes, false, walletPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize Wallet struct: %s", err)
+	}
+
+	if err = o.Upsert(tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert Wallet: %s", err)
+	}
+
+	count, err = Wallets().Count(tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
@@ -0,0 +1,173 @@
+package server
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+// compressibleTypes are response content types that are worth compressing.
+// Media served by the player is already compressed and is served in ranges,
+// so it's never included here.
+var compressibleTypes = []string{
+	"application/json",
+	"application/msgpack",
+	"text/",
+}
+
+// compressor is implemented by both gzip and flate writers.
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// compressingWriter compresses response body if the response turns out to be compressible,
+// otherwise it passes the data through as is. An empty encoding disables compression.
+type compressingWriter struct {
+	http.ResponseWriter
+	encoding    string
+	compressor  compressor
+	wroteHeader bool
+}
+
+// compressionMiddleware compresses responses with gzip or deflate, whichever the client prefers.
+// Range requests (player) are passed through untouched. Other responses always carry
+// `Vary: Accept-Encoding`, so shared caches don't serve compressed bodies to clients which can't decode them.
+func compressionMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if r.Method == http.MethodHead {
+			encoding = ""
+		}
+		cw := &compressingWriter{ResponseWriter: w, encoding: encoding}
+		defer cw.Close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// negotiateEncoding picks a supported content coding from Accept-Encoding header value.
+func negotiateEncoding(acceptEncoding string) string {
+	var (
+		best  string
+		bestQ float64
+	)
+	for _, item := range strings.Split(acceptEncoding, ",") {
+		parts := strings.Split(item, ";")
+		coding := strings.ToLower(strings.TrimSpace(parts[0]))
+		if coding != encodingGzip && coding != encodingDeflate {
+			continue
+		}
+		q := 1.0
+		for _, p := range parts[1:] {
+			p = strings.TrimSpace(p)
+			if strings.HasPrefix(p, "q=") {
+				parsedQ, err := strconv.ParseFloat(strings.TrimPrefix(p, "q="), 64)
+				if err != nil {
+					parsedQ = 0
+				}
+				q = parsedQ
+			}
+		}
+		// q=0 means the coding is explicitly refused
+		if q <= 0 {
+			continue
+		}
+		// gzip wins ties as the more widely supported coding
+		if q > bestQ || (q == bestQ && coding == encodingGzip) {
+			best, bestQ = coding, q
+		}
+	}
+	return best
+}
+
+func (w *compressingWriter) shouldCompress(status int) bool {
+	h := w.Header()
+	if w.encoding == "" {
+		return false
+	}
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		return false
+	}
+	if h.Get("Content-Encoding") != "" || h.Get("Content-Range") != "" || h.Get("Accept-Ranges") != "" {
+		return false
+	}
+	ct := h.Get("Content-Type")
+	for _, t := range compressibleTypes {
+		if strings.HasPrefix(ct, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *compressingWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	h := w.Header()
+	if !strings.Contains(strings.Join(h["Vary"], ","), "Accept-Encoding") {
+		h.Add("Vary", "Accept-Encoding")
+	}
+	if w.shouldCompress(status) {
+		h.Set("Content-Encoding", w.encoding)
+		h.Del("Content-Length")
+		// Compressed representation is not byte-for-byte identical to the original one
+		if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			h.Set("ETag", "W/"+etag)
+		}
+		if w.encoding == encodingGzip {
+			w.compressor = gzip.NewWriter(w.ResponseWriter)
+		} else {
+			w.compressor, _ = flate.NewWriter(w.ResponseWriter, flate.DefaultCompression)
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *compressingWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(p))
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.compressor != nil {
+		return w.compressor.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush sends compressed data buffered so far to the client.
+func (w *compressingWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.compressor != nil {
+		w.compressor.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Close flushes remaining compressed data to the client.
+func (w *compressingWriter) Close() error {
+	if w.compressor != nil {
+		return w.compressor.Close()
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBody = `{"jsonrpc":"2.0","result":{"name":"what"},"id":0}`
+
+func TestNegotiateEncoding(t *testing.T) {
+	cases := map[string]string{
+		"":                         "",
+		"identity":                 "",
+		"gzip":                     "gzip",
+		"deflate":                  "deflate",
+		"gzip, deflate, br":        "gzip",
+		"deflate, gzip":            "gzip",
+		"gzip;q=0.5, deflate":      "deflate",
+		"gzip;q=0, deflate;q=0":    "",
+		"GZIP":                     "gzip",
+		"deflate;q=0.9, gzip;q=xx": "deflate",
+	}
+	for header, encoding := range cases {
+		assert.Equal(t, encoding, negotiateEncoding(header), header)
+	}
+}
+
+func serveCompressed(h http.HandlerFunc, reqHeaders map[string]string) *httptest.ResponseRecorder {
+	r, _ := http.NewRequest("GET", "/", nil)
+	for k, v := range reqHeaders {
+		r.Header.Set(k, v)
+	}
+	rr := httptest.NewRecorder()
+	compressionMiddleware(h).ServeHTTP(rr, r)
+	return rr
+}
+
+func jsonHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("ETag", `"abc"`)
+	w.Write([]byte(testBody))
+}
+
+func TestCompressionMiddlewareGzip(t *testing.T) {
+	rr := serveCompressed(jsonHandler, map[string]string{"Accept-Encoding": "gzip"})
+
+	assert.Equal(t, "gzip", rr.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", rr.Header().Get("Vary"))
+	assert.Equal(t, `W/"abc"`, rr.Header().Get("ETag"))
+
+	gr, err := gzip.NewReader(rr.Body)
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(gr)
+	require.NoError(t, err)
+	assert.Equal(t, testBody, string(body))
+}
+
+func TestCompressionMiddlewareDeflate(t *testing.T) {
+	rr := serveCompressed(jsonHandler, map[string]string{"Accept-Encoding": "deflate"})
+
+	assert.Equal(t, "deflate", rr.Header().Get("Content-Encoding"))
+	body, err := ioutil.ReadAll(flate.NewReader(rr.Body))
+	require.NoError(t, err)
+	assert.Equal(t, testBody, string(body))
+}
+
+func TestCompressionMiddlewareNoAcceptEncoding(t *testing.T) {
+	rr := serveCompressed(jsonHandler, nil)
+
+	assert.Empty(t, rr.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", rr.Header().Get("Vary"))
+	assert.Equal(t, `"abc"`, rr.Header().Get("ETag"))
+	assert.Equal(t, testBody, rr.Body.String())
+}
+
+func TestCompressionMiddlewareFlush(t *testing.T) {
+	streamHandler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testBody))
+		f, ok := w.(http.Flusher)
+		require.True(t, ok)
+		f.Flush()
+	}
+	rr := serveCompressed(streamHandler, map[string]string{"Accept-Encoding": "gzip"})
+	assert.True(t, rr.Flushed)
+
+	gr, err := gzip.NewReader(rr.Body)
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(gr)
+	require.NoError(t, err)
+	assert.Equal(t, testBody, string(body))
+}
+
+func TestCompressionMiddlewarePassthrough(t *testing.T) {
+	videoHandler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "video/mp4")
+		w.Header().Set("Accept-Ranges", "bytes")
+		w.Write([]byte("videodata"))
+	}
+
+	rr := serveCompressed(videoHandler, map[string]string{"Accept-Encoding": "gzip"})
+	assert.Empty(t, rr.Header().Get("Content-Encoding"))
+	assert.Equal(t, "videodata", rr.Body.String())
+
+	rr = serveCompressed(jsonHandler, map[string]string{"Accept-Encoding": "gzip", "Range": "bytes=0-10"})
+	assert.Empty(t, rr.Header().Get("Content-Encoding"))
+	assert.Equal(t, testBody, rr.Body.String())
+
+	notModifiedHandler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotModified)
+	}
+	rr = serveCompressed(notModifiedHandler, map[string]string{"Accept-Encoding": "gzip"})
+	assert.Equal(t, http.StatusNotModified, rr.Code)
+	assert.Empty(t, rr.Header().Get("Content-Encoding"))
+	assert.Empty(t, rr.Body.String())
+}
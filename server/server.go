@@ -81,6 +81,10 @@ func (s *Server) configureRouter() *mux.Router {
 
 	api.InstallRoutes(s.ProxyService, r)
 
+	// Compression goes first so error logging sees uncompressed response body
+	r.Use(compressionMiddleware)
+	// Request ID needs to be set before error logging so it gets attached to Sentry events
+	r.Use(monitor.RequestIDMiddleware)
 	r.Use(monitor.ErrorLoggingMiddleware)
 	r.Use(s.defaultHeadersMiddleware)
 	return r